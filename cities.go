@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"runcast/internal/display"
+	"runcast/internal/i18n"
+	"runcast/internal/weather"
+)
+
+// runCities lists the built-in cities and the custom locations of the config file
+func runCities(args []string) {
+	flags := flag.NewFlagSet("cities", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Println(i18n.T("help.cities"))
+	}
+	output := flags.String("output", "text", i18n.T("flag.output"))
+	lang := flags.String("lang", "", i18n.T("flag.lang"))
+	flags.Parse(args)
+
+	if *lang != "" && !i18n.IsSupported(*lang) {
+		fmt.Println(i18n.T("error.invalid_language", *lang))
+		fmt.Println(i18n.T("hint.valid_language", strings.Join(i18n.SupportedLanguages(), ", ")))
+		return
+	}
+	cfg := loadConfig(*lang)
+
+	cities := weather.ListCities(cfg)
+	switch *output {
+	case "json":
+		if err := display.RenderCitiesJSON(os.Stdout, cities); err != nil {
+			log.Fatal(err)
+		}
+	case "text":
+		display.RenderCities(os.Stdout, cities)
+	default:
+		fmt.Println(i18n.T("error.invalid_output", *output))
+		fmt.Println(i18n.T("hint.valid_output"))
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"runcast/internal/config"
+	"runcast/internal/i18n"
+	"runcast/internal/weather"
+)
+
+// runConfig shows which config file is in use, whether it is valid and what it sets
+func runConfig(args []string) {
+	flags := flag.NewFlagSet("config", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Println(i18n.T("help.config"))
+	}
+	lang := flags.String("lang", "", i18n.T("flag.lang"))
+	flags.Parse(args)
+
+	if *lang != "" && !i18n.IsSupported(*lang) {
+		fmt.Println(i18n.T("error.invalid_language", *lang))
+		fmt.Println(i18n.T("hint.valid_language", strings.Join(i18n.SupportedLanguages(), ", ")))
+		return
+	}
+
+	path := config.FindConfigPath()
+	cfg, err := config.LoadConfig()
+	configLanguage := ""
+	if err == nil {
+		configLanguage = cfg.Language
+	}
+	i18n.SetLanguage(i18n.DetectLanguage(*lang, configLanguage, os.Getenv))
+
+	if path == "" {
+		fmt.Println(i18n.T("config.not_found"))
+		for _, candidate := range config.ConfigPaths() {
+			fmt.Printf("   %s\n", candidate)
+		}
+		return
+	}
+	fmt.Println(i18n.T("config.path", path))
+	if err != nil {
+		fmt.Println(i18n.T("config.invalid", err))
+		os.Exit(1)
+	}
+	fmt.Println(i18n.T("config.valid"))
+
+	if cfg.Language != "" {
+		fmt.Println(i18n.T("config.language", cfg.Language))
+	}
+	if names := cfg.GetCustomLocationNames(); len(names) > 0 {
+		sort.Strings(names)
+		fmt.Println(i18n.T("config.locations", strings.Join(names, ", ")))
+	}
+	if periods := cfg.TimePeriods(); len(periods) > 0 {
+		ranges := make([]string, 0, len(periods))
+		for _, period := range periods {
+			ranges = append(ranges, fmt.Sprintf("%s (%s)", period.Key, weather.FormatPeriodRange(period)))
+		}
+		fmt.Println(i18n.T("config.periods", strings.Join(ranges, ", ")))
+	}
+	if cfg.Profile.Pace != "" {
+		fmt.Println(i18n.T("config.pace", cfg.Profile.Pace))
+	}
+	if cfg.Profile.Distance != "" {
+		fmt.Println(i18n.T("config.distance", cfg.Profile.Distance))
+	}
+	if cfg.Route.Bearing != "" {
+		fmt.Println(i18n.T("config.bearing", cfg.Route.Bearing))
+	}
+}
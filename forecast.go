@@ -0,0 +1,281 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/display"
+	"runcast/internal/i18n"
+	"runcast/internal/report"
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// forecastOptions holds the flags of the forecast commands
+type forecastOptions struct {
+	city      *string
+	lat       *string
+	lon       *string
+	timeOfDay *string
+	dateSpec  *string
+	distance  *string
+	offline   *bool
+	output    *string
+	lang      *string
+	pace      *string
+	bearing   *string
+	best      *bool
+	days      *int
+	top       *int
+	help      *bool
+}
+
+// addForecastFlags defines the forecast flags on flags.
+// Without planning only the current conditions can be assessed, so the date, time and best start time flags are left out.
+func addForecastFlags(flags *flag.FlagSet, planning bool) *forecastOptions {
+	opts := &forecastOptions{
+		city:      flags.String("city", "tokyo", i18n.T("flag.city")),
+		lat:       flags.String("lat", "", i18n.T("flag.lat")),
+		lon:       flags.String("lon", "", i18n.T("flag.lon")),
+		timeOfDay: new(string),
+		dateSpec:  new(string),
+		distance:  flags.String("distance", "", i18n.T("flag.distance")),
+		offline:   flags.Bool("offline", false, i18n.T("flag.offline")),
+		output:    flags.String("output", "text", i18n.T("flag.output")),
+		lang:      flags.String("lang", "", i18n.T("flag.lang")),
+		pace:      flags.String("pace", "", i18n.T("flag.pace")),
+		bearing:   flags.String("bearing", "", i18n.T("flag.bearing")),
+		best:      new(bool),
+		days:      new(int),
+		top:       new(int),
+		help:      flags.Bool("help", false, i18n.T("flag.help")),
+	}
+	if planning {
+		opts.timeOfDay = flags.String("time", "", i18n.T("flag.time"))
+		opts.dateSpec = flags.String("date", "", i18n.T("flag.date"))
+		opts.best = flags.Bool("best", false, i18n.T("flag.best"))
+		opts.days = flags.Int("days", report.DefaultBestDays, i18n.T("flag.days"))
+		opts.top = flags.Int("top", report.DefaultBestTop, i18n.T("flag.top"))
+	}
+	return opts
+}
+
+// runNow assesses the current conditions
+func runNow(args []string) {
+	flags := flag.NewFlagSet("now", flag.ExitOnError)
+	usage := func() {
+		fmt.Println(i18n.T("help.now"))
+	}
+	flags.Usage = usage
+	opts := addForecastFlags(flags, false)
+	flags.Parse(args)
+	runForecast(opts, usage)
+}
+
+// runPlan assesses a date, a time period or the best start times, today when none is given
+func runPlan(args []string) {
+	flags := flag.NewFlagSet("plan", flag.ExitOnError)
+	usage := func() {
+		fmt.Println(i18n.T("help.plan"))
+	}
+	flags.Usage = usage
+	opts := addForecastFlags(flags, true)
+	flags.Parse(args)
+	if *opts.dateSpec == "" && *opts.timeOfDay == "" && !*opts.best {
+		*opts.dateSpec = "today"
+	}
+	runForecast(opts, usage)
+}
+
+// runForecast fetches the forecast and writes the assessment selected by opts
+func runForecast(opts *forecastOptions, usage func()) {
+	// Validate language
+	if *opts.lang != "" && !i18n.IsSupported(*opts.lang) {
+		fmt.Println(i18n.T("error.invalid_language", *opts.lang))
+		fmt.Println(i18n.T("hint.valid_language", strings.Join(i18n.SupportedLanguages(), ", ")))
+		return
+	}
+
+	cfg := loadConfig(*opts.lang)
+
+	// Show help if requested
+	if *opts.help {
+		usage()
+		return
+	}
+
+	// Validate output format
+	if *opts.output != "text" && *opts.output != "json" {
+		fmt.Println(i18n.T("error.invalid_output", *opts.output))
+		fmt.Println(i18n.T("hint.valid_output"))
+		return
+	}
+
+	// Distance category from the flag, falling back to the profile's preferred distance
+	distanceKey := *opts.distance
+	if distanceKey == "" && cfg != nil {
+		distanceKey = cfg.Profile.Distance
+	}
+	var distanceCategory *types.DistanceCategory
+	if distanceKey != "" {
+		distanceCategory = running.GetDistanceCategory(distanceKey)
+		if distanceCategory == nil {
+			fmt.Println(i18n.T("error.invalid_distance", distanceKey))
+			fmt.Println(i18n.T("hint.valid_distance"))
+			return
+		}
+	}
+
+	// Target pace from the flag, falling back to the profile
+	var pace time.Duration
+	if *opts.pace != "" {
+		var err error
+		pace, err = config.ParsePace(*opts.pace)
+		if err != nil {
+			fmt.Println(i18n.T("error.invalid_pace", *opts.pace))
+			fmt.Println(i18n.T("hint.valid_pace"))
+			return
+		}
+	} else if cfg != nil {
+		pace = cfg.Profile.PaceDuration()
+	}
+	var profile types.RunnerProfile
+	if cfg != nil {
+		profile = cfg.Profile.Runner()
+	}
+
+	// Route bearing from the flag, falling back to the config
+	var route *types.Route
+	if *opts.bearing != "" {
+		bearing, err := config.ParseBearing(*opts.bearing)
+		if err != nil {
+			fmt.Println(i18n.T("error.invalid_bearing", *opts.bearing))
+			fmt.Println(i18n.T("hint.valid_bearing"))
+			return
+		}
+		route = &types.Route{Bearing: bearing}
+	} else if cfg != nil {
+		route = cfg.Route.Route()
+	}
+
+	provider := newProvider(cfg, *opts.offline)
+
+	// Coordinates from -lat and -lon take the place of the city
+	city := *opts.city
+	if *opts.lat != "" || *opts.lon != "" {
+		if *opts.lat == "" || *opts.lon == "" {
+			fmt.Println(i18n.T("error.lat_lon_pair"))
+			return
+		}
+		city = "geo:" + *opts.lat + "," + *opts.lon
+	}
+
+	// Get city coordinates
+	coord, err := weather.ResolveCityCoordinate(city, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Resolve date specification if provided, in the location's timezone
+	now := time.Now().In(weather.ExpectedLocation(*coord))
+	dayOffset := 0
+	if *opts.dateSpec != "" {
+		dayOffset, err = weather.ParseDateOffset(*opts.dateSpec, now)
+		if err != nil {
+			fmt.Println(err)
+			fmt.Println(i18n.T("hint.valid_date"))
+			return
+		}
+	}
+
+	// Validate time specification if provided
+	if *opts.timeOfDay != "" && !weather.ValidateTimeSpec(*opts.timeOfDay) {
+		fmt.Println(i18n.T("error.invalid_time", *opts.timeOfDay))
+		fmt.Println(i18n.T("hint.valid_time", strings.Join(weather.GetTimePeriodKeys(), ", ")))
+		return
+	}
+
+	// Validate the best start time finder options
+	if *opts.best {
+		if *opts.dateSpec != "" {
+			fmt.Println(i18n.T("error.best_with_date"))
+			return
+		}
+		if *opts.days < 1 || *opts.days > weather.MaxForecastDays {
+			fmt.Println(i18n.T("error.invalid_days", *opts.days))
+			fmt.Println(i18n.T("hint.valid_days", weather.MaxForecastDays))
+			return
+		}
+		if *opts.top < 1 {
+			fmt.Println(i18n.T("error.invalid_top", *opts.top))
+			fmt.Println(i18n.T("hint.valid_top"))
+			return
+		}
+	}
+
+	// Determine required forecast days
+	requiredDays := 1 // Default to 1 day for running forecasts
+	if *opts.best {
+		requiredDays = *opts.days
+	}
+	if *opts.dateSpec != "" {
+		// Ensure we have enough data for the requested date
+		if requiredDays <= dayOffset {
+			requiredDays = dayOffset + 1
+		}
+	}
+	if requiredDays > weather.MaxForecastDays {
+		fmt.Println(i18n.T("error.forecast_horizon", weather.MaxForecastDays-1))
+		return
+	}
+
+	// Get weather data
+	timezone := weather.LocationTimezone(*coord)
+	weatherData, err := provider.Forecast(coord.Lat, coord.Lon, timezone, requiredDays)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Get air quality data
+	airQuality, err := provider.AirQuality(coord.Lat, coord.Lon, timezone, requiredDays)
+	if err != nil {
+		// Air quality data is optional, continue without it
+		fmt.Fprintln(os.Stderr, i18n.T("warning.air_quality_fetch", err))
+		airQuality = nil
+	}
+
+	// Analyse the forecast independently of the output format
+	runningReport, err := report.Build(report.Request{
+		Location:  *coord,
+		DateSpec:  *opts.dateSpec,
+		DayOffset: dayOffset,
+		TimeOfDay: *opts.timeOfDay,
+		Days:      requiredDays,
+		Distance:  distanceCategory,
+		Pace:      pace,
+		Profile:   profile,
+		Route:     route,
+		Best:      *opts.best,
+		Top:       *opts.top,
+		Now:       now,
+	}, weatherData, airQuality)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if *opts.output == "json" {
+		if err := display.RenderJSON(os.Stdout, runningReport); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	display.RenderText(os.Stdout, runningReport)
+}
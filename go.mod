@@ -2,4 +2,4 @@ module runcast
 
 go 1.24.4
 
-require github.com/BurntSushi/toml v1.5.0 // indirect
+require github.com/BurntSushi/toml v1.5.0
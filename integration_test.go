@@ -18,22 +18,25 @@ func TestGetWeatherIntegration(t *testing.T) {
 		name string
 		lat  float64
 		lon  float64
+		days int
 	}{
 		{
 			name: "Tokyo running weather",
 			lat:  35.6762,
 			lon:  139.6503,
+			days: 1,
 		},
 		{
-			name: "Osaka running weather",
+			name: "Osaka running weather for 3 days",
 			lat:  34.6937,
 			lon:  135.5023,
+			days: 3,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			weatherData, err := weather.GetWeather(tt.lat, tt.lon)
+			weatherData, err := weather.GetWeather(tt.lat, tt.lon, weather.DefaultTimezone, tt.days)
 			if err != nil {
 				t.Fatalf("API call failed: %v", err)
 			}
@@ -55,7 +58,7 @@ func TestGetWeatherIntegration(t *testing.T) {
 			}
 
 			// Verify we got at least the requested number of days (or fewer if API limits)
-			expectedDays := 1
+			expectedDays := tt.days
 			if len(weatherData.Daily.Time) < expectedDays {
 				t.Logf("Warning: Expected %d days, got %d days", expectedDays, len(weatherData.Daily.Time))
 			}
@@ -84,7 +87,7 @@ func TestGetWeatherWithInvalidCoordinates(t *testing.T) {
 	}
 
 	// Test with coordinates that are way out of range
-	_, err := weather.GetWeather(999.0, 999.0)
+	_, err := weather.GetWeather(999.0, 999.0, weather.DefaultTimezone, 1)
 	
 	// The API might still return data or give an error
 	// We mainly want to ensure our code doesn't crash
@@ -107,7 +110,7 @@ func TestFullWorkflowIntegration(t *testing.T) {
 		t.Fatalf("Failed to get coordinates for %s: %v", city, err)
 	}
 
-	weatherData, err := weather.GetWeather(coord.Lat, coord.Lon)
+	weatherData, err := weather.GetWeather(coord.Lat, coord.Lon, weather.LocationTimezone(*coord), 1)
 	if err != nil {
 		t.Fatalf("Failed to get weather for %s: %v", city, err)
 	}
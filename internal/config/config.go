@@ -2,65 +2,700 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezones of custom locations must load on hosts without a zoneinfo database
 
 	"github.com/BurntSushi/toml"
+	"runcast/internal/i18n"
 	"runcast/internal/types"
 )
 
 // Config represents the configuration file structure
 type Config struct {
+	Language  string                          `toml:"language"` // message language such as "ja" or "en"
 	Locations map[string]types.CityCoordinate `toml:"locations"`
+	Provider  ProviderConfig                  `toml:"provider"`
+	Cache     CacheConfig                     `toml:"cache"`
+	Profile   ProfileConfig                   `toml:"profile"`
+	Route     RouteConfig                     `toml:"route"`
+	Periods   map[string]PeriodConfig         `toml:"periods"` // custom time periods usable with -time
+	Scoring   ScoringConfig                   `toml:"scoring"`
 }
 
+// ProviderConfig represents weather data provider settings
+type ProviderConfig struct {
+	ForecastURL   string `toml:"forecast_url"`
+	AirQualityURL string `toml:"air_quality_url"`
+	Timeout       int    `toml:"timeout"` // seconds
+}
+
+// CacheConfig represents response cache settings
+type CacheConfig struct {
+	Disabled bool   `toml:"disabled"`
+	Dir      string `toml:"dir"`
+	TTL      string `toml:"ttl"` // duration such as "30m" or "2h"
+}
+
+// TTLDuration returns the configured TTL, or fallback when unset
+func (c CacheConfig) TTLDuration(fallback time.Duration) time.Duration {
+	if c.TTL == "" {
+		return fallback
+	}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return fallback
+	}
+	return ttl
+}
+
+// ProfileConfig represents the runner's personal settings
+type ProfileConfig struct {
+	Pace             string  `toml:"pace"`              // typical pace per km such as "5:30"
+	Distance         string  `toml:"distance"`          // preferred distance used when -distance is omitted
+	BodyWeight       float64 `toml:"body_weight"`       // kg
+	SweatRate        float64 `toml:"sweat_rate"`        // L/h measured in mild conditions
+	HeatTolerance    string  `toml:"heat_tolerance"`    // "low", "normal" or "high"
+	ColdTolerance    string  `toml:"cold_tolerance"`    // "low", "normal" or "high"
+	SensitiveAirways bool    `toml:"sensitive_airways"` // asthma or a pollen allergy
+}
+
+// Runner returns the profile values used by the assessments and running plans
+func (p ProfileConfig) Runner() types.RunnerProfile {
+	return types.RunnerProfile{
+		BodyWeight:       p.BodyWeight,
+		SweatRate:        p.SweatRate,
+		HeatTolerance:    p.HeatTolerance,
+		ColdTolerance:    p.ColdTolerance,
+		SensitiveAirways: p.SensitiveAirways,
+	}
+}
+
+// tolerances are the accepted heat and cold tolerances of the profile
+var tolerances = []string{"low", "normal", "high"}
+
+// isTolerance reports whether value is an accepted tolerance
+func isTolerance(value string) bool {
+	for _, tolerance := range tolerances {
+		if value == tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDistanceKey reports whether key is a distance category such as "10k"
+func IsDistanceKey(key string) bool {
+	_, exists := types.DefaultScoring().Distances[key]
+	return exists
+}
+
+// PaceDuration returns the configured pace per km, or zero when unset or invalid
+func (p ProfileConfig) PaceDuration() time.Duration {
+	if p.Pace == "" {
+		return 0
+	}
+	pace, err := ParsePace(p.Pace)
+	if err != nil {
+		return 0
+	}
+	return pace
+}
+
+// RouteConfig represents the runner's usual course
+type RouteConfig struct {
+	Bearing string `toml:"bearing"` // outbound direction such as "NE" or "45"
+}
+
+// Route returns the configured route, or nil when no bearing is set or it is invalid
+func (r RouteConfig) Route() *types.Route {
+	if r.Bearing == "" {
+		return nil
+	}
+	bearing, err := ParseBearing(r.Bearing)
+	if err != nil {
+		return nil
+	}
+	return &types.Route{Bearing: bearing}
+}
+
+// PeriodConfig represents a custom time period such as lunch = {start = "12:00", end = "13:30"}
+type PeriodConfig struct {
+	Start string `toml:"start"` // "H:MM" or "HH:MM"
+	End   string `toml:"end"`
+	Name  string `toml:"name"` // display name, the period key when empty
+}
+
+// TimePeriods returns the configured time periods sorted by key, skipping invalid ones
+func (c *Config) TimePeriods() []types.TimePeriod {
+	keys := make([]string, 0, len(c.Periods))
+	for key := range c.Periods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	periods := make([]types.TimePeriod, 0, len(keys))
+	for _, key := range keys {
+		period, err := c.Periods[key].TimePeriod(key)
+		if err != nil {
+			continue
+		}
+		periods = append(periods, period)
+	}
+	return periods
+}
+
+// TimePeriod converts the configured period with the given key
+func (p PeriodConfig) TimePeriod(key string) (types.TimePeriod, error) {
+	start, err := ParseClock(p.Start)
+	if err != nil {
+		return types.TimePeriod{}, err
+	}
+	end, err := ParseClock(p.End)
+	if err != nil {
+		return types.TimePeriod{}, err
+	}
+	if end <= start {
+		return types.TimePeriod{}, fmt.Errorf("end must be after start within the same day: %s-%s", p.Start, p.End)
+	}
+
+	name := p.Name
+	if name == "" {
+		name = key
+	}
+	return types.TimePeriod{
+		Key:         key,
+		DisplayName: name,
+		StartHour:   start / 60,
+		StartMinute: start % 60,
+		EndHour:     end / 60,
+		EndMinute:   end % 60,
+	}, nil
+}
+
+// ScoringConfig represents overrides of the built-in scoring; unset values keep their defaults
+type ScoringConfig struct {
+	ColdSevere *float64 `toml:"cold_severe"` // °C
+	Cold       *float64 `toml:"cold"`
+	Cool       *float64 `toml:"cool"`
+	Hot        *float64 `toml:"hot"`
+	VeryHot    *float64 `toml:"very_hot"`
+	Wind       *float64 `toml:"wind"` // m/s
+	WindStrong *float64 `toml:"wind_strong"`
+	Rain       *float64 `toml:"rain"` // mm
+	RainHeavy  *float64 `toml:"rain_heavy"`
+
+	WBGTCaution        *float64 `toml:"wbgt_caution"` // °C
+	WBGTWarning        *float64 `toml:"wbgt_warning"`
+	WBGTSevere         *float64 `toml:"wbgt_severe"`
+	WBGTDanger         *float64 `toml:"wbgt_danger"`
+	DewPointHumid      *float64 `toml:"dew_point_humid"` // °C
+	DewPointMuggy      *float64 `toml:"dew_point_muggy"`
+	DewPointOppressive *float64 `toml:"dew_point_oppressive"`
+	DewPointDangerous  *float64 `toml:"dew_point_dangerous"`
+	PM25Standard       *float64 `toml:"pm25_standard"` // μg/m³
+	PM25High           *float64 `toml:"pm25_high"`
+	PM25Alert          *float64 `toml:"pm25_alert"`
+	Mild               *float64 `toml:"mild"` // °C
+	Warm               *float64 `toml:"warm"`
+	Summer             *float64 `toml:"summer"`
+	LongRunFuel        *float64 `toml:"long_run_fuel"` // °C
+	LongRunHot         *float64 `toml:"long_run_hot"`
+	MarathonHot        *float64 `toml:"marathon_hot"`
+	UVModerate         *float64 `toml:"uv_moderate"` // UV index
+	UVHigh             *float64 `toml:"uv_high"`
+	UVVeryHigh         *float64 `toml:"uv_very_high"`
+	UVExtreme          *float64 `toml:"uv_extreme"`
+	Gust               *float64 `toml:"gust"` // m/s
+	GustStrong         *float64 `toml:"gust_strong"`
+	GustDangerous      *float64 `toml:"gust_dangerous"`
+	DryProbability     *float64 `toml:"dry_probability"` // %
+	RainLikely         *float64 `toml:"rain_likely"`
+	TemperatureRise    *float64 `toml:"temperature_rise"` // °C
+
+	Penalties PenaltyConfig                    `toml:"penalties"`
+	Levels    LevelConfig                      `toml:"levels"`
+	Distances map[string]DistancePenaltyConfig `toml:"distance"` // by distance category key
+}
+
+// PenaltyConfig represents overrides of the points taken off for each weather band
+type PenaltyConfig struct {
+	ColdSevere         *int `toml:"cold_severe"`
+	Cold               *int `toml:"cold"`
+	Cool               *int `toml:"cool"`
+	WBGTWarning        *int `toml:"wbgt_warning"`
+	WBGTSevere         *int `toml:"wbgt_severe"`
+	WBGTDanger         *int `toml:"wbgt_danger"`
+	HumidityMuggy      *int `toml:"humidity_muggy"`
+	HumidityOppressive *int `toml:"humidity_oppressive"`
+	HumidityDangerous  *int `toml:"humidity_dangerous"`
+	Wind               *int `toml:"wind"`
+	WindStrong         *int `toml:"wind_strong"`
+	RainLight          *int `toml:"rain_light"`
+	Rain               *int `toml:"rain"`
+	RainHeavy          *int `toml:"rain_heavy"`
+	Showers            *int `toml:"showers"`
+	Thunderstorm       *int `toml:"thunderstorm"`
+	DustLow            *int `toml:"dust_low"`
+	DustModerate       *int `toml:"dust_moderate"`
+	DustHigh           *int `toml:"dust_high"`
+	DustVeryHigh       *int `toml:"dust_very_high"`
+	PM25Elevated       *int `toml:"pm25_elevated"`
+	PM25High           *int `toml:"pm25_high"`
+	PM25Alert          *int `toml:"pm25_alert"`
+	UVHigh             *int `toml:"uv_high"`
+	UVVeryHigh         *int `toml:"uv_very_high"`
+	UVExtreme          *int `toml:"uv_extreme"`
+	Gust               *int `toml:"gust"`
+	GustStrong         *int `toml:"gust_strong"`
+	GustDangerous      *int `toml:"gust_dangerous"`
+	RainChance         *int `toml:"rain_chance"`
+	TemperatureRise    *int `toml:"temperature_rise"`
+}
+
+// LevelConfig represents overrides of the lowest score of each level
+type LevelConfig struct {
+	Excellent *int `toml:"excellent"`
+	Good      *int `toml:"good"`
+	Fair      *int `toml:"fair"`
+	Caution   *int `toml:"caution"`
+}
+
+// DistancePenaltyConfig represents overrides of the extra penalties of a distance category
+type DistancePenaltyConfig struct {
+	Temperature *int `toml:"temperature"`
+	Humidity    *int `toml:"humidity"`
+	Wind        *int `toml:"wind"`
+	HeatIndex   *int `toml:"heat_index"`
+}
+
+// Scoring returns the built-in scoring with the configured overrides applied
+func (s ScoringConfig) Scoring() types.Scoring {
+	scoring := types.DefaultScoring()
+
+	thresholds := &scoring.Thresholds
+	override(&thresholds.ColdSevere, s.ColdSevere)
+	override(&thresholds.Cold, s.Cold)
+	override(&thresholds.Cool, s.Cool)
+	override(&thresholds.Hot, s.Hot)
+	override(&thresholds.VeryHot, s.VeryHot)
+	override(&thresholds.Wind, s.Wind)
+	override(&thresholds.WindStrong, s.WindStrong)
+	override(&thresholds.Rain, s.Rain)
+	override(&thresholds.RainHeavy, s.RainHeavy)
+	override(&thresholds.WBGTCaution, s.WBGTCaution)
+	override(&thresholds.WBGTWarning, s.WBGTWarning)
+	override(&thresholds.WBGTSevere, s.WBGTSevere)
+	override(&thresholds.WBGTDanger, s.WBGTDanger)
+	override(&thresholds.DewPointHumid, s.DewPointHumid)
+	override(&thresholds.DewPointMuggy, s.DewPointMuggy)
+	override(&thresholds.DewPointOppressive, s.DewPointOppressive)
+	override(&thresholds.DewPointDangerous, s.DewPointDangerous)
+	override(&thresholds.PM25Standard, s.PM25Standard)
+	override(&thresholds.PM25High, s.PM25High)
+	override(&thresholds.PM25Alert, s.PM25Alert)
+	override(&thresholds.Mild, s.Mild)
+	override(&thresholds.Warm, s.Warm)
+	override(&thresholds.Summer, s.Summer)
+	override(&thresholds.LongRunFuel, s.LongRunFuel)
+	override(&thresholds.LongRunHot, s.LongRunHot)
+	override(&thresholds.MarathonHot, s.MarathonHot)
+	override(&thresholds.UVModerate, s.UVModerate)
+	override(&thresholds.UVHigh, s.UVHigh)
+	override(&thresholds.UVVeryHigh, s.UVVeryHigh)
+	override(&thresholds.UVExtreme, s.UVExtreme)
+	override(&thresholds.Gust, s.Gust)
+	override(&thresholds.GustStrong, s.GustStrong)
+	override(&thresholds.GustDangerous, s.GustDangerous)
+	override(&thresholds.DryProbability, s.DryProbability)
+	override(&thresholds.RainLikely, s.RainLikely)
+	override(&thresholds.TemperatureRise, s.TemperatureRise)
+
+	penalties := &scoring.Penalties
+	override(&penalties.ColdSevere, s.Penalties.ColdSevere)
+	override(&penalties.Cold, s.Penalties.Cold)
+	override(&penalties.Cool, s.Penalties.Cool)
+	override(&penalties.WBGTWarning, s.Penalties.WBGTWarning)
+	override(&penalties.WBGTSevere, s.Penalties.WBGTSevere)
+	override(&penalties.WBGTDanger, s.Penalties.WBGTDanger)
+	override(&penalties.HumidityMuggy, s.Penalties.HumidityMuggy)
+	override(&penalties.HumidityOppressive, s.Penalties.HumidityOppressive)
+	override(&penalties.HumidityDangerous, s.Penalties.HumidityDangerous)
+	override(&penalties.Wind, s.Penalties.Wind)
+	override(&penalties.WindStrong, s.Penalties.WindStrong)
+	override(&penalties.RainLight, s.Penalties.RainLight)
+	override(&penalties.Rain, s.Penalties.Rain)
+	override(&penalties.RainHeavy, s.Penalties.RainHeavy)
+	override(&penalties.Showers, s.Penalties.Showers)
+	override(&penalties.Thunderstorm, s.Penalties.Thunderstorm)
+	override(&penalties.DustLow, s.Penalties.DustLow)
+	override(&penalties.DustModerate, s.Penalties.DustModerate)
+	override(&penalties.DustHigh, s.Penalties.DustHigh)
+	override(&penalties.DustVeryHigh, s.Penalties.DustVeryHigh)
+	override(&penalties.PM25Elevated, s.Penalties.PM25Elevated)
+	override(&penalties.PM25High, s.Penalties.PM25High)
+	override(&penalties.PM25Alert, s.Penalties.PM25Alert)
+	override(&penalties.UVHigh, s.Penalties.UVHigh)
+	override(&penalties.UVVeryHigh, s.Penalties.UVVeryHigh)
+	override(&penalties.UVExtreme, s.Penalties.UVExtreme)
+	override(&penalties.Gust, s.Penalties.Gust)
+	override(&penalties.GustStrong, s.Penalties.GustStrong)
+	override(&penalties.GustDangerous, s.Penalties.GustDangerous)
+	override(&penalties.RainChance, s.Penalties.RainChance)
+	override(&penalties.TemperatureRise, s.Penalties.TemperatureRise)
+
+	levels := &scoring.Levels
+	override(&levels.Excellent, s.Levels.Excellent)
+	override(&levels.Good, s.Levels.Good)
+	override(&levels.Fair, s.Levels.Fair)
+	override(&levels.Caution, s.Levels.Caution)
+
+	for key, distance := range s.Distances {
+		penalties, exists := scoring.Distances[key]
+		if !exists {
+			continue
+		}
+		override(&penalties.Temperature, distance.Temperature)
+		override(&penalties.Humidity, distance.Humidity)
+		override(&penalties.Wind, distance.Wind)
+		override(&penalties.HeatIndex, distance.HeatIndex)
+		scoring.Distances[key] = penalties
+	}
+	return scoring
+}
+
+// override replaces value with the configured one when it is set
+func override[T any](value *T, configured *T) {
+	if configured != nil {
+		*value = *configured
+	}
+}
+
+// MaxPenalty is the largest penalty accepted in the scoring, the whole score
+const MaxPenalty = 100
+
+// MaxTemperature is the highest temperature threshold (°C) accepted in the scoring
+const MaxTemperature = 45.0
+
+// validateScoring checks that the thresholds and level cutoffs keep their order and the penalties fit the score
+func validateScoring(s ScoringConfig) error {
+	for key := range s.Distances {
+		if !IsDistanceKey(key) {
+			return fmt.Errorf("scoring distance must be one of 5k, 10k, half or full: %s", key)
+		}
+	}
+
+	scoring := s.Scoring()
+	thresholds := scoring.Thresholds
+	if thresholds.ColdSevere >= thresholds.Cold || thresholds.Cold >= thresholds.Cool {
+		return fmt.Errorf("scoring temperatures must rise from cold_severe to cool: %g, %g, %g", thresholds.ColdSevere, thresholds.Cold, thresholds.Cool)
+	}
+	if thresholds.Hot >= thresholds.VeryHot {
+		return fmt.Errorf("scoring hot must be below very_hot: %g, %g", thresholds.Hot, thresholds.VeryHot)
+	}
+	if thresholds.Wind < 0 || thresholds.Wind >= thresholds.WindStrong {
+		return fmt.Errorf("scoring wind must be from 0 and below wind_strong: %g, %g", thresholds.Wind, thresholds.WindStrong)
+	}
+	if thresholds.Rain < 0 || thresholds.Rain >= thresholds.RainHeavy {
+		return fmt.Errorf("scoring rain must be from 0 and below rain_heavy: %g, %g", thresholds.Rain, thresholds.RainHeavy)
+	}
+	if thresholds.WBGTCaution < 0 || thresholds.WBGTCaution >= thresholds.WBGTWarning || thresholds.WBGTWarning >= thresholds.WBGTSevere || thresholds.WBGTSevere >= thresholds.WBGTDanger {
+		return fmt.Errorf("scoring wbgt must rise from 0 and wbgt_caution to wbgt_danger: %g, %g, %g, %g", thresholds.WBGTCaution, thresholds.WBGTWarning, thresholds.WBGTSevere, thresholds.WBGTDanger)
+	}
+	if thresholds.DewPointHumid >= thresholds.DewPointMuggy || thresholds.DewPointMuggy >= thresholds.DewPointOppressive || thresholds.DewPointOppressive >= thresholds.DewPointDangerous {
+		return fmt.Errorf("scoring dew points must rise from dew_point_humid to dew_point_dangerous: %g, %g, %g, %g", thresholds.DewPointHumid, thresholds.DewPointMuggy, thresholds.DewPointOppressive, thresholds.DewPointDangerous)
+	}
+	if thresholds.PM25Standard < 0 || thresholds.PM25Standard >= thresholds.PM25High || thresholds.PM25High >= thresholds.PM25Alert {
+		return fmt.Errorf("scoring pm25 must rise from 0 and pm25_standard to pm25_alert: %g, %g, %g", thresholds.PM25Standard, thresholds.PM25High, thresholds.PM25Alert)
+	}
+	if thresholds.Cool > thresholds.Mild || thresholds.Mild >= thresholds.Warm || thresholds.Warm >= thresholds.Summer {
+		return fmt.Errorf("scoring clothing temperatures must rise from cool to summer: %g, %g, %g, %g", thresholds.Cool, thresholds.Mild, thresholds.Warm, thresholds.Summer)
+	}
+	for key, temperature := range map[string]float64{
+		"long_run_fuel": thresholds.LongRunFuel,
+		"long_run_hot":  thresholds.LongRunHot,
+		"marathon_hot":  thresholds.MarathonHot,
+	} {
+		if temperature < thresholds.ColdSevere || temperature > MaxTemperature {
+			return fmt.Errorf("scoring %s must be between cold_severe and %g: %g", key, MaxTemperature, temperature)
+		}
+	}
+	if thresholds.UVModerate <= 0 || thresholds.UVModerate >= thresholds.UVHigh || thresholds.UVHigh >= thresholds.UVVeryHigh || thresholds.UVVeryHigh >= thresholds.UVExtreme {
+		return fmt.Errorf("scoring uv must rise from above 0 and uv_moderate to uv_extreme: %g, %g, %g, %g", thresholds.UVModerate, thresholds.UVHigh, thresholds.UVVeryHigh, thresholds.UVExtreme)
+	}
+	if thresholds.Gust <= 0 || thresholds.Gust >= thresholds.GustStrong || thresholds.GustStrong >= thresholds.GustDangerous {
+		return fmt.Errorf("scoring gusts must rise from above 0 and gust to gust_dangerous: %g, %g, %g", thresholds.Gust, thresholds.GustStrong, thresholds.GustDangerous)
+	}
+	if thresholds.DryProbability <= 0 || thresholds.DryProbability > thresholds.RainLikely || thresholds.RainLikely > 100 {
+		return fmt.Errorf("scoring dry_probability must be above 0 and up to rain_likely, at most 100: %g, %g", thresholds.DryProbability, thresholds.RainLikely)
+	}
+	if thresholds.TemperatureRise <= 0 || thresholds.TemperatureRise > MaxTemperature {
+		return fmt.Errorf("scoring temperature_rise must be above 0 and at most %g: %g", MaxTemperature, thresholds.TemperatureRise)
+	}
+
+	penalties := scoring.Penalties
+	for key, penalty := range map[string]int{
+		"cold_severe":         penalties.ColdSevere,
+		"cold":                penalties.Cold,
+		"cool":                penalties.Cool,
+		"wbgt_warning":        penalties.WBGTWarning,
+		"wbgt_severe":         penalties.WBGTSevere,
+		"wbgt_danger":         penalties.WBGTDanger,
+		"humidity_muggy":      penalties.HumidityMuggy,
+		"humidity_oppressive": penalties.HumidityOppressive,
+		"humidity_dangerous":  penalties.HumidityDangerous,
+		"wind":                penalties.Wind,
+		"wind_strong":         penalties.WindStrong,
+		"rain_light":          penalties.RainLight,
+		"rain":                penalties.Rain,
+		"rain_heavy":          penalties.RainHeavy,
+		"showers":             penalties.Showers,
+		"thunderstorm":        penalties.Thunderstorm,
+		"dust_low":            penalties.DustLow,
+		"dust_moderate":       penalties.DustModerate,
+		"dust_high":           penalties.DustHigh,
+		"dust_very_high":      penalties.DustVeryHigh,
+		"pm25_elevated":       penalties.PM25Elevated,
+		"pm25_high":           penalties.PM25High,
+		"pm25_alert":          penalties.PM25Alert,
+		"uv_high":             penalties.UVHigh,
+		"uv_very_high":        penalties.UVVeryHigh,
+		"uv_extreme":          penalties.UVExtreme,
+		"gust":                penalties.Gust,
+		"gust_strong":         penalties.GustStrong,
+		"gust_dangerous":      penalties.GustDangerous,
+		"rain_chance":         penalties.RainChance,
+		"temperature_rise":    penalties.TemperatureRise,
+	} {
+		if penalty < 0 || penalty > MaxPenalty {
+			return fmt.Errorf("scoring penalty %s must be between 0 and %d: %d", key, MaxPenalty, penalty)
+		}
+	}
+	for key, distance := range scoring.Distances {
+		for _, penalty := range []int{distance.Temperature, distance.Humidity, distance.Wind, distance.HeatIndex} {
+			if penalty < 0 || penalty > MaxPenalty {
+				return fmt.Errorf("scoring distance %s penalties must be between 0 and %d: %d", key, MaxPenalty, penalty)
+			}
+		}
+	}
+
+	levels := scoring.Levels
+	if levels.Excellent > 100 || levels.Excellent <= levels.Good || levels.Good <= levels.Fair || levels.Fair <= levels.Caution || levels.Caution <= 0 {
+		return fmt.Errorf("scoring levels must fall from excellent to caution between 100 and 1: %d, %d, %d, %d", levels.Excellent, levels.Good, levels.Fair, levels.Caution)
+	}
+	return nil
+}
+
+// ParseClock parses a time of day written as "H:MM" or "HH:MM" into minutes since midnight
+func ParseClock(value string) (int, error) {
+	hoursPart, minutesPart, found := strings.Cut(strings.TrimSpace(value), ":")
+	if !found || len(minutesPart) != 2 {
+		return 0, fmt.Errorf("time must be written as HH:MM: %s", value)
+	}
+	hours, err := strconv.Atoi(hoursPart)
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("time must be written as HH:MM between 0:00 and 23:59: %s", value)
+	}
+	minutes, err := strconv.Atoi(minutesPart)
+	if err != nil || minutes < 0 || minutes >= 60 {
+		return 0, fmt.Errorf("time must be written as HH:MM between 0:00 and 23:59: %s", value)
+	}
+	return hours*60 + minutes, nil
+}
+
+// compassPoints are the 16 compass point abbreviations clockwise from north
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// ParseBearing parses a direction given in degrees clockwise from north (0-360) or as a compass point such as "NE"
+func ParseBearing(value string) (float64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for i, point := range compassPoints {
+		if value == point {
+			return float64(i) * 22.5, nil
+		}
+	}
+
+	bearing, err := strconv.ParseFloat(value, 64)
+	if err != nil || bearing < 0 || bearing > 360 {
+		return 0, fmt.Errorf("bearing must be degrees from 0 to 360 or a compass point such as NE: %s", value)
+	}
+	if bearing == 360 {
+		bearing = 0
+	}
+	return bearing, nil
+}
+
+// ValidateCoordinate checks that a latitude and longitude lie on the globe
+func ValidateCoordinate(lat, lon float64) error {
+	if !(lat >= -90 && lat <= 90) {
+		return fmt.Errorf("invalid latitude: %f", lat)
+	}
+	if !(lon >= -180 && lon <= 180) {
+		return fmt.Errorf("invalid longitude: %f", lon)
+	}
+	return nil
+}
+
+// TimezoneAuto leaves the timezone of a location to the forecast API, which picks the one of its coordinates
+const TimezoneAuto = "auto"
+
+// ValidateTimezone checks that a location's timezone is empty (Japan), auto or an IANA timezone such as America/New_York
+func ValidateTimezone(timezone string) error {
+	if timezone == "" || timezone == TimezoneAuto {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || strings.EqualFold(timezone, "local") {
+		return fmt.Errorf("invalid timezone: %s", timezone)
+	}
+	return nil
+}
+
+// geoScheme is the scheme of geo URIs (RFC 5870)
+const geoScheme = "geo:"
+
+// IsCoordinate reports whether value is written as coordinates rather than a place name,
+// that is a geo URI or a number followed by a comma
+func IsCoordinate(value string) bool {
+	value = strings.TrimSpace(value)
+	if len(value) >= len(geoScheme) && strings.EqualFold(value[:len(geoScheme)], geoScheme) {
+		return true
+	}
+	latPart, _, found := strings.Cut(value, ",")
+	if !found {
+		return false
+	}
+	_, err := strconv.ParseFloat(strings.TrimSpace(latPart), 64)
+	return err == nil
+}
+
+// ParseCoordinate parses coordinates written as "lat,lon" (e.g. "35.68,139.76") or as a geo URI
+// (e.g. "geo:35.68,139.76"), whose altitude and parameters are ignored
+func ParseCoordinate(value string) (lat, lon float64, err error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= len(geoScheme) && strings.EqualFold(value[:len(geoScheme)], geoScheme) {
+		value, _, _ = strings.Cut(value[len(geoScheme):], ";")
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, fmt.Errorf("coordinates must be written as lat,lon: %s", value)
+	}
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if latErr != nil || lonErr != nil {
+		return 0, 0, fmt.Errorf("coordinates must be written as lat,lon: %s", value)
+	}
+	if err := ValidateCoordinate(lat, lon); err != nil {
+		return 0, 0, err
+	}
+	return lat, lon, nil
+}
+
+// ParsePace parses a pace per km written as "M:SS" (e.g. "5:30") into a duration
+func ParsePace(value string) (time.Duration, error) {
+	minutesPart, secondsPart, found := strings.Cut(strings.TrimSpace(value), ":")
+	if !found || len(secondsPart) != 2 {
+		return 0, fmt.Errorf("pace must be written as M:SS per km: %s", value)
+	}
+	minutes, err := strconv.Atoi(minutesPart)
+	if err != nil || minutes < 0 {
+		return 0, fmt.Errorf("pace must be written as M:SS per km: %s", value)
+	}
+	seconds, err := strconv.Atoi(secondsPart)
+	if err != nil || seconds < 0 || seconds >= 60 {
+		return 0, fmt.Errorf("pace must be written as M:SS per km: %s", value)
+	}
+
+	pace := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	if pace < MinPace || pace > MaxPace {
+		return 0, fmt.Errorf("pace must be between %s and %s per km: %s", FormatPace(MinPace), FormatPace(MaxPace), value)
+	}
+	return pace, nil
+}
+
+// FormatPace formats a pace per km as "M:SS"
+func FormatPace(pace time.Duration) string {
+	seconds := int(pace.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// Accepted range of paces per km
+const (
+	MinPace = 2 * time.Minute
+	MaxPace = 15 * time.Minute
+)
+
+// Accepted ranges of the profile's body weight (kg) and sweat rate (L/h)
+const (
+	MinBodyWeight = 30.0
+	MaxBodyWeight = 200.0
+	MinSweatRate  = 0.2
+	MaxSweatRate  = 4.0
+)
+
 // LoadConfig loads configuration from available config files
 func LoadConfig() (*Config, error) {
-	configPaths := getConfigPaths()
-	
-	for _, path := range configPaths {
-		if _, err := os.Stat(path); err == nil {
-			return loadConfigFromFile(path)
-		}
+	if path := FindConfigPath(); path != "" {
+		return loadConfigFromFile(path)
 	}
-	
+
 	// Return empty config if no config file found
 	return &Config{
 		Locations: make(map[string]types.CityCoordinate),
 	}, nil
 }
 
+// FindConfigPath returns the path of the config file in use, or an empty string when there is none
+func FindConfigPath() string {
+	for _, path := range getConfigPaths() {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// ConfigPaths returns the paths searched for the config file in order of priority
+func ConfigPaths() []string {
+	return getConfigPaths()
+}
+
 // getConfigPaths returns possible config file paths in order of priority
 func getConfigPaths() []string {
 	var paths []string
-	
+
 	// 1. Current directory
 	if cwd, err := os.Getwd(); err == nil {
 		paths = append(paths, filepath.Join(cwd, ".runcast.conf"))
 	}
-	
+
 	// 2. Home directory
 	if home, err := os.UserHomeDir(); err == nil {
 		paths = append(paths, filepath.Join(home, ".runcast.conf"))
 		paths = append(paths, filepath.Join(home, ".config", "runcast", "config.toml"))
 	}
-	
+
 	return paths
 }
 
 // loadConfigFromFile loads configuration from a specific file
 func loadConfigFromFile(path string) (*Config, error) {
 	var config Config
-	
+
 	if _, err := toml.DecodeFile(path, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
 	}
-	
+
 	// Validate configuration
 	if err := validateConfig(&config); err != nil {
 		return nil, fmt.Errorf("invalid configuration in %s: %w", path, err)
 	}
-	
+
 	return &config, nil
 }
 
@@ -69,7 +704,11 @@ func validateConfig(config *Config) error {
 	if config.Locations == nil {
 		config.Locations = make(map[string]types.CityCoordinate)
 	}
-	
+
+	if config.Language != "" && !i18n.IsSupported(config.Language) {
+		return fmt.Errorf("language must be one of %v: %s", i18n.SupportedLanguages(), config.Language)
+	}
+
 	for name, location := range config.Locations {
 		if name == "" {
 			return fmt.Errorf("location name cannot be empty")
@@ -77,14 +716,79 @@ func validateConfig(config *Config) error {
 		if location.Name == "" {
 			return fmt.Errorf("location '%s' must have a name", name)
 		}
-		if location.Lat < -90 || location.Lat > 90 {
-			return fmt.Errorf("location '%s' has invalid latitude: %f", name, location.Lat)
+		if err := ValidateCoordinate(location.Lat, location.Lon); err != nil {
+			return fmt.Errorf("location '%s' has %w", name, err)
 		}
-		if location.Lon < -180 || location.Lon > 180 {
-			return fmt.Errorf("location '%s' has invalid longitude: %f", name, location.Lon)
+		if err := ValidateTimezone(location.Timezone); err != nil {
+			return fmt.Errorf("location '%s' has %w", name, err)
 		}
 	}
-	
+
+	for key, value := range map[string]string{
+		"forecast_url":    config.Provider.ForecastURL,
+		"air_quality_url": config.Provider.AirQualityURL,
+	} {
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("provider %s must be an http(s) URL: %s", key, value)
+		}
+	}
+	if config.Provider.Timeout < 0 {
+		return fmt.Errorf("provider timeout cannot be negative: %d", config.Provider.Timeout)
+	}
+
+	if config.Profile.Pace != "" {
+		if _, err := ParsePace(config.Profile.Pace); err != nil {
+			return fmt.Errorf("profile %w", err)
+		}
+	}
+	if config.Profile.Distance != "" && !IsDistanceKey(config.Profile.Distance) {
+		return fmt.Errorf("profile distance must be one of 5k, 10k, half or full: %s", config.Profile.Distance)
+	}
+	for key, value := range map[string]string{
+		"heat_tolerance": config.Profile.HeatTolerance,
+		"cold_tolerance": config.Profile.ColdTolerance,
+	} {
+		if value != "" && !isTolerance(value) {
+			return fmt.Errorf("profile %s must be one of %v: %s", key, tolerances, value)
+		}
+	}
+	if config.Route.Bearing != "" {
+		if _, err := ParseBearing(config.Route.Bearing); err != nil {
+			return fmt.Errorf("route %w", err)
+		}
+	}
+	for key, period := range config.Periods {
+		if key == "" || strings.ContainsAny(key, " \t=") {
+			return fmt.Errorf("period name must be a single word usable with -time: %q", key)
+		}
+		if _, err := period.TimePeriod(key); err != nil {
+			return fmt.Errorf("period '%s' %w", key, err)
+		}
+	}
+	if err := validateScoring(config.Scoring); err != nil {
+		return err
+	}
+	if config.Profile.BodyWeight != 0 && (config.Profile.BodyWeight < MinBodyWeight || config.Profile.BodyWeight > MaxBodyWeight) {
+		return fmt.Errorf("profile body_weight must be between %.0f and %.0f kg: %g", MinBodyWeight, MaxBodyWeight, config.Profile.BodyWeight)
+	}
+	if config.Profile.SweatRate != 0 && (config.Profile.SweatRate < MinSweatRate || config.Profile.SweatRate > MaxSweatRate) {
+		return fmt.Errorf("profile sweat_rate must be between %.1f and %.1f L/h: %g", MinSweatRate, MaxSweatRate, config.Profile.SweatRate)
+	}
+
+	if config.Cache.TTL != "" {
+		ttl, err := time.ParseDuration(config.Cache.TTL)
+		if err != nil {
+			return fmt.Errorf("cache ttl must be a duration such as \"30m\": %s", config.Cache.TTL)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("cache ttl cannot be negative: %s", config.Cache.TTL)
+		}
+	}
+
 	return nil
 }
 
@@ -104,4 +808,4 @@ func (c *Config) GetCustomLocationNames() []string {
 		names = append(names, name)
 	}
 	return names
-}
\ No newline at end of file
+}
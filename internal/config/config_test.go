@@ -3,8 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"runcast/internal/types"
 )
 
@@ -12,27 +15,27 @@ func TestLoadConfig(t *testing.T) {
 	// Create a temporary config file
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, ".runcast.conf")
-	
+
 	configContent := `[locations]
 home = { name = "自宅", lat = 35.6762, lon = 139.6503 }
 office = { name = "会社", lat = 35.6584, lon = 139.7016 }`
-	
+
 	err := os.WriteFile(configPath, []byte(configContent), 0644)
 	if err != nil {
 		t.Fatalf("Failed to create test config file: %v", err)
 	}
-	
+
 	// Change working directory to temp dir for testing
 	oldWd, _ := os.Getwd()
 	defer os.Chdir(oldWd)
 	os.Chdir(tmpDir)
-	
+
 	// Load config
 	config, err := LoadConfig()
 	if err != nil {
 		t.Fatalf("LoadConfig failed: %v", err)
 	}
-	
+
 	// Test home location
 	homeCoord, exists := config.GetCustomLocation("home")
 	if !exists {
@@ -47,7 +50,7 @@ office = { name = "会社", lat = 35.6584, lon = 139.7016 }`
 	if homeCoord.Lon != 139.6503 {
 		t.Errorf("Expected lon 139.6503, got %f", homeCoord.Lon)
 	}
-	
+
 	// Test office location
 	officeCoord, exists := config.GetCustomLocation("office")
 	if !exists {
@@ -56,13 +59,13 @@ office = { name = "会社", lat = 35.6584, lon = 139.7016 }`
 	if officeCoord.Name != "会社" {
 		t.Errorf("Expected name '会社', got '%s'", officeCoord.Name)
 	}
-	
+
 	// Test non-existent location
 	_, exists = config.GetCustomLocation("nonexistent")
 	if exists {
 		t.Error("Expected 'nonexistent' location to not exist")
 	}
-	
+
 	// Test custom location names
 	names := config.GetCustomLocationNames()
 	if len(names) != 2 {
@@ -76,17 +79,39 @@ func TestLoadConfigNoFile(t *testing.T) {
 	oldWd, _ := os.Getwd()
 	defer os.Chdir(oldWd)
 	os.Chdir(tmpDir)
-	
+
 	config, err := LoadConfig()
 	if err != nil {
 		t.Fatalf("LoadConfig should not fail when no config file exists: %v", err)
 	}
-	
+
 	if len(config.Locations) != 0 {
 		t.Errorf("Expected empty locations, got %d", len(config.Locations))
 	}
 }
 
+func TestFindConfigPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+	t.Setenv("HOME", tmpDir)
+
+	if path := FindConfigPath(); path != "" {
+		t.Fatalf("Expected no config file, got %s", path)
+	}
+	if paths := ConfigPaths(); len(paths) != 3 {
+		t.Errorf("Expected three searched paths, got %v", paths)
+	}
+
+	expected := filepath.Join(tmpDir, ".config", "runcast", "config.toml")
+	os.MkdirAll(filepath.Dir(expected), 0755)
+	os.WriteFile(expected, []byte("language = \"en\"\n"), 0644)
+	if path := FindConfigPath(); path != expected {
+		t.Errorf("Expected %s, got %s", expected, path)
+	}
+}
+
 func TestValidateConfig(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -97,7 +122,7 @@ func TestValidateConfig(t *testing.T) {
 			name: "valid config",
 			config: Config{
 				Locations: map[string]types.CityCoordinate{
-					"home": {"自宅", 35.6762, 139.6503},
+					"home": {Name: "自宅", Lat: 35.6762, Lon: 139.6503},
 				},
 			},
 			expectError: false,
@@ -106,7 +131,26 @@ func TestValidateConfig(t *testing.T) {
 			name: "invalid latitude",
 			config: Config{
 				Locations: map[string]types.CityCoordinate{
-					"invalid": {"無効", 91.0, 139.6503}, // latitude > 90
+					"invalid": {Name: "無効", Lat: 91.0, Lon: 139.6503}, // latitude > 90
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "location timezones",
+			config: Config{
+				Locations: map[string]types.CityCoordinate{
+					"race":  {Name: "大会会場", Lat: 40.7128, Lon: -74.006, Timezone: "America/New_York"},
+					"hotel": {Name: "ホテル", Lat: 40.75, Lon: -73.98, Timezone: "auto"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid timezone",
+			config: Config{
+				Locations: map[string]types.CityCoordinate{
+					"race": {Name: "大会会場", Lat: 40.7128, Lon: -74.006, Timezone: "Mars/Olympus"},
 				},
 			},
 			expectError: true,
@@ -115,7 +159,7 @@ func TestValidateConfig(t *testing.T) {
 			name: "invalid longitude",
 			config: Config{
 				Locations: map[string]types.CityCoordinate{
-					"invalid": {"無効", 35.6762, 181.0}, // longitude > 180
+					"invalid": {Name: "無効", Lat: 35.6762, Lon: 181.0}, // longitude > 180
 				},
 			},
 			expectError: true,
@@ -124,13 +168,340 @@ func TestValidateConfig(t *testing.T) {
 			name: "empty location name",
 			config: Config{
 				Locations: map[string]types.CityCoordinate{
-					"test": {"", 35.6762, 139.6503}, // empty name
+					"test": {Name: "", Lat: 35.6762, Lon: 139.6503}, // empty name
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid provider URLs",
+			config: Config{
+				Provider: ProviderConfig{
+					ForecastURL:   "http://localhost:8080/v1/jma",
+					AirQualityURL: "https://mirror.example.com/v1/air-quality",
+					Timeout:       30,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid provider URL",
+			config: Config{
+				Provider: ProviderConfig{
+					ForecastURL: "localhost:8080",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid cache ttl",
+			config: Config{
+				Cache: CacheConfig{TTL: "2h"},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid cache ttl",
+			config: Config{
+				Cache: CacheConfig{TTL: "30"},
+			},
+			expectError: true,
+		},
+		{
+			name: "supported language",
+			config: Config{
+				Language: "en",
+			},
+			expectError: false,
+		},
+		{
+			name: "unsupported language",
+			config: Config{
+				Language: "fr",
+			},
+			expectError: true,
+		},
+		{
+			name: "valid profile pace",
+			config: Config{
+				Profile: ProfileConfig{
+					Pace: "5:30",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid profile pace",
+			config: Config{
+				Profile: ProfileConfig{
+					Pace: "5.5",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid profile body weight and sweat rate",
+			config: Config{
+				Profile: ProfileConfig{
+					BodyWeight: 62.5,
+					SweatRate:  1.1,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "profile body weight out of range",
+			config: Config{
+				Profile: ProfileConfig{
+					BodyWeight: 650,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "profile sweat rate out of range",
+			config: Config{
+				Profile: ProfileConfig{
+					SweatRate: 11,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid route bearing",
+			config: Config{
+				Route: RouteConfig{
+					Bearing: "SSE",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid route bearing",
+			config: Config{
+				Route: RouteConfig{
+					Bearing: "up",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid periods",
+			config: Config{
+				Periods: map[string]PeriodConfig{
+					"lunch":  {Start: "12:00", End: "13:30"},
+					"before": {Start: "6:30", End: "7:45", Name: "出勤前"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "period ending before it starts",
+			config: Config{
+				Periods: map[string]PeriodConfig{
+					"late": {Start: "23:00", End: "1:00"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "period with an invalid time",
+			config: Config{
+				Periods: map[string]PeriodConfig{
+					"lunch": {Start: "12", End: "13:30"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "period name with a space",
+			config: Config{
+				Periods: map[string]PeriodConfig{
+					"long run": {Start: "6:00", End: "9:00"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "runner profile",
+			config: Config{
+				Profile: ProfileConfig{Distance: "half", HeatTolerance: "high", ColdTolerance: "normal", SensitiveAirways: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown preferred distance",
+			config: Config{
+				Profile: ProfileConfig{Distance: "ultra"},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown heat tolerance",
+			config: Config{
+				Profile: ProfileConfig{HeatTolerance: "extreme"},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring overrides",
+			config: Config{
+				Scoring: ScoringConfig{
+					Cold:      floatPtr(8),
+					Penalties: PenaltyConfig{WBGTWarning: intPtr(5)},
+					Distances: map[string]DistancePenaltyConfig{"full": {HeatIndex: intPtr(10)}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "scoring cold above cool",
+			config: Config{
+				Scoring: ScoringConfig{Cold: floatPtr(16)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring negative penalty",
+			config: Config{
+				Scoring: ScoringConfig{Penalties: PenaltyConfig{Rain: intPtr(-5)}},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring levels out of order",
+			config: Config{
+				Scoring: ScoringConfig{Levels: LevelConfig{Good: intPtr(85)}},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring heat acclimatization",
+			config: Config{
+				Scoring: ScoringConfig{
+					WBGTWarning:   floatPtr(26),
+					WBGTSevere:    floatPtr(29),
+					DewPointMuggy: floatPtr(17),
+					Penalties:     PenaltyConfig{DustHigh: intPtr(40), PM25Alert: intPtr(40)},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "scoring wbgt out of order",
+			config: Config{
+				Scoring: ScoringConfig{WBGTSevere: floatPtr(32)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring dew points out of order",
+			config: Config{
+				Scoring: ScoringConfig{DewPointHumid: floatPtr(16)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring negative pm25",
+			config: Config{
+				Scoring: ScoringConfig{PM25Standard: floatPtr(-1)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring clothing below cool",
+			config: Config{
+				Scoring: ScoringConfig{Mild: floatPtr(12)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring long run heat out of range",
+			config: Config{
+				Scoring: ScoringConfig{MarathonHot: floatPtr(60)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring dust penalty above the score",
+			config: Config{
+				Scoring: ScoringConfig{Penalties: PenaltyConfig{DustVeryHigh: intPtr(120)}},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring uv, gusts, rain chance and temperature rise",
+			config: Config{
+				Scoring: ScoringConfig{
+					UVHigh:          floatPtr(7),
+					GustStrong:      floatPtr(17),
+					RainLikely:      floatPtr(70),
+					TemperatureRise: floatPtr(4),
+					Penalties:       PenaltyConfig{UVExtreme: intPtr(30), Gust: intPtr(0), RainChance: intPtr(10), TemperatureRise: intPtr(0)},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "scoring uv out of order",
+			config: Config{
+				Scoring: ScoringConfig{UVVeryHigh: floatPtr(5)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring gusts out of order",
+			config: Config{
+				Scoring: ScoringConfig{Gust: floatPtr(16)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring dry probability above rain likely",
+			config: Config{
+				Scoring: ScoringConfig{DryProbability: floatPtr(70)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring rain likely above 100",
+			config: Config{
+				Scoring: ScoringConfig{RainLikely: floatPtr(120)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring no temperature rise",
+			config: Config{
+				Scoring: ScoringConfig{TemperatureRise: floatPtr(0)},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring gust penalty above the score",
+			config: Config{
+				Scoring: ScoringConfig{Penalties: PenaltyConfig{GustDangerous: intPtr(150)}},
+			},
+			expectError: true,
+		},
+		{
+			name: "scoring unknown distance",
+			config: Config{
+				Scoring: ScoringConfig{Distances: map[string]DistancePenaltyConfig{"ultra": {}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "negative provider timeout",
+			config: Config{
+				Provider: ProviderConfig{
+					Timeout: -1,
 				},
 			},
 			expectError: true,
 		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := validateConfig(&tt.config)
@@ -142,4 +513,255 @@ func TestValidateConfig(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParsePace(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{value: "5:30", expected: 5*time.Minute + 30*time.Second},
+		{value: " 4:05 ", expected: 4*time.Minute + 5*time.Second},
+		{value: "12:00", expected: 12 * time.Minute},
+		{value: "5:3", expectError: true},
+		{value: "5:60", expectError: true},
+		{value: "330", expectError: true},
+		{value: "1:30", expectError: true},
+		{value: "16:00", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			pace, err := ParsePace(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", pace)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if pace != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, pace)
+			}
+			if formatted := FormatPace(pace); formatted != strings.TrimSpace(tt.value) {
+				t.Errorf("Expected FormatPace to return %s, got %s", strings.TrimSpace(tt.value), formatted)
+			}
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    int
+		expectError bool
+	}{
+		{value: "0:00", expected: 0},
+		{value: "6:30", expected: 390},
+		{value: "12:00", expected: 720},
+		{value: " 23:59 ", expected: 1439},
+		{value: "24:00", expectError: true},
+		{value: "7:60", expectError: true},
+		{value: "7:5", expectError: true},
+		{value: "noon", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			minutes, err := ParseClock(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %d", minutes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if minutes != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, minutes)
+			}
+		})
+	}
+}
+
+func TestTimePeriods(t *testing.T) {
+	var config Config
+	if _, err := toml.Decode(`[periods]
+lunch = {start="12:00", end="13:30"}
+commute = {start="6:30", end="7:15", name="出勤前"}
+broken = {start="9:00", end="8:00"}`, &config); err != nil {
+		t.Fatalf("Failed to decode periods: %v", err)
+	}
+
+	periods := config.TimePeriods()
+	expected := []types.TimePeriod{
+		{Key: "commute", DisplayName: "出勤前", StartHour: 6, StartMinute: 30, EndHour: 7, EndMinute: 15},
+		{Key: "lunch", DisplayName: "lunch", StartHour: 12, EndHour: 13, EndMinute: 30},
+	}
+	if len(periods) != len(expected) {
+		t.Fatalf("Expected %d valid periods, got %+v", len(expected), periods)
+	}
+	for i, period := range periods {
+		if period != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], period)
+		}
+	}
+}
+
+func TestScoring(t *testing.T) {
+	var config Config
+	if _, err := toml.Decode(`[scoring]
+hot = 30
+wbgt_caution = 22
+dew_point_oppressive = 19
+pm25_high = 55
+warm = 26
+marathon_hot = 20
+uv_high = 7
+gust = 10
+rain_likely = 70
+temperature_rise = 4
+[scoring.penalties]
+wbgt_warning = 5
+dust_moderate = 10
+pm25_elevated = 3
+uv_very_high = 15
+gust_dangerous = 40
+rain_chance = 8
+temperature_rise = 2
+[scoring.levels]
+excellent = 85
+[scoring.distance.full]
+heat_index = 10`, &config); err != nil {
+		t.Fatalf("Failed to decode scoring: %v", err)
+	}
+
+	scoring := config.Scoring.Scoring()
+	expected := types.DefaultScoring()
+	expected.Thresholds.Hot = 30
+	expected.Thresholds.WBGTCaution = 22
+	expected.Thresholds.DewPointOppressive = 19
+	expected.Thresholds.PM25High = 55
+	expected.Thresholds.Warm = 26
+	expected.Thresholds.MarathonHot = 20
+	expected.Thresholds.UVHigh = 7
+	expected.Thresholds.Gust = 10
+	expected.Thresholds.RainLikely = 70
+	expected.Thresholds.TemperatureRise = 4
+	expected.Penalties.WBGTWarning = 5
+	expected.Penalties.DustModerate = 10
+	expected.Penalties.PM25Elevated = 3
+	expected.Penalties.UVVeryHigh = 15
+	expected.Penalties.GustDangerous = 40
+	expected.Penalties.RainChance = 8
+	expected.Penalties.TemperatureRise = 2
+	expected.Levels.Excellent = 85
+	full := expected.Distances["full"]
+	full.HeatIndex = 10
+	expected.Distances["full"] = full
+
+	if scoring.Thresholds != expected.Thresholds || scoring.Penalties != expected.Penalties || scoring.Levels != expected.Levels {
+		t.Errorf("Expected %+v, got %+v", expected, scoring)
+	}
+	for key, penalties := range expected.Distances {
+		if scoring.Distances[key] != penalties {
+			t.Errorf("Expected %s penalties %+v, got %+v", key, penalties, scoring.Distances[key])
+		}
+	}
+
+	// Without overrides the defaults are unchanged
+	if defaults := (ScoringConfig{}).Scoring(); defaults.Levels != types.DefaultScoring().Levels {
+		t.Errorf("Expected the default levels, got %+v", defaults.Levels)
+	}
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestParseBearing(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    float64
+		expectError bool
+	}{
+		{value: "N", expected: 0},
+		{value: "ne", expected: 45},
+		{value: "WSW", expected: 247.5},
+		{value: "135", expected: 135},
+		{value: " 22.5 ", expected: 22.5},
+		{value: "360", expected: 0},
+		{value: "-10", expectError: true},
+		{value: "400", expectError: true},
+		{value: "north", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			bearing, err := ParseBearing(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", bearing)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if bearing != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, bearing)
+			}
+		})
+	}
+}
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		value        string
+		isCoordinate bool
+		lat, lon     float64
+		expectError  bool
+	}{
+		{value: "35.68,139.76", isCoordinate: true, lat: 35.68, lon: 139.76},
+		{value: " 35.68, 139.76 ", isCoordinate: true, lat: 35.68, lon: 139.76},
+		{value: "-33.87,151.21", isCoordinate: true, lat: -33.87, lon: 151.21},
+		{value: "geo:35.68,139.76", isCoordinate: true, lat: 35.68, lon: 139.76},
+		{value: "GEO:35.68,139.76,40;u=10", isCoordinate: true, lat: 35.68, lon: 139.76},
+		{value: "91,139.76", isCoordinate: true, expectError: true},
+		{value: "35.68,181", isCoordinate: true, expectError: true},
+		{value: "NaN,139.76", isCoordinate: true, expectError: true},
+		{value: "35.68,east", isCoordinate: true, expectError: true},
+		{value: "35.68", isCoordinate: false, expectError: true},
+		{value: "geo:tokyo", isCoordinate: true, expectError: true},
+		{value: "tokyo", isCoordinate: false, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if isCoordinate := IsCoordinate(tt.value); isCoordinate != tt.isCoordinate {
+				t.Errorf("Expected IsCoordinate %v, got %v", tt.isCoordinate, isCoordinate)
+			}
+			lat, lon, err := ParseCoordinate(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v,%v", lat, lon)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if lat != tt.lat || lon != tt.lon {
+				t.Errorf("Expected %v,%v, got %v,%v", tt.lat, tt.lon, lat, lon)
+			}
+		})
+	}
+}
@@ -2,11 +2,21 @@ package display
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/i18n"
+	"runcast/internal/report"
 	"runcast/internal/running"
 	"runcast/internal/types"
 	"runcast/internal/weather"
 )
 
+// separator is the horizontal rule between output sections
+const separator = "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+
 // GetRunningTempIcon returns temperature icon for running
 func GetRunningTempIcon(temp float64) string {
 	if temp >= 30 {
@@ -24,101 +34,288 @@ func GetRunningTempIcon(temp float64) string {
 	}
 }
 
-// DisplayCurrentWeather displays current weather information
-func DisplayCurrentWeather(weatherData *types.WeatherData, cityName string) {
-	fmt.Printf("🌤️ %s の現在の天気\n", cityName)
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	fmt.Printf("🌡️ 気温: %.1f°C (体感: %.1f°C)\n", weatherData.Current.Temperature, weatherData.Current.ApparentTemp)
-	fmt.Printf("💧 湿度: %d%%\n", weatherData.Current.Humidity)
-	fmt.Printf("🌬️ 風: %s %.1f m/s\n", weather.GetWindDirection(weatherData.Current.WindDirection), weatherData.Current.WindSpeed)
-	fmt.Printf("☁️ 天気: %s\n", weather.GetWeatherDescription(weatherData.Current.WeatherCode))
-	if weatherData.Current.Precipitation > 0 {
-		fmt.Printf("🌧️ 降水量: %.1f mm\n", weatherData.Current.Precipitation)
+// RenderText writes a running report as human-readable text
+func RenderText(w io.Writer, r *report.Report) {
+	switch r.Mode {
+	case report.ModeTime, report.ModeDateTime:
+		renderHours(w, r)
+	case report.ModeDate:
+		renderDate(w, r)
+	case report.ModeBest:
+		renderBest(w, r)
+	default:
+		renderCurrent(w, r)
 	}
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 }
 
-// DisplayRunningWeatherWithDistance displays running weather with distance consideration
-func DisplayRunningWeatherWithDistance(weatherData *types.WeatherData, cityName string, distanceCategory *types.DistanceCategory) {
-	DisplayRunningWeatherWithDistanceAndDust(weatherData, cityName, distanceCategory, nil)
+// RenderCities writes the built-in cities followed by the custom locations
+func RenderCities(w io.Writer, cities []weather.City) {
+	fmt.Fprintln(w, i18n.T("cities.title"))
+	fmt.Fprintln(w, separator)
+	custom := false
+	for _, city := range cities {
+		if city.Custom && !custom {
+			custom = true
+			fmt.Fprintln(w, separator)
+			fmt.Fprintln(w, i18n.T("cities.custom"))
+		}
+		fmt.Fprintf(w, "   %-10s %s (%.4f, %.4f)\n", city.Key, city.Coordinate.Name, city.Coordinate.Lat, city.Coordinate.Lon)
+	}
 }
 
-// DisplayRunningWeatherWithDistanceAndDust displays running weather with distance and dust consideration
-func DisplayRunningWeatherWithDistanceAndDust(weatherData *types.WeatherData, cityName string, distanceCategory *types.DistanceCategory, dustLevel *types.DustLevel) {
-	var condition types.RunningCondition
-	var titleSuffix string
+// renderCurrent writes the assessment of the current conditions
+func renderCurrent(w io.Writer, r *report.Report) {
+	printHeader(w, r)
 
-	if distanceCategory != nil {
-		titleSuffix = fmt.Sprintf("(%s)", distanceCategory.DisplayName)
-		condition = running.AssessDistanceBasedRunningCondition(
-			weatherData.Current.Temperature,
-			weatherData.Current.ApparentTemp,
-			float64(weatherData.Current.Humidity),
-			weatherData.Current.WindSpeed,
-			weatherData.Current.Precipitation,
-			weatherData.Current.WeatherCode,
-			distanceCategory,
-		)
-	} else {
-		titleSuffix = ""
-		condition = running.AssessRunningCondition(
-			weatherData.Current.Temperature,
-			weatherData.Current.ApparentTemp,
-			float64(weatherData.Current.Humidity),
-			weatherData.Current.WindSpeed,
-			weatherData.Current.Precipitation,
-			weatherData.Current.WeatherCode,
-		)
+	entry := r.Summary
+	condition := entry.Condition
+	data := entry.Weather
+
+	fmt.Fprintln(w, i18n.T("report.score", condition.Score, condition.Level))
+	fmt.Fprintf(w, "💡 %s\n", condition.Recommendation)
+	fmt.Fprintln(w, separator)
+
+	fmt.Fprintln(w, i18n.T("report.temperature", data.Temperature, data.ApparentTemp))
+	fmt.Fprintln(w, i18n.T("report.humidity", data.Humidity))
+	fmt.Fprintln(w, i18n.T("report.dew_point", data.DewPoint, running.GetDewPointComfortName(data.DewPoint)))
+	fmt.Fprintln(w, i18n.T("report.wind", weather.GetWindDirection(data.WindDirection), data.WindSpeed))
+	printGusts(w, data.WindGusts)
+	printRouteWind(w, entry.RouteWind)
+	fmt.Fprintln(w, i18n.T("report.weather", weather.GetWeatherDescription(data.WeatherCode)))
+	if data.Precipitation > 0 {
+		fmt.Fprintln(w, i18n.T("report.precipitation", data.Precipitation))
+	}
+	printRunWindow(w, condition.Window)
+	printHeatStress(w, "report.wbgt", condition.HeatStress)
+	fmt.Fprintln(w, i18n.T("report.uv", data.UVIndex, running.GetUVLevelName(data.UVIndex)))
+	printPace(w, r, entry.Pace)
+	printHydration(w, entry.Hydration)
+
+	printDust(w, entry.Dust)
+	printClothing(w, condition)
+	printWarnings(w, condition)
+
+	fmt.Fprintln(w, separator)
+}
+
+// printHeader writes the title, data age and target distance shared by every running report
+func printHeader(w io.Writer, r *report.Report) {
+	var titleSuffix string
+	if r.Distance != nil {
+		titleSuffix = i18n.T("report.title.distance", r.Distance.DisplayName)
 	}
 
-	// Apply dust penalty
-	running.ApplyDustPenalty(&condition, dustLevel, distanceCategory)
+	var title string
+	switch r.Mode {
+	case report.ModeTime:
+		title = i18n.T("report.title.time", r.Location.Name, r.Period.DisplayName, titleSuffix)
+	case report.ModeDate:
+		title = i18n.T("report.title.date", r.Location.Name, r.DateLabel, titleSuffix)
+	case report.ModeDateTime:
+		title = i18n.T("report.title.datetime", r.Location.Name, r.DateLabel, r.Period.DisplayName, titleSuffix)
+	case report.ModeBest:
+		title = i18n.T("report.title.best", r.Location.Name, r.Days, titleSuffix)
+	default:
+		title = i18n.T("report.title.current", r.Location.Name, titleSuffix)
+	}
 
-	fmt.Printf("🏃‍♂️ %s のランニング情報%s\n", cityName, titleSuffix)
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Fprintln(w, title)
+	fmt.Fprintln(w, separator)
+	printTimeZone(w, r)
+	printDataAsOf(w, r)
 
 	// Distance category info
-	if distanceCategory != nil {
-		fmt.Printf("📏 目標距離: %s (%.1f-%.1fkm)\n", distanceCategory.DisplayName, distanceCategory.MinKm, distanceCategory.MaxKm)
-		fmt.Printf("💭 %s\n", distanceCategory.Description)
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if r.Distance != nil {
+		fmt.Fprintln(w, i18n.T("report.target_distance", r.Distance.DisplayName, r.Distance.MinKm, r.Distance.MaxKm))
+		fmt.Fprintf(w, "💭 %s\n", r.Distance.Description)
+		fmt.Fprintln(w, separator)
 	}
+}
 
-	fmt.Printf("🏆 ランニング指数: %d/100 (%s)\n", condition.Score, condition.Level)
-	fmt.Printf("💡 %s\n", condition.Recommendation)
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+// printDataAsOf prints when the data was fetched if it was served from the cache
+func printDataAsOf(w io.Writer, r *report.Report) {
+	if !r.FromCache {
+		return
+	}
+	fmt.Fprintln(w, i18n.T("report.data_as_of", r.FetchedAt.In(reportLocation(r)).Format("2006/01/02 15:04")))
+}
 
-	fmt.Printf("🌡️ 気温: %.1f°C (体感: %.1f°C)\n", weatherData.Current.Temperature, weatherData.Current.ApparentTemp)
-	fmt.Printf("💧 湿度: %d%%\n", weatherData.Current.Humidity)
-	fmt.Printf("🌬️ 風: %s %.1f m/s\n", weather.GetWindDirection(weatherData.Current.WindDirection), weatherData.Current.WindSpeed)
-	fmt.Printf("☁️ 天気: %s\n", weather.GetWeatherDescription(weatherData.Current.WeatherCode))
-	if weatherData.Current.Precipitation > 0 {
-		fmt.Printf("🌧️ 降水量: %.1f mm\n", weatherData.Current.Precipitation)
+// printTimeZone prints the timezone of the times in the report when it is not Japan's
+func printTimeZone(w io.Writer, r *report.Report) {
+	location := reportLocation(r)
+	if location.String() == weather.DefaultTimezone {
+		return
 	}
+	fmt.Fprintln(w, i18n.T("report.timezone", location.String(), formatUTCOffset(r.UTCOffset)))
+}
+
+// formatUTCOffset formats a UTC offset in seconds as -07:00
+func formatUTCOffset(offset int) string {
+	return time.Unix(0, 0).In(time.FixedZone("", offset)).Format("-07:00")
+}
 
-	// Dust information
-	if dustLevel != nil {
-		fmt.Printf("🌫️ 黄砂: %s (%.0f μg/m³)\n", dustLevel.DisplayName, dustLevel.Dust)
-		fmt.Printf("   PM2.5: %.0f μg/m³ / PM10: %.0f μg/m³\n", dustLevel.PM2_5, dustLevel.PM10)
+// reportLocation returns the timezone of the local times in the report
+func reportLocation(r *report.Report) *time.Location {
+	if r.TimeZone == nil {
+		return weather.DefaultLocation
 	}
+	return r.TimeZone
+}
 
-	// Clothing recommendations
-	if len(condition.Clothing) > 0 {
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		fmt.Printf("👕 推奨ウェア:\n")
-		for _, item := range condition.Clothing {
-			fmt.Printf("   • %s\n", item)
-		}
+// printDust prints dust and particulate matter levels when air quality data is available
+func printDust(w io.Writer, dustLevel *types.DustLevel) {
+	if dustLevel == nil {
+		return
 	}
+	fmt.Fprintln(w, i18n.T("report.dust", dustLevel.DisplayName, dustLevel.Dust))
+	fmt.Fprintf(w, "   PM2.5: %.0f μg/m³ / PM10: %.0f μg/m³\n", dustLevel.PM2_5, dustLevel.PM10)
+}
 
-	// Warnings
-	if len(condition.Warnings) > 0 {
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		fmt.Printf("⚠️ 注意事項:\n")
-		for _, warning := range condition.Warnings {
-			fmt.Printf("   %s\n", warning)
-		}
+// printDaylight prints the sunrise, sunset and civil twilight of the report date
+func printDaylight(w io.Writer, daylight *types.Daylight) {
+	if daylight == nil {
+		return
+	}
+	minutes := int(daylight.Duration.Minutes())
+	fmt.Fprintln(w, i18n.T("report.daylight", daylight.Sunrise.Format("15:04"), daylight.Sunset.Format("15:04"), minutes/60, minutes%60))
+	fmt.Fprintln(w, i18n.T("report.twilight", daylight.CivilDawn.Format("15:04"), daylight.CivilDusk.Format("15:04")))
+}
+
+// printDryWindow prints the longest dry window of the report, with the date when it covers several days
+func printDryWindow(w io.Writer, r *report.Report) {
+	if r.Mode == report.ModeCurrent {
+		return
+	}
+	window := r.DryWindow
+	if window == nil {
+		fmt.Fprintln(w, i18n.T("report.dry_window_none"))
+		return
+	}
+
+	layout := "15:04"
+	if r.Mode == report.ModeTime {
+		layout = "01/02 15:04"
+	}
+	start, _ := time.Parse(weather.ForecastTimeLayout, window.Start)
+	end, _ := time.Parse(weather.ForecastTimeLayout, window.End)
+	if !window.LongEnough {
+		fmt.Fprintln(w, i18n.T("report.dry_window_short", start.Format(layout), end.Format(layout), window.Hours, int(window.RunDuration.Minutes())))
+		return
+	}
+	fmt.Fprintln(w, i18n.T("report.dry_window", start.Format(layout), end.Format(layout), window.Hours,
+		window.DryProbability, i18n.T("dry_window.confidence."+window.Confidence)))
+}
+
+// printRunWindow prints the conditions over a run that covers more than one forecast hour
+func printRunWindow(w io.Writer, window *types.RunWindowSummary) {
+	if window == nil || window.Hours <= 1 {
+		return
 	}
+	fmt.Fprintln(w, i18n.T("report.run_window", window.Hours, window.MinTemperature, window.MaxTemperature,
+		window.PeakWBGT, window.TotalPrecipitation, window.RainChance))
+}
+
+// printGusts prints the gust speed when the forecast has one
+func printGusts(w io.Writer, gusts float64) {
+	if gusts <= 0 {
+		return
+	}
+	fmt.Fprintln(w, i18n.T("report.gusts", gusts))
+}
+
+// printRouteWind prints the wind along the route and which way to start an out-and-back
+func printRouteWind(w io.Writer, routeWind *types.RouteWind) {
+	if routeWind == nil {
+		return
+	}
+	key := "report.route_wind.head"
+	if routeWind.Headwind < 0 {
+		key = "report.route_wind.tail"
+	}
+	fmt.Fprintln(w, i18n.T(key, weather.GetWindDirection(routeWind.Bearing), routeWind.Bearing, math.Abs(routeWind.Headwind), math.Abs(routeWind.Crosswind)))
+	if routeWind.Advice == running.WindAdviceEither {
+		fmt.Fprintln(w, i18n.T("route.advice.either"))
+		return
+	}
+	fmt.Fprintln(w, i18n.T("route.advice.start", weather.GetWindDirection(routeWind.StartBearing)))
+}
+
+// printHeatStress prints the estimated WBGT with its heat stroke prevention band
+func printHeatStress(w io.Writer, key string, heatStress *types.HeatStress) {
+	if heatStress == nil {
+		return
+	}
+	fmt.Fprintln(w, i18n.T(key, heatStress.WBGT, heatStress.DisplayName, heatStress.Guidance))
+}
+
+// printPace prints the weather-adjusted pace and the estimated finish time
+func printPace(w io.Writer, r *report.Report, pace *types.PaceEstimate) {
+	if pace == nil {
+		return
+	}
+	fmt.Fprintln(w, i18n.T("report.pace", config.FormatPace(pace.TargetPace), config.FormatPace(pace.AdjustedPace), (pace.HeatAdjustment+pace.WindAdjustment)*100))
+	fmt.Fprintln(w, i18n.T("report.pace_breakdown", pace.HeatAdjustment*100, pace.WindAdjustment*100))
+	printFinishTime(w, r, pace)
+}
+
+// printFinishTime prints the estimated finish time for the target distance
+func printFinishTime(w io.Writer, r *report.Report, pace *types.PaceEstimate) {
+	if pace == nil || r.Distance == nil || pace.FinishTime == 0 {
+		return
+	}
+	fmt.Fprintln(w, i18n.T("report.finish_time", r.Distance.DisplayName, formatDuration(pace.FinishTime)))
+}
 
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-}
\ No newline at end of file
+// printHydration prints how much to drink and eat during the run
+func printHydration(w io.Writer, plan *types.HydrationPlan) {
+	if plan == nil {
+		return
+	}
+	minutes := int(plan.Duration.Minutes())
+	if plan.FluidPerHour > 0 {
+		fmt.Fprintln(w, i18n.T("report.hydration", plan.FluidPerHour, plan.TotalFluid, minutes))
+	} else {
+		fmt.Fprintln(w, i18n.T("report.hydration_none", minutes))
+	}
+	source := i18n.T("hydration.sweat.configured")
+	if plan.SweatRateEstimated {
+		source = i18n.T("hydration.sweat.estimated")
+	}
+	fmt.Fprintln(w, i18n.T("report.sweat_rate", plan.SweatRate, source))
+	if plan.SodiumPerHour > 0 {
+		fmt.Fprintln(w, i18n.T("report.sodium", plan.SodiumPerHour))
+	}
+	if plan.GelCount > 0 {
+		fmt.Fprintln(w, i18n.T("report.gels", plan.GelCount, int(plan.FirstGel.Minutes()), int(plan.GelInterval.Minutes())))
+	}
+}
+
+// formatDuration formats a duration as H:MM:SS
+func formatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// printClothing prints clothing recommendations
+func printClothing(w io.Writer, condition types.RunningCondition) {
+	if len(condition.Clothing) == 0 {
+		return
+	}
+	fmt.Fprintln(w, separator)
+	fmt.Fprintln(w, i18n.T("report.clothing"))
+	for _, item := range condition.Clothing {
+		fmt.Fprintf(w, "   • %s\n", item)
+	}
+}
+
+// printWarnings prints warnings in their own section
+func printWarnings(w io.Writer, condition types.RunningCondition) {
+	if len(condition.Warnings) == 0 {
+		return
+	}
+	fmt.Fprintln(w, separator)
+	fmt.Fprintln(w, i18n.T("report.warnings"))
+	for _, warning := range condition.Warnings {
+		fmt.Fprintf(w, "   %s\n", warning)
+	}
+}
@@ -50,4 +50,4 @@ func TestGetRunningTempIcon(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
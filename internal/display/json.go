@@ -0,0 +1,498 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/report"
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// JSONSchemaVersion is incremented whenever the JSON output changes incompatibly
+const JSONSchemaVersion = 1
+
+// JSONReport is the top-level document written by -output json
+type JSONReport struct {
+	SchemaVersion int             `json:"schema_version"`
+	Mode          string          `json:"mode"` // current, time, date, datetime or best
+	Location      JSONLocation    `json:"location"`
+	DateSpec      string          `json:"date_spec,omitempty"`
+	Date          string          `json:"date,omitempty"`
+	TimeWindow    *JSONTimeWindow `json:"time_window,omitempty"`
+	Distance      *JSONDistance   `json:"distance"`
+	Daylight      *JSONDaylight   `json:"daylight,omitempty"`
+	DryWindow     *JSONDryWindow  `json:"dry_window"` // null when rain is possible throughout or for current conditions
+	DataAsOf      *time.Time      `json:"data_as_of,omitempty"`
+	Summary       *JSONCondition  `json:"summary,omitempty"`
+	Hours         []JSONCondition `json:"hours,omitempty"`
+	Best          *JSONBest       `json:"best,omitempty"`
+	Days          int             `json:"days,omitempty"` // days scanned in best mode
+	Candidates    []JSONCandidate `json:"candidates,omitempty"`
+}
+
+// JSONLocation describes the forecast location
+type JSONLocation struct {
+	Name             string  `json:"name"`
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+	Timezone         string  `json:"timezone"`           // timezone of the local times in the document
+	UTCOffsetSeconds int     `json:"utc_offset_seconds"` // offset of the local times from UTC
+}
+
+// JSONTimeWindow describes the requested time period
+type JSONTimeWindow struct {
+	Period    string `json:"period"`
+	Name      string `json:"name"`
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
+	Start     string `json:"start"` // HH:MM
+	End       string `json:"end"`
+}
+
+// JSONDistance describes the target distance category
+type JSONDistance struct {
+	Key   string  `json:"key"`
+	Name  string  `json:"name"`
+	MinKm float64 `json:"min_km"`
+	MaxKm float64 `json:"max_km"`
+}
+
+// JSONDaylight describes the sun times of the report date in local time
+type JSONDaylight struct {
+	Sunrise         string `json:"sunrise"`
+	Sunset          string `json:"sunset"`
+	CivilDawn       string `json:"civil_dawn"`
+	CivilDusk       string `json:"civil_dusk"`
+	DurationMinutes int    `json:"daylight_minutes"`
+}
+
+// JSONDryWindow describes the longest window without expected rain
+type JSONDryWindow struct {
+	Start              string  `json:"start"`
+	End                string  `json:"end"`
+	Hours              int     `json:"hours"`
+	DryProbability     float64 `json:"dry_probability"`
+	Confidence         string  `json:"confidence"` // high, medium or low
+	RunDurationMinutes int     `json:"run_duration_minutes"`
+	LongEnough         bool    `json:"long_enough"`
+}
+
+// JSONCondition is the weather and running assessment for an hour or a day
+type JSONCondition struct {
+	Time       string          `json:"time"`
+	Weather    JSONWeather     `json:"weather"`
+	Assessment JSONAssessment  `json:"assessment"`
+	HeatStress *JSONHeatStress `json:"heat_stress"`
+	Dust       *JSONDust       `json:"dust"`
+	Pace       *JSONPace       `json:"pace,omitempty"`
+	Hydration  *JSONHydration  `json:"hydration"`
+	Light      string          `json:"light,omitempty"` // day, twilight or dark for hourly entries
+	RouteWind  *JSONRouteWind  `json:"route_wind,omitempty"`
+	RunWindow  *JSONRunWindow  `json:"run_window"`
+}
+
+// JSONRunWindow aggregates the forecast hours the run covers
+type JSONRunWindow struct {
+	Hours              int     `json:"hours"`
+	WorstTime          string  `json:"worst_time"`
+	MinTemperature     float64 `json:"temperature_min"`
+	MaxTemperature     float64 `json:"temperature_max"`
+	TemperatureRise    float64 `json:"temperature_rise"`
+	PeakWBGT           float64 `json:"wbgt_max"`
+	MaxWindSpeed       float64 `json:"wind_speed_max"`
+	TotalPrecipitation float64 `json:"precipitation_total"`
+	RainChance         float64 `json:"rain_chance"`
+}
+
+// JSONWeather holds the weather values used for the assessment
+type JSONWeather struct {
+	Temperature         float64  `json:"temperature"`
+	TemperatureMin      *float64 `json:"temperature_min,omitempty"`
+	TemperatureMax      *float64 `json:"temperature_max,omitempty"`
+	ApparentTemperature *float64 `json:"apparent_temperature,omitempty"`
+	Humidity            *int     `json:"humidity,omitempty"`
+	DewPoint            *float64 `json:"dew_point,omitempty"`
+	DewPointComfort     string   `json:"dew_point_comfort,omitempty"`
+	WindSpeed           float64  `json:"wind_speed"`
+	WindDirection       *float64 `json:"wind_direction,omitempty"`
+	WindGusts           float64  `json:"wind_gusts"`
+	GustLevel           string   `json:"gust_level"`
+	ShortwaveRadiation  *float64 `json:"shortwave_radiation,omitempty"`
+	UVIndex             float64  `json:"uv_index"`
+	UVLevel             string   `json:"uv_level"`
+	Precipitation       float64  `json:"precipitation"`
+	PrecipitationChance float64  `json:"precipitation_probability"`
+	WeatherCode         int      `json:"weather_code"`
+	Description         string   `json:"description"`
+}
+
+// JSONAssessment mirrors types.RunningCondition
+type JSONAssessment struct {
+	Score          int      `json:"score"`
+	Level          string   `json:"level"`
+	LevelKey       string   `json:"level_key"`
+	Recommendation string   `json:"recommendation"`
+	Warnings       []string `json:"warnings"`
+	Clothing       []string `json:"clothing"`
+}
+
+// JSONHeatStress mirrors types.HeatStress
+type JSONHeatStress struct {
+	WBGT     float64 `json:"wbgt"`
+	Level    string  `json:"level"`
+	LevelKey string  `json:"level_key"`
+	Guidance string  `json:"guidance"`
+}
+
+// JSONPace mirrors types.PaceEstimate; paces are per km
+type JSONPace struct {
+	TargetPace          string  `json:"target_pace"`
+	TargetPaceSeconds   int     `json:"target_pace_seconds"`
+	AdjustedPace        string  `json:"adjusted_pace"`
+	AdjustedPaceSeconds int     `json:"adjusted_pace_seconds"`
+	HeatAdjustment      float64 `json:"heat_adjustment_percent"`
+	WindAdjustment      float64 `json:"wind_adjustment_percent"`
+	DistanceKm          float64 `json:"distance_km,omitempty"`
+	FinishTime          string  `json:"finish_time,omitempty"`
+	FinishTimeSeconds   int     `json:"finish_time_seconds,omitempty"`
+}
+
+// JSONHydration mirrors types.HydrationPlan; durations are in minutes
+type JSONHydration struct {
+	DurationMinutes    int     `json:"duration_minutes"`
+	SweatRate          float64 `json:"sweat_rate"`
+	SweatRateEstimated bool    `json:"sweat_rate_estimated"`
+	FluidPerHour       int     `json:"fluid_ml_per_hour"`
+	TotalFluid         int     `json:"fluid_ml_total"`
+	SodiumPerHour      int     `json:"sodium_mg_per_hour"`
+	GelCount           int     `json:"gels"`
+	FirstGelMinutes    int     `json:"first_gel_minutes,omitempty"`
+	GelIntervalMinutes int     `json:"gel_interval_minutes,omitempty"`
+}
+
+// JSONRouteWind mirrors types.RouteWind; bearings are degrees clockwise from north
+type JSONRouteWind struct {
+	Bearing      float64 `json:"bearing"`
+	Headwind     float64 `json:"headwind"`
+	Crosswind    float64 `json:"crosswind"`
+	StartBearing float64 `json:"start_bearing"`
+	Advice       string  `json:"advice"`
+}
+
+// JSONDust mirrors types.DustLevel
+type JSONDust struct {
+	Level       int     `json:"level"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Dust        float64 `json:"dust"`
+	PM10        float64 `json:"pm10"`
+	PM2_5       float64 `json:"pm2_5"`
+}
+
+// JSONBest is the best start time within the requested time window
+type JSONBest struct {
+	Time  string `json:"time"`
+	Score int    `json:"score"`
+}
+
+// JSONCandidate is a start time ranked in best mode
+type JSONCandidate struct {
+	Rank            int      `json:"rank"`
+	DurationMinutes int      `json:"duration_minutes"`
+	Reasons         []string `json:"reasons"`
+	JSONCondition
+}
+
+// newJSONLocation describes the location of the report with the timezone of its local times
+func newJSONLocation(r *report.Report) JSONLocation {
+	return JSONLocation{
+		Name:             r.Location.Name,
+		Latitude:         r.Location.Lat,
+		Longitude:        r.Location.Lon,
+		Timezone:         reportLocation(r).String(),
+		UTCOffsetSeconds: r.UTCOffset,
+	}
+}
+
+// RenderJSON writes a running report as a JSON document
+func RenderJSON(w io.Writer, r *report.Report) error {
+	doc := &JSONReport{
+		SchemaVersion: JSONSchemaVersion,
+		Mode:          r.Mode,
+		Location:      newJSONLocation(r),
+		DateSpec:      r.DateSpec,
+		Date:          r.Date,
+	}
+	if r.Period != nil {
+		doc.TimeWindow = &JSONTimeWindow{
+			Period:    r.Period.Key,
+			Name:      r.Period.DisplayName,
+			StartHour: r.Period.StartHour,
+			EndHour:   r.Period.EndHour,
+			Start:     fmt.Sprintf("%02d:%02d", r.Period.StartHour, r.Period.StartMinute),
+			End:       fmt.Sprintf("%02d:%02d", r.Period.EndHour, r.Period.EndMinute),
+		}
+	}
+	if r.Distance != nil {
+		doc.Distance = &JSONDistance{
+			Key:   r.Distance.Key,
+			Name:  r.Distance.DisplayName,
+			MinKm: r.Distance.MinKm,
+			MaxKm: r.Distance.MaxKm,
+		}
+	}
+	if r.Daylight != nil {
+		doc.Daylight = &JSONDaylight{
+			Sunrise:         r.Daylight.Sunrise.Format(weather.ForecastTimeLayout),
+			Sunset:          r.Daylight.Sunset.Format(weather.ForecastTimeLayout),
+			CivilDawn:       r.Daylight.CivilDawn.Format(weather.ForecastTimeLayout),
+			CivilDusk:       r.Daylight.CivilDusk.Format(weather.ForecastTimeLayout),
+			DurationMinutes: int(r.Daylight.Duration.Minutes()),
+		}
+	}
+	if r.DryWindow != nil {
+		doc.DryWindow = &JSONDryWindow{
+			Start:              r.DryWindow.Start,
+			End:                r.DryWindow.End,
+			Hours:              r.DryWindow.Hours,
+			DryProbability:     r.DryWindow.DryProbability,
+			Confidence:         r.DryWindow.Confidence,
+			RunDurationMinutes: int(r.DryWindow.RunDuration.Minutes()),
+			LongEnough:         r.DryWindow.LongEnough,
+		}
+	}
+	if r.FromCache {
+		fetchedAt := r.FetchedAt.In(reportLocation(r)).Truncate(time.Second)
+		doc.DataAsOf = &fetchedAt
+	}
+	if r.Summary != nil {
+		summary := newJSONCondition(*r.Summary)
+		doc.Summary = &summary
+	}
+	for _, entry := range r.Hours {
+		doc.Hours = append(doc.Hours, newJSONCondition(entry))
+	}
+	if r.Best != nil {
+		doc.Best = &JSONBest{Time: r.Best.Time, Score: r.Best.Condition.Score}
+	}
+	if r.Mode == report.ModeBest {
+		doc.Days = r.Days
+	}
+	for _, candidate := range r.Candidates {
+		doc.Candidates = append(doc.Candidates, JSONCandidate{
+			Rank:            candidate.Rank,
+			DurationMinutes: int(candidate.Duration.Minutes()),
+			Reasons:         candidate.Reasons,
+			JSONCondition:   newJSONCondition(candidate.Entry),
+		})
+	}
+
+	return writeJSON(w, doc)
+}
+
+// newJSONCondition converts a report entry; daily entries carry min/max instead of hourly-only fields
+func newJSONCondition(entry report.Entry) JSONCondition {
+	data := entry.Weather
+	condition := JSONCondition{
+		Time: entry.Time,
+		Weather: JSONWeather{
+			Temperature:         data.Temperature,
+			WindSpeed:           data.WindSpeed,
+			WindGusts:           data.WindGusts,
+			GustLevel:           running.GetGustLevelKey(data.WindGusts),
+			Precipitation:       data.Precipitation,
+			PrecipitationChance: data.PrecipitationProbability,
+			WeatherCode:         data.WeatherCode,
+			UVIndex:             data.UVIndex,
+			UVLevel:             running.GetUVLevelKey(data.UVIndex),
+			Description:         weather.GetWeatherDescription(data.WeatherCode),
+		},
+		Assessment: newJSONAssessment(entry.Condition),
+		HeatStress: newJSONHeatStress(entry.Condition.HeatStress),
+		Dust:       newJSONDust(entry.Dust),
+		Pace:       newJSONPace(entry.Pace),
+		Hydration:  newJSONHydration(entry.Hydration),
+		Light:      entry.Light,
+		RouteWind:  newJSONRouteWind(entry.RouteWind),
+		RunWindow:  newJSONRunWindow(entry.Condition.Window),
+	}
+
+	if entry.Daily != nil {
+		condition.Weather.TemperatureMin = &entry.Daily.TemperatureMin
+		condition.Weather.TemperatureMax = &entry.Daily.TemperatureMax
+	} else {
+		condition.Weather.ApparentTemperature = &data.ApparentTemp
+		condition.Weather.Humidity = &data.Humidity
+		condition.Weather.DewPoint = &data.DewPoint
+		condition.Weather.DewPointComfort = running.GetDewPointComfortKey(data.DewPoint)
+		condition.Weather.WindDirection = &data.WindDirection
+		condition.Weather.ShortwaveRadiation = &data.ShortwaveRadiation
+	}
+
+	return condition
+}
+
+// newJSONAssessment converts a running condition, using empty lists instead of null
+func newJSONAssessment(condition types.RunningCondition) JSONAssessment {
+	assessment := JSONAssessment{
+		Score:          condition.Score,
+		Level:          condition.Level,
+		LevelKey:       running.GetLevelKey(condition.Score),
+		Recommendation: condition.Recommendation,
+		Warnings:       condition.Warnings,
+		Clothing:       condition.Clothing,
+	}
+	if assessment.Warnings == nil {
+		assessment.Warnings = []string{}
+	}
+	if assessment.Clothing == nil {
+		assessment.Clothing = []string{}
+	}
+	return assessment
+}
+
+// newJSONHeatStress converts a heat stress assessment, returning nil when there is none
+func newJSONHeatStress(heatStress *types.HeatStress) *JSONHeatStress {
+	if heatStress == nil {
+		return nil
+	}
+	return &JSONHeatStress{
+		WBGT:     heatStress.WBGT,
+		Level:    heatStress.DisplayName,
+		LevelKey: heatStress.Level,
+		Guidance: heatStress.Guidance,
+	}
+}
+
+// newJSONPace converts a pace estimate, returning nil without a target pace
+func newJSONPace(pace *types.PaceEstimate) *JSONPace {
+	if pace == nil {
+		return nil
+	}
+	doc := &JSONPace{
+		TargetPace:          config.FormatPace(pace.TargetPace),
+		TargetPaceSeconds:   int(pace.TargetPace.Seconds()),
+		AdjustedPace:        config.FormatPace(pace.AdjustedPace),
+		AdjustedPaceSeconds: int(pace.AdjustedPace.Seconds()),
+		HeatAdjustment:      math.Round(pace.HeatAdjustment*1000) / 10,
+		WindAdjustment:      math.Round(pace.WindAdjustment*1000) / 10,
+		DistanceKm:          pace.DistanceKm,
+	}
+	if pace.FinishTime > 0 {
+		doc.FinishTime = formatDuration(pace.FinishTime)
+		doc.FinishTimeSeconds = int(pace.FinishTime.Seconds())
+	}
+	return doc
+}
+
+// newJSONHydration converts a hydration plan, returning nil when there is none
+func newJSONHydration(plan *types.HydrationPlan) *JSONHydration {
+	if plan == nil {
+		return nil
+	}
+	return &JSONHydration{
+		DurationMinutes:    int(plan.Duration.Minutes()),
+		SweatRate:          plan.SweatRate,
+		SweatRateEstimated: plan.SweatRateEstimated,
+		FluidPerHour:       plan.FluidPerHour,
+		TotalFluid:         plan.TotalFluid,
+		SodiumPerHour:      plan.SodiumPerHour,
+		GelCount:           plan.GelCount,
+		FirstGelMinutes:    int(plan.FirstGel.Minutes()),
+		GelIntervalMinutes: int(plan.GelInterval.Minutes()),
+	}
+}
+
+// newJSONRouteWind converts the route wind, returning nil without a route
+func newJSONRouteWind(routeWind *types.RouteWind) *JSONRouteWind {
+	if routeWind == nil {
+		return nil
+	}
+	return &JSONRouteWind{
+		Bearing:      routeWind.Bearing,
+		Headwind:     math.Round(routeWind.Headwind*10) / 10,
+		Crosswind:    math.Round(routeWind.Crosswind*10) / 10,
+		StartBearing: routeWind.StartBearing,
+		Advice:       routeWind.Advice,
+	}
+}
+
+// newJSONDust converts a dust level, returning nil when air quality data is unavailable
+func newJSONDust(dustLevel *types.DustLevel) *JSONDust {
+	if dustLevel == nil {
+		return nil
+	}
+	return &JSONDust{
+		Level:       dustLevel.Level,
+		Name:        dustLevel.DisplayName,
+		Description: dustLevel.Description,
+		Dust:        dustLevel.Dust,
+		PM10:        dustLevel.PM10,
+		PM2_5:       dustLevel.PM2_5,
+	}
+}
+
+// JSONCities is the document listing the cities, also served by /v1/cities
+type JSONCities struct {
+	Cities []JSONCity `json:"cities"`
+}
+
+// JSONCity describes a location that can be given as the city
+type JSONCity struct {
+	Key       string  `json:"key"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Custom    bool    `json:"custom"` // defined in the config file
+}
+
+// NewJSONCities converts the city list
+func NewJSONCities(cities []weather.City) *JSONCities {
+	doc := &JSONCities{Cities: make([]JSONCity, 0, len(cities))}
+	for _, city := range cities {
+		doc.Cities = append(doc.Cities, JSONCity{
+			Key:       city.Key,
+			Name:      city.Coordinate.Name,
+			Latitude:  city.Coordinate.Lat,
+			Longitude: city.Coordinate.Lon,
+			Custom:    city.Custom,
+		})
+	}
+	return doc
+}
+
+// RenderCitiesJSON writes the city list as JSON
+func RenderCitiesJSON(w io.Writer, cities []weather.City) error {
+	return writeJSON(w, NewJSONCities(cities))
+}
+
+// writeJSON writes an indented JSON document
+func writeJSON(w io.Writer, doc any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// newJSONRunWindow converts the run window summary, nil when there is none
+func newJSONRunWindow(window *types.RunWindowSummary) *JSONRunWindow {
+	if window == nil {
+		return nil
+	}
+	return &JSONRunWindow{
+		Hours:              window.Hours,
+		WorstTime:          window.WorstTime,
+		MinTemperature:     window.MinTemperature,
+		MaxTemperature:     window.MaxTemperature,
+		TemperatureRise:    math.Round(window.TemperatureRise*10) / 10,
+		PeakWBGT:           window.PeakWBGT,
+		MaxWindSpeed:       window.MaxWindSpeed,
+		TotalPrecipitation: math.Round(window.TotalPrecipitation*10) / 10,
+		RainChance:         window.RainChance,
+	}
+}
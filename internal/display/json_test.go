@@ -0,0 +1,234 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"runcast/internal/i18n"
+	"runcast/internal/report"
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+func TestRenderJSON(t *testing.T) {
+	weatherData := &types.WeatherData{}
+	weatherData.Daily.Time = []string{"2025-07-05"}
+	weatherData.Hourly.Time = []string{"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00"}
+	weatherData.Hourly.Temperature = []float64{18.0, 20.0, 31.0}
+	weatherData.Hourly.ApparentTemp = []float64{18.0, 20.0, 36.0}
+	weatherData.Hourly.Humidity = []int{60, 55, 80}
+	weatherData.Hourly.WindSpeed = []float64{2.0, 2.0, 2.0}
+	weatherData.Hourly.WindDirection = []float64{0, 90, 180}
+	weatherData.Hourly.Precipitation = []float64{0, 0, 0}
+	weatherData.Hourly.WeatherCode = []int{0, 1, 0}
+	weatherData.Hourly.PrecipitationProbability = []float64{0, 40, 10}
+
+	airQuality := &types.AirQualityData{}
+	airQuality.Hourly.Time = []string{"2025-07-05T06:00"}
+	airQuality.Hourly.Dust = []float64{150}
+	airQuality.Hourly.PM10 = []float64{60}
+	airQuality.Hourly.PM2_5 = []float64{20}
+
+	runningReport, err := report.Build(report.Request{
+		Location:  types.CityCoordinate{Name: "東京", Lat: 35.6762, Lon: 139.6503},
+		TimeOfDay: "morning",
+		Days:      1,
+		Distance:  running.GetDistanceCategory("10k"),
+		Now:       time.Date(2025, 7, 5, 4, 0, 0, 0, weather.DefaultLocation),
+	}, weatherData, airQuality)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderJSON(&buf, runningReport); err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+
+	var doc JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc.SchemaVersion != JSONSchemaVersion || doc.Mode != "time" {
+		t.Errorf("Unexpected header: schema_version=%d mode=%s", doc.SchemaVersion, doc.Mode)
+	}
+	if doc.Location.Name != "東京" || doc.Location.Latitude != 35.6762 || doc.Location.Timezone != "Asia/Tokyo" || doc.Location.UTCOffsetSeconds != 9*60*60 {
+		t.Errorf("Unexpected location: %+v", doc.Location)
+	}
+	if doc.TimeWindow == nil || doc.TimeWindow.Period != "morning" || doc.TimeWindow.StartHour != 5 {
+		t.Errorf("Unexpected time window: %+v", doc.TimeWindow)
+	}
+	if doc.Distance == nil || doc.Distance.Key != "10k" {
+		t.Errorf("Unexpected distance: %+v", doc.Distance)
+	}
+	if len(doc.Hours) != 3 {
+		t.Fatalf("Expected 3 hours, got %d", len(doc.Hours))
+	}
+
+	// Dust is only available for 06:00
+	if doc.Hours[0].Dust != nil {
+		t.Errorf("Expected no dust data for 05:00, got %+v", doc.Hours[0].Dust)
+	}
+	if doc.Hours[1].Dust == nil || doc.Hours[1].Dust.Level != 2 {
+		t.Errorf("Expected dust level 2 for 06:00, got %+v", doc.Hours[1].Dust)
+	}
+
+	// 31°C at 80% humidity is in the severe WBGT band
+	if heatStress := doc.Hours[2].HeatStress; heatStress == nil || heatStress.LevelKey != "severe" {
+		t.Errorf("Expected severe heat stress at 07:00, got %+v", heatStress)
+	}
+
+	// Without a target pace a 10k is planned at the default pace
+	if hydration := doc.Hours[0].Hydration; hydration == nil || hydration.DurationMinutes != 60 || hydration.FluidPerHour == 0 {
+		t.Errorf("Expected a one hour hydration plan at 05:00, got %+v", hydration)
+	}
+
+	// 06:00 is likely to see rain, leaving 05:00 and 07:00 as one hour windows
+	if window := doc.DryWindow; window == nil || window.Start != "2025-07-05T05:00" || window.End != "2025-07-05T06:00" || !window.LongEnough {
+		t.Errorf("Expected a dry window from 05:00 to 06:00, got %+v", window)
+	}
+	if doc.Hours[1].Weather.PrecipitationChance != 40 {
+		t.Errorf("Expected a 40%% chance of rain at 06:00, got %.0f", doc.Hours[1].Weather.PrecipitationChance)
+	}
+
+	for _, hour := range doc.Hours {
+		if hour.Assessment.LevelKey != running.GetLevelKey(hour.Assessment.Score) {
+			t.Errorf("Level key %s does not match score %d", hour.Assessment.LevelKey, hour.Assessment.Score)
+		}
+		if hour.Assessment.Warnings == nil || hour.Assessment.Clothing == nil {
+			t.Errorf("Warnings and clothing should be empty lists, not null, at %s", hour.Time)
+		}
+	}
+
+	// 05:00 has no dust penalty and mild weather, so it should be the best pick
+	if doc.Best == nil || doc.Best.Time != "2025-07-05T05:00" {
+		t.Errorf("Expected best time 2025-07-05T05:00, got %+v", doc.Best)
+	}
+	if doc.Best != nil && doc.Best.Score != doc.Hours[0].Assessment.Score {
+		t.Errorf("Best score %d does not match hour score %d", doc.Best.Score, doc.Hours[0].Assessment.Score)
+	}
+}
+
+func TestRenderJSONBest(t *testing.T) {
+	weatherData := &types.WeatherData{}
+	weatherData.Daily.Time = []string{"2025-07-05"}
+	weatherData.Hourly.Time = []string{"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00"}
+	weatherData.Hourly.Temperature = []float64{18.0, 31.0, 20.0}
+	weatherData.Hourly.ApparentTemp = []float64{18.0, 36.0, 20.0}
+	weatherData.Hourly.Humidity = []int{60, 80, 55}
+	weatherData.Hourly.WindSpeed = []float64{2.0, 2.0, 2.0}
+	weatherData.Hourly.WindDirection = []float64{0, 90, 180}
+	weatherData.Hourly.Precipitation = []float64{0, 0, 0}
+	weatherData.Hourly.WeatherCode = []int{0, 1, 0}
+
+	runningReport, err := report.Build(report.Request{Best: true, Days: 1, Top: 2, Now: time.Date(2025, 7, 5, 4, 0, 0, 0, weather.DefaultLocation)}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderJSON(&buf, runningReport); err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+	var doc JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc.Mode != "best" || doc.Days != 1 || len(doc.Candidates) != 2 {
+		t.Fatalf("Unexpected best document: mode=%s days=%d candidates=%d", doc.Mode, doc.Days, len(doc.Candidates))
+	}
+	first := doc.Candidates[0]
+	if first.Rank != 1 || first.Time == "2025-07-05T06:00" || len(first.Reasons) == 0 || first.DurationMinutes != 60 {
+		t.Errorf("Unexpected top candidate: %+v", first)
+	}
+	if doc.Best == nil || doc.Best.Time != first.Time || doc.Best.Score != first.Assessment.Score {
+		t.Errorf("Expected best to match the top candidate, got %+v", doc.Best)
+	}
+}
+
+func TestRenderCities(t *testing.T) {
+	cities := []weather.City{
+		{Key: "tokyo", Coordinate: types.CityCoordinate{Name: "東京", Lat: 35.6762, Lon: 139.6503}},
+		{Key: "home", Coordinate: types.CityCoordinate{Name: "自宅", Lat: 35.6, Lon: 139.6}, Custom: true},
+	}
+
+	var text bytes.Buffer
+	RenderCities(&text, cities)
+	if !strings.Contains(text.String(), "tokyo") || !strings.Contains(text.String(), i18n.T("cities.custom")) {
+		t.Errorf("Expected the cities and the custom section, got:\n%s", text.String())
+	}
+
+	var buf bytes.Buffer
+	if err := RenderCitiesJSON(&buf, cities); err != nil {
+		t.Fatalf("RenderCitiesJSON returned an error: %v", err)
+	}
+	var doc JSONCities
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(doc.Cities) != 2 || doc.Cities[0].Key != "tokyo" || doc.Cities[0].Custom || !doc.Cities[1].Custom {
+		t.Errorf("Unexpected cities %+v", doc.Cities)
+	}
+}
+
+func TestRenderTimeZoneWithoutFetchTime(t *testing.T) {
+	// Without the cache the forecast has no fetch time, whose zero value would give the local mean time
+	tests := []struct {
+		timezone string
+		offset   int
+		text     string
+	}{
+		{"Asia/Tokyo", 9 * 60 * 60, ""},
+		{"America/New_York", -4 * 60 * 60, "-04:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			weatherData := &types.WeatherData{Timezone: tt.timezone, UTCOffsetSeconds: tt.offset}
+			r := report.BuildCurrent(report.Request{Now: time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)}, weatherData, nil)
+			if !r.FetchedAt.IsZero() {
+				t.Fatalf("Expected no fetch time, got %v", r.FetchedAt)
+			}
+			if location := newJSONLocation(r); location.Timezone != tt.timezone || location.UTCOffsetSeconds != tt.offset {
+				t.Errorf("Expected %s %d in JSON, got %+v", tt.timezone, tt.offset, location)
+			}
+			var buf bytes.Buffer
+			printTimeZone(&buf, r)
+			if tt.text == "" && buf.Len() != 0 || !strings.Contains(buf.String(), tt.text) {
+				t.Errorf("Expected the offset %q, got %q", tt.text, buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderTimeZone(t *testing.T) {
+	r := &report.Report{Mode: report.ModeCurrent, Location: types.CityCoordinate{Name: "東京"}}
+	var buf bytes.Buffer
+	printTimeZone(&buf, r)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no timezone line for Japan, got %q", buf.String())
+	}
+
+	// Forecasts carry the IANA zone, named JST or EDT by time.Time.Zone
+	r.TimeZone, _ = time.LoadLocation("Asia/Tokyo")
+	printTimeZone(&buf, r)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no timezone line for a forecast in Japan, got %q", buf.String())
+	}
+
+	r.TimeZone, _ = time.LoadLocation("America/New_York")
+	r.UTCOffset = -4 * 60 * 60
+	printTimeZone(&buf, r)
+	if !strings.Contains(buf.String(), "America/New_York") || !strings.Contains(buf.String(), "-04:00") {
+		t.Errorf("Expected the local timezone, got %q", buf.String())
+	}
+	if location := newJSONLocation(r); location.Timezone != "America/New_York" || location.UTCOffsetSeconds != -4*60*60 {
+		t.Errorf("Expected the IANA timezone in JSON, got %+v", location)
+	}
+}
@@ -2,135 +2,118 @@ package display
 
 import (
 	"fmt"
+	"io"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/i18n"
+	"runcast/internal/report"
 	"runcast/internal/running"
-	"runcast/internal/types"
 	"runcast/internal/weather"
 )
 
-// DisplayTimeBasedRunningWeatherWithDistance displays time-based running weather with distance consideration
-func DisplayTimeBasedRunningWeatherWithDistance(weatherData *types.WeatherData, cityName, timeOfDay string, days int, distanceCategory *types.DistanceCategory) {
-	DisplayTimeBasedRunningWeatherWithDistanceAndDust(weatherData, cityName, timeOfDay, days, distanceCategory, nil)
-}
+// renderHours writes the hourly assessments of a time period and the best start time
+func renderHours(w io.Writer, r *report.Report) {
+	printHeader(w, r)
 
-// DisplayTimeBasedRunningWeatherWithDistanceAndDust displays time-based running weather with distance and dust consideration
-func DisplayTimeBasedRunningWeatherWithDistanceAndDust(weatherData *types.WeatherData, cityName, timeOfDay string, days int, distanceCategory *types.DistanceCategory, airQuality *types.AirQualityData) {
-	periods := weather.GetTimePeriods()
-	period := periods[timeOfDay]
-	
-	timeData := weather.ExtractTimeBasedWeather(weatherData, timeOfDay, days)
-	if len(timeData) == 0 {
-		fmt.Println("指定された時間帯のデータが見つかりません")
-		return
-	}
-	
-	var titleSuffix string
-	if distanceCategory != nil {
-		titleSuffix = fmt.Sprintf("(%s)", distanceCategory.DisplayName)
+	if r.Mode == report.ModeDateTime {
+		fmt.Fprintln(w, i18n.T("report.hours.datetime", r.DateLabel, r.Period.DisplayName, weather.FormatPeriodRange(*r.Period)))
+		printDaylight(w, r.Daylight)
 	} else {
-		titleSuffix = ""
-	}
-	
-	fmt.Printf("🏃‍♂️ %s の%s時間帯ランニング情報%s\n", cityName, period.DisplayName, titleSuffix)
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	
-	// Distance category info
-	if distanceCategory != nil {
-		fmt.Printf("📏 目標距離: %s (%.1f-%.1fkm)\n", distanceCategory.DisplayName, distanceCategory.MinKm, distanceCategory.MaxKm)
-		fmt.Printf("💭 %s\n", distanceCategory.Description)
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Fprintln(w, i18n.T("report.hours.time", r.Period.DisplayName, weather.FormatPeriodRange(*r.Period)))
 	}
-	
-	bestCondition := types.TimeBasedWeather{}
-	bestScore := -1
-	bestTime := ""
-	
-	fmt.Printf("⏰ %s時間帯詳細 (%d:00-%d:00)\n", period.DisplayName, period.StartHour, period.EndHour)
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	
-	for _, data := range timeData {
-		var condition types.RunningCondition
-		if distanceCategory != nil {
-			condition = running.AssessDistanceBasedRunningCondition(
-				data.Temperature,
-				data.ApparentTemp,
-				float64(data.Humidity),
-				data.WindSpeed,
-				data.Precipitation,
-				data.WeatherCode,
-				distanceCategory,
-			)
-		} else {
-			condition = running.AssessRunningCondition(
-				data.Temperature,
-				data.ApparentTemp,
-				float64(data.Humidity),
-				data.WindSpeed,
-				data.Precipitation,
-				data.WeatherCode,
-			)
-		}
+	fmt.Fprintln(w, separator)
 
-		// Get dust level for this hour
-		hour := weather.ExtractHour(data.Time)
-		hourInt := weather.ExtractHourInt(data.Time)
-		dustLevel := weather.GetHourlyDustLevel(airQuality, hourInt, days)
-		running.ApplyDustPenalty(&condition, dustLevel, distanceCategory)
+	for _, entry := range r.Hours {
+		data := entry.Weather
+		condition := entry.Condition
 
-		fmt.Printf("🕐 %s時: %d/100 (%s)\n", hour, condition.Score, condition.Level)
-		fmt.Printf("   🌡️ %.1f°C (体感: %.1f°C) | 💧 %d%% | 🌬️ %s %.1fm/s\n",
-			data.Temperature, data.ApparentTemp, data.Humidity, weather.GetWindDirection(data.WindDirection), data.WindSpeed)
-		fmt.Printf("   ☁️ %s", weather.GetWeatherDescription(data.WeatherCode))
+		fmt.Fprintln(w, i18n.T("report.hour", weather.ExtractHour(entry.Time), condition.Score, condition.Level))
+		fmt.Fprint(w, i18n.T("report.hour.details",
+			data.Temperature, data.ApparentTemp, data.Humidity, data.DewPoint, weather.GetWindDirection(data.WindDirection), data.WindSpeed))
+		if running.GetGustLevelKey(data.WindGusts) != "calm" {
+			fmt.Fprint(w, i18n.T("report.hour.gusts", data.WindGusts))
+		}
+		if condition.HeatStress != nil {
+			fmt.Fprint(w, i18n.T("report.hour.wbgt", condition.HeatStress.WBGT, condition.HeatStress.DisplayName))
+		}
+		if running.GetUVLevelKey(data.UVIndex) != "low" {
+			fmt.Fprint(w, i18n.T("report.hour.uv", data.UVIndex, running.GetUVLevelName(data.UVIndex)))
+		}
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "   ☁️ %s", weather.GetWeatherDescription(data.WeatherCode))
 		if data.Precipitation > 0 {
-			fmt.Printf(" | 🌧️ %.1fmm", data.Precipitation)
+			fmt.Fprint(w, i18n.T("weather.precipitation_short", data.Precipitation))
+		}
+		if data.PrecipitationProbability >= running.GetScoring().Thresholds.DryProbability {
+			fmt.Fprint(w, i18n.T("report.hour.rain_chance", data.PrecipitationProbability))
 		}
-		if dustLevel != nil {
-			fmt.Printf(" | 🌫️ %s", dustLevel.DisplayName)
+		if entry.Dust != nil {
+			fmt.Fprintf(w, " | 🌫️ %s", entry.Dust.DisplayName)
 		}
-		fmt.Printf("\n")
+		fmt.Fprintf(w, "\n")
+		if entry.Pace != nil {
+			fmt.Fprintln(w, i18n.T("report.hour.pace", config.FormatPace(entry.Pace.AdjustedPace), (entry.Pace.HeatAdjustment+entry.Pace.WindAdjustment)*100))
+		}
+		fmt.Fprintf(w, "   ────────────────────────────\n")
+	}
+
+	printDryWindow(w, r)
+
+	// Best time recommendation
+	if r.Best != nil {
+		fmt.Fprintln(w, i18n.T("report.best_time", weather.ExtractHour(r.Best.Time), r.Best.Condition.Score))
+		fmt.Fprintf(w, "💡 %s\n", r.Best.Condition.Recommendation)
+		printRunWindow(w, r.Best.Condition.Window)
+		printRouteWind(w, r.Best.RouteWind)
+		printPace(w, r, r.Best.Pace)
+		printHydration(w, r.Best.Hydration)
 
-		if condition.Score > bestScore {
-			bestScore = condition.Score
-			bestCondition = data
-			bestTime = hour
+		if len(r.Best.Condition.Warnings) > 0 {
+			fmt.Fprintln(w, i18n.T("report.warnings"))
+			for _, warning := range r.Best.Condition.Warnings {
+				fmt.Fprintf(w, "   %s\n", warning)
+			}
 		}
+	}
+
+	fmt.Fprintln(w, separator)
+}
 
-		fmt.Printf("   ────────────────────────────\n")
+// renderBest writes the ranked start times over the scanned days
+func renderBest(w io.Writer, r *report.Report) {
+	printHeader(w, r)
+
+	minutes := int(r.Candidates[0].Duration.Minutes())
+	if r.Period != nil {
+		fmt.Fprintln(w, i18n.T("report.best.scope_period", r.Period.DisplayName, weather.FormatPeriodRange(*r.Period), minutes))
+	} else {
+		fmt.Fprintln(w, i18n.T("report.best.scope_all", weather.FormatPeriodRange(weather.GetRunnablePeriod()), minutes))
 	}
-	
-	// Best time recommendation
-	if bestScore >= 0 {
-		var bestRunningCondition types.RunningCondition
-		if distanceCategory != nil {
-			bestRunningCondition = running.AssessDistanceBasedRunningCondition(
-				bestCondition.Temperature,
-				bestCondition.ApparentTemp,
-				float64(bestCondition.Humidity),
-				bestCondition.WindSpeed,
-				bestCondition.Precipitation,
-				bestCondition.WeatherCode,
-				distanceCategory,
-			)
-		} else {
-			bestRunningCondition = running.AssessRunningCondition(
-				bestCondition.Temperature,
-				bestCondition.ApparentTemp,
-				float64(bestCondition.Humidity),
-				bestCondition.WindSpeed,
-				bestCondition.Precipitation,
-				bestCondition.WeatherCode,
-			)
+	fmt.Fprintln(w, separator)
+
+	for _, candidate := range r.Candidates {
+		weekday := ""
+		if date, err := time.Parse(weather.ForecastTimeLayout, candidate.Time); err == nil {
+			weekday = i18n.T(fmt.Sprintf("weekday.%d", date.Weekday()))
 		}
-		
-		fmt.Printf("🏆 最適時間: %s時 (スコア: %d/100)\n", bestTime, bestScore)
-		fmt.Printf("💡 %s\n", bestRunningCondition.Recommendation)
-		
-		if len(bestRunningCondition.Warnings) > 0 {
-			fmt.Printf("⚠️ 注意事項:\n")
-			for _, warning := range bestRunningCondition.Warnings {
-				fmt.Printf("   %s\n", warning)
-			}
+		fmt.Fprintln(w, i18n.T("report.best.rank", candidate.Rank, weather.FormatDate(candidate.Time), weekday,
+			weather.ExtractHour(candidate.Time), candidate.Condition.Score, candidate.Condition.Level))
+		if window := candidate.Condition.Window; window != nil && window.WorstTime != candidate.Time {
+			fmt.Fprintln(w, i18n.T("report.best.worst", weather.ExtractHour(window.WorstTime)))
 		}
+		for _, reason := range candidate.Reasons {
+			fmt.Fprintf(w, "   %s\n", reason)
+		}
+		printRouteWind(w, candidate.RouteWind)
+		fmt.Fprintf(w, "   ────────────────────────────\n")
 	}
-	
-	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-}
\ No newline at end of file
+
+	// Pace and fueling for the top pick
+	if r.Best != nil {
+		printPace(w, r, r.Best.Pace)
+		printHydration(w, r.Best.Hydration)
+	}
+
+	fmt.Fprintln(w, separator)
+}
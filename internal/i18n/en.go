@@ -0,0 +1,557 @@
+package i18n
+
+// en is the English message catalog
+var en = map[string]string{
+	// Weather descriptions (WMO weather codes)
+	"weather.code.0":  "Clear sky",
+	"weather.code.1":  "Mainly clear",
+	"weather.code.2":  "Partly cloudy",
+	"weather.code.3":  "Overcast",
+	"weather.code.45": "Fog",
+	"weather.code.48": "Rime fog",
+	"weather.code.51": "Light drizzle",
+	"weather.code.53": "Drizzle",
+	"weather.code.55": "Dense drizzle",
+	"weather.code.56": "Light freezing drizzle",
+	"weather.code.57": "Freezing drizzle",
+	"weather.code.61": "Light rain",
+	"weather.code.63": "Rain",
+	"weather.code.65": "Heavy rain",
+	"weather.code.66": "Light freezing rain",
+	"weather.code.67": "Freezing rain",
+	"weather.code.71": "Light snow",
+	"weather.code.73": "Snow",
+	"weather.code.75": "Heavy snow",
+	"weather.code.77": "Snow grains",
+	"weather.code.80": "Light showers",
+	"weather.code.81": "Showers",
+	"weather.code.82": "Heavy showers",
+	"weather.code.85": "Light snow showers",
+	"weather.code.86": "Snow showers",
+	"weather.code.95": "Thunderstorm",
+	"weather.code.96": "Thunderstorm with hail",
+	"weather.code.99": "Thunderstorm with heavy hail",
+	"weather.unknown": "Unknown",
+
+	// Wind directions, clockwise from north
+	"wind.0":  "N",
+	"wind.1":  "NNE",
+	"wind.2":  "NE",
+	"wind.3":  "ENE",
+	"wind.4":  "E",
+	"wind.5":  "ESE",
+	"wind.6":  "SE",
+	"wind.7":  "SSE",
+	"wind.8":  "S",
+	"wind.9":  "SSW",
+	"wind.10": "SW",
+	"wind.11": "WSW",
+	"wind.12": "W",
+	"wind.13": "WNW",
+	"wind.14": "NW",
+	"wind.15": "NNW",
+
+	// Dust levels
+	"dust.0.name": "None",
+	"dust.0.desc": "No Asian dust",
+	"dust.1.name": "Low",
+	"dust.1.desc": "Traces of Asian dust",
+	"dust.2.name": "Moderate",
+	"dust.2.desc": "May reduce visibility",
+	"dust.3.name": "High",
+	"dust.3.desc": "Take care outdoors",
+	"dust.4.name": "Very high",
+	"dust.4.desc": "Avoid outdoor activity",
+
+	// Time periods
+	"period.morning": "early morning",
+	"period.noon":    "midday",
+	"period.evening": "evening",
+	"period.night":   "night",
+
+	// Dates
+	"date.today":              "today",
+	"date.tomorrow":           "tomorrow",
+	"date.day_after_tomorrow": "the day after tomorrow",
+	"date.days_later":         "in %d days",
+	"date.weekday":            "%s",
+	"date.next_weekday":       "next %s",
+	"date.iso":                "%[3]s, %[4]s %[2]d",
+	"date.other":              "%s",
+	"date.layout":             "Jan 2",
+	"weekday.0":               "Sunday",
+	"weekday.1":               "Monday",
+	"weekday.2":               "Tuesday",
+	"weekday.3":               "Wednesday",
+	"weekday.4":               "Thursday",
+	"weekday.5":               "Friday",
+	"weekday.6":               "Saturday",
+	"weekday.short.0":         "Sun",
+	"weekday.short.1":         "Mon",
+	"weekday.short.2":         "Tue",
+	"weekday.short.3":         "Wed",
+	"weekday.short.4":         "Thu",
+	"weekday.short.5":         "Fri",
+	"weekday.short.6":         "Sat",
+
+	// Built-in city names
+	"city.tokyo":     "Tokyo",
+	"city.osaka":     "Osaka",
+	"city.kyoto":     "Kyoto",
+	"city.yokohama":  "Yokohama",
+	"city.nagoya":    "Nagoya",
+	"city.sapporo":   "Sapporo",
+	"city.fukuoka":   "Fukuoka",
+	"city.sendai":    "Sendai",
+	"city.hiroshima": "Hiroshima",
+	"city.naha":      "Naha",
+	"city.kobe":      "Kobe",
+	"city.shiga":     "Shiga",
+
+	// Distance categories
+	"distance.5k.name":   "5K",
+	"distance.5k.desc":   "Short run - relatively light load",
+	"distance.10k.name":  "10K",
+	"distance.10k.desc":  "Middle-distance run - moderate load",
+	"distance.half.name": "Half marathon",
+	"distance.half.desc": "Long run - high load",
+	"distance.full.name": "Marathon",
+	"distance.full.desc": "Very long run - very high load",
+
+	// Running levels and recommendations
+	"level.excellent":                   "Excellent",
+	"level.good":                        "Good",
+	"level.fair":                        "Fair",
+	"level.caution":                     "Caution",
+	"level.danger":                      "Danger",
+	"recommendation.excellent":          "Perfect weather for running!",
+	"recommendation.good":               "Good conditions. Enjoy your run",
+	"recommendation.fair":               "Check the warnings before heading out",
+	"recommendation.caution":            "There are warnings. Keep your run easy",
+	"recommendation.danger":             "Poor weather. Consider skipping your run",
+	"recommendation.distance.excellent": "Perfect weather for a %s!",
+	"recommendation.distance.good":      "Good conditions for a %s",
+	"recommendation.distance.fair":      "Run a %s with care and listen to your body",
+	"recommendation.distance.caution":   "Take a %s easy and consider shortening it",
+	"recommendation.distance.danger":    "Consider skipping a %s today",
+
+	// Running warnings
+	"warning.cold_severe":       "🥶 Cold: dress warmly and protect against the cold",
+	"warning.cold":              "🌡️ Chilly: dress in layers to regulate body temperature",
+	"warning.humidity":          "💧 High humidity: sweat will not evaporate easily",
+	"warning.dew_point":         "🥵 Dew point %.1f°C: sweat barely cools you, slow down considerably",
+	"warning.wind_strong":       "💨 Strong wind: risk of falls and injury",
+	"warning.wind":              "💨 Windy: run with care",
+	"warning.gust.gusty":        "💨 Gusts of %.1f m/s: hold on to your cap and belongings",
+	"warning.gust.strong":       "💨 Strong gusts of %.1f m/s: watch your footing and flying debris, avoid seafronts and bridges",
+	"warning.gust.dangerous":    "🌪️ Dangerous gusts of %.1f m/s: you could be knocked over, avoid running outdoors",
+	"warning.rain_heavy":        "☔ Heavy rain: consider skipping your run",
+	"warning.rain":              "🌧️ Rain: watch out for slippery surfaces",
+	"warning.rain_light":        "🌦️ Light rain: a light rain jacket will help",
+	"warning.rain_chance":       "🌂 %.0f%% chance of rain: a packable rain jacket is worth taking",
+	"warning.rain_chance_run":   "🌂 %.0f%% chance of rain during the run: a packable rain jacket is worth taking",
+	"warning.rain_total":        "🌧️ %.1f mm of rain in total during the run: bring rain gear and a change of clothes",
+	"warning.temperature_rise":  "📈 It warms up from %.1f°C to %.1f°C during the run: dress in layers you can shed and be ready for the heat later on",
+	"warning.thunderstorm":      "⚡ Thunderstorm: do not run outdoors",
+	"warning.showers":           "🌧️ Showers: be ready for sudden rain",
+	"warning.uv.high":           "☀️ UV index %.0f (high): wear sunscreen, a cap and sunglasses",
+	"warning.uv.very_high":      "☀️ UV index %.0f (very high): avoid the midday sun and pick shaded routes",
+	"warning.uv.extreme":        "☀️ UV index %.0f (extreme): avoid running in the middle of the day, run in the morning or evening",
+	"warning.darkness":          "🔦 Dark: it gets light around %s and dark around %s. Choose lit routes and watch for cars and uneven ground",
+	"warning.long_heat":         "🏃‍♂️ Long-distance warning: long efforts in the heat are dangerous",
+	"warning.long_humidity":     "💦 Long-distance warning: high humidity increases the risk of dehydration",
+	"warning.full_heat":         "🏃‍♂️ Marathon warning: long efforts in the heat are dangerous",
+	"warning.dust_mask":         "🌫️ Asian dust is present. Wearing a mask is recommended",
+	"warning.dust_breathing":    "🌫️ If you have respiratory concerns, consider training indoors",
+	"warning.dust_severe":       "⚠️ Very heavy Asian dust. Avoid running outdoors",
+	"warning.pm25_alert":        "⚠️ PM2.5 is at alert level (over %.0fμg/m³). Avoid strenuous outdoor exercise",
+	"warning.pm25_high":         "😷 PM2.5 is elevated (over %.0fμg/m³). Limit long outdoor sessions",
+	"warning.pm25_over_limit":   "😷 PM2.5 exceeds the standard (%.0fμg/m³). Sensitive people should take care",
+	"warning.sensitive_airways": "🫁 Asthma or pollen allergy: polluted air hits your airways harder, so carry your inhaler and keep the effort down",
+	"warning.cold_tolerance":    "🥶 You feel the cold easily: %.1f°C at the coldest, so wear an extra layer",
+	"warning.heat_tolerance":    "🥵 You feel the heat easily: WBGT %.1f°C is hard on you, so slow down and drink early",
+	"warning.wbgt.caution":      "💧 Heat stress caution (WBGT %.1f): drink water and take electrolytes regularly",
+	"warning.wbgt.warning":      "🔥 Heat stress warning (WBGT %.1f): take frequent breaks; early morning or evening is better",
+	"warning.wbgt.severe":       "🥵 Severe heat stress (WBGT %.1f): avoid hard efforts and long runs",
+	"warning.wbgt.danger":       "🚨 Dangerous heat stress (WBGT %.1f): high heat stroke risk, exercise should be stopped",
+
+	// Dew point comfort bands
+	"dew_point.comfortable": "Comfortable",
+	"dew_point.humid":       "Slightly humid",
+	"dew_point.muggy":       "Muggy",
+	"dew_point.oppressive":  "Oppressive",
+	"dew_point.dangerous":   "Very oppressive",
+
+	// UV index bands (WHO)
+	"uv.low":       "Low",
+	"uv.moderate":  "Moderate",
+	"uv.high":      "High",
+	"uv.very_high": "Very high",
+	"uv.extreme":   "Extreme",
+
+	// Heat stress (WBGT) bands
+	"heat.level.safe":       "Mostly safe",
+	"heat.level.caution":    "Caution",
+	"heat.level.warning":    "Warning",
+	"heat.level.severe":     "Severe warning",
+	"heat.level.danger":     "Danger",
+	"heat.guidance.safe":    "drink as needed",
+	"heat.guidance.caution": "drink actively",
+	"heat.guidance.warning": "take breaks actively",
+	"heat.guidance.severe":  "stop strenuous exercise",
+	"heat.guidance.danger":  "stop exercising",
+
+	// Clothing
+	"clothing.long_sleeves":       "Long sleeves",
+	"clothing.long_pants":         "Long tights",
+	"clothing.gloves":             "Gloves",
+	"clothing.hat":                "Hat",
+	"clothing.light_gloves":       "Light gloves",
+	"clothing.thin_long_sleeves":  "Light long sleeves",
+	"clothing.shorts":             "Shorts",
+	"clothing.thin_short_sleeves": "Light short sleeves",
+	"clothing.hat_recommended":    "Cap recommended",
+	"clothing.hat_required":       "Cap required",
+	"clothing.sunglasses":         "Sunglasses",
+	"clothing.reflective":         "Reflective vest or gear",
+	"clothing.headlamp":           "Headlamp or light",
+	"clothing.sunscreen":          "Sunscreen (SPF 30+)",
+	"clothing.cap":                "Cap",
+	"clothing.hydration":          "Hydration gear",
+	"clothing.energy":             "Energy gels",
+	"clothing.cooling_towel":      "Cooling towel",
+	"clothing.salt":               "Salt tablets",
+	"clothing.sports_mask":        "Sports mask",
+	"clothing.eye_protection":     "Sunglasses (eye protection)",
+
+	// Running report output
+	"report.title.current":             "🏃‍♂️ Running conditions in %[1]s%[2]s",
+	"report.title.time":                "🏃‍♂️ Running conditions in %[1]s, %[2]s%[3]s",
+	"report.title.date":                "🏃‍♂️ Running conditions in %[1]s, %[2]s%[3]s",
+	"report.title.datetime":            "🏃‍♂️ Running conditions in %[1]s, %[2]s %[3]s%[4]s",
+	"report.title.best":                "🏃‍♂️ Best start times in %[1]s over %[2]d days%[3]s",
+	"report.title.distance":            " (%s)",
+	"report.timezone":                  "🌐 Times are local (%s, UTC%s)",
+	"report.data_as_of":                "🕒 Data as of: %s (cached)",
+	"report.target_distance":           "📏 Target distance: %s (%.1f-%.1fkm)",
+	"report.score":                     "🏆 Running index: %d/100 (%s)",
+	"report.temperature":               "🌡️ Temperature: %.1f°C (feels like %.1f°C)",
+	"report.humidity":                  "💧 Humidity: %d%%",
+	"report.dew_point":                 "💧 Dew point: %.1f°C (%s)",
+	"report.uv":                        "☀️ UV index: %.1f (%s)",
+	"report.uv_max":                    "☀️ Peak UV index: %.1f (%s)",
+	"report.wind":                      "🌬️ Wind: %s %.1f m/s",
+	"report.gusts":                     "   Gusts up to %.1f m/s",
+	"report.route_wind.head":           "🧭 Route %s (%.0f°): headwind %.1f m/s on the way out / crosswind %.1f m/s",
+	"report.route_wind.tail":           "🧭 Route %s (%.0f°): tailwind %.1f m/s on the way out / crosswind %.1f m/s",
+	"report.hour.gusts":                " (gusts %.0f)",
+	"route.advice.start":               "   ➡️ Start heading %s to have the tailwind on the way back",
+	"route.advice.either":              "   ➡️ Mostly a crosswind, either direction is fine",
+	"report.weather":                   "☁️ Weather: %s",
+	"report.precipitation":             "🌧️ Precipitation: %.1f mm",
+	"report.precipitation_probability": "☂️ Chance of rain (max): %.0f%%",
+	"report.run_window":                "⏳ During the run (%d h): %.1f to %.1f°C / WBGT up to %.1f / %.1f mm of rain / %.0f%% chance of rain",
+	"report.dry_window":                "🌂 Dry window: %s-%s (%d h, %.0f%% chance to stay dry, %s confidence)",
+	"report.dry_window_short":          "🌂 The longest dry window is %s-%s (%d h), too short for the expected %d min run",
+	"report.dry_window_none":           "🌂 No dry window: rain is possible throughout",
+	"dry_window.confidence.high":       "high",
+	"dry_window.confidence.medium":     "medium",
+	"dry_window.confidence.low":        "low",
+	"report.temperature_range":         "🌡️ %s%.1f°C - %.1f°C",
+	"report.max_wind":                  "🌬️ Max wind: %.1f m/s",
+	"report.daylight":                  "🌅 Sunrise %s / Sunset %s (daylight %dh %02dm)",
+	"report.twilight":                  "   Civil twilight: %s-%s",
+	"report.wbgt":                      "🥵 Heat stress (WBGT): %.1f°C (%s: %s)",
+	"report.wbgt_max":                  "🥵 Peak heat stress (WBGT): %.1f°C (%s: %s)",
+	"report.dust":                      "🌫️ Asian dust: %s (%.0f μg/m³)",
+	"report.clothing":                  "👕 Recommended gear:",
+	"report.warnings":                  "⚠️ Warnings:",
+	"report.hours.time":                "⏰ %[1]s hour by hour (%[2]s)",
+	"report.hours.datetime":            "⏰ %[1]s %[2]s hour by hour (%[3]s)",
+	"report.hour":                      "🕐 %s:00: %d/100 (%s)",
+	"report.hour.details":              "   🌡️ %.1f°C (feels like %.1f°C) | 💧 %d%% (dew point %.1f°C) | 🌬️ %s %.1fm/s",
+	"report.hour.wbgt":                 " | 🥵 WBGT %.1f (%s)",
+	"report.hour.uv":                   " | ☀️ UV %.0f (%s)",
+	"report.hour.rain_chance":          " | ☂️ %.0f%%",
+	"report.pace":                      "⏱️ Pace: target %s/km → adjusted %s/km (%+.1f%%)",
+	"report.pace_breakdown":            "   Heat and humidity %+.1f%% / wind %+.1f%%",
+	"report.finish_time":               "🏁 Estimated finish (%s): %s",
+	"report.hour.pace":                 "   ⏱️ %s/km (%+.1f%%)",
+	"report.hydration":                 "🥤 Fluids: %d ml/h (about %d ml in total, %d min run)",
+	"report.hydration_none":            "🥤 Fluids: not needed while running (%d min run, a glass or two before and after)",
+	"report.sweat_rate":                "   Sweat rate: %.1f L/h (%s)",
+	"report.sodium":                    "🧂 Sodium: %d mg/h (salt tablets or electrolyte drinks)",
+	"report.gels":                      "⚡ Fuel: %d gels (first at %d min, then every %d min)",
+	"hydration.sweat.estimated":        "estimated",
+	"hydration.sweat.configured":       "from your profile",
+	"report.best_time":                 "🏆 Best time: %s:00 (score: %d/100)",
+	"report.best.scope_all":            "🗓️ Scanning every hour of %s each day (runs of about %d min)",
+	"report.best.scope_period":         "🗓️ Scanning %s (%s) each day (runs of about %d min)",
+	"report.best.rank":                 "%d. %s (%s) %s:00 start: %d/100 (%s)",
+	"report.best.worst":                "   Lowest score during the run at %s:00",
+	"reason.temperature":               "🌡️ %.1f to %.1f°C, WBGT up to %.1f",
+	"reason.wind":                      "🌬️ Wind up to %.1f m/s",
+	"reason.rain_chance":               "☂️ %.0f%% chance of rain during the run",
+	"reason.dust":                      "🌫️ Dust: %s",
+	"reason.light.day":                 "☀️ In daylight",
+	"reason.light.twilight":            "🌆 Partly in twilight",
+	"reason.light.dark":                "🌙 Partly in the dark",
+	"reason.no_warnings":               "✅ No warnings",
+	"weather.precipitation_short":      " | 🌧️ %.1fmm",
+
+	// Errors and warnings
+	"error.city_not_found":      "City not found: %s\nSupported cities: %v\nJapanese municipalities (e.g. setagaya, 世田谷区) can be given too",
+	"error.invalid_coordinate":  "Invalid coordinates: %s (%v)",
+	"error.lat_lon_pair":        "Give both -lat and -lon",
+	"error.city_ambiguous":      "%q matches several municipalities: %s",
+	"error.invalid_time":        "Invalid time of day: %s",
+	"error.invalid_date":        "Invalid date: %s",
+	"error.past_date":           "Dates in the past are not allowed: %s",
+	"error.date_out_of_range":   "The date is outside the forecast: %s (the forecast covers %s to %s)",
+	"error.date_incomplete":     "Forecast data for the date is incomplete: %s",
+	"error.missing_city":        "Please specify a city",
+	"error.forecast_horizon":    "The date is outside the forecast (up to %d days ahead)",
+	"error.no_time_data":        "No data found for the time of day",
+	"error.no_date_data":        "No data found for the date",
+	"error.no_datetime_data":    "No data found for the date and time of day",
+	"error.no_cached_data":      "No cached forecast data",
+	"error.cache_dir":           "Cannot determine the cache directory: %v",
+	"error.invalid_output":      "Invalid output format: %s",
+	"error.invalid_distance":    "Invalid distance: %s",
+	"error.invalid_language":    "Invalid language: %s",
+	"error.invalid_pace":        "Invalid pace: %s",
+	"error.invalid_bearing":     "Invalid route bearing: %s",
+	"error.invalid_days":        "Invalid number of days: %d",
+	"error.invalid_top":         "Invalid number of start times: %d",
+	"error.best_with_date":      "-best cannot be combined with -date",
+	"warning.config_load":       "Warning: failed to load the config file: %v",
+	"warning.air_quality_fetch": "Warning: failed to fetch air quality data: %v",
+	"hint.valid_output":         "Valid output formats: text, json",
+	"hint.valid_distance":       "Valid distances: 5k, 10k, half, full",
+	"hint.valid_date":           "Valid dates: today, tomorrow, day-after-tomorrow, 2026-10-20, sat, next-sun, +5d",
+	"hint.valid_time":           "Valid times: %s",
+	"hint.valid_language":       "Valid languages: %s",
+	"hint.valid_pace":           "Valid paces: M:SS per km (2:00 to 15:00)",
+	"hint.valid_days":           "Valid days: 1 to %d",
+	"hint.valid_top":            "Valid numbers: 1 or more",
+	"hint.valid_bearing":        "Valid bearings: degrees clockwise from north (0 to 360) or one of the 16 compass points such as N, NE, ENE",
+
+	// Command line help
+	"flag.lat":        "Latitude (with -lon, instead of -city)",
+	"flag.lon":        "Longitude (with -lat, instead of -city)",
+	"flag.city":       "City or municipality name",
+	"flag.time":       "Time of day (morning, noon, evening, night or a [periods] name)",
+	"flag.date":       "Date (today, tomorrow, day-after-tomorrow, 2026-10-20, sat, next-sun, +5d)",
+	"flag.distance":   "Target distance (5k, 10k, half, full) (default: distance in the [profile] config section)",
+	"flag.offline":    "Use cached forecast data only",
+	"flag.output":     "Output format (text, json)",
+	"flag.lang":       "Display language (ja, en)",
+	"flag.pace":       "Target pace per km (e.g. 5:30)",
+	"flag.bearing":    "Outbound direction of an out-and-back route (e.g. NE or 45)",
+	"flag.best":       "Find the best start times over the coming days",
+	"flag.days":       "Days scanned by -best",
+	"flag.top":        "Start times listed by -best",
+	"flag.help":       "Show help",
+	"flag.addr":       "Address to listen on",
+	"flag.cache_ttl":  "How long an assessment is reused for the same query (0 disables)",
+	"serve.listening": "runcast API listening on %s",
+	"serve.stopped":   "runcast API stopped",
+	"help.serve": `🏃‍♂️ runcast serve - HTTP API of the weather forecasts for runners
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+Usage:
+  runcast serve [options]
+
+Options:
+  -addr string
+      Address to listen on (default: 127.0.0.1:8080)
+  -cache-ttl duration
+      How long an assessment is reused for the same query (default: 10m, 0 disables)
+  -offline
+      Use cached forecast data only (no network access)
+  -lang string
+      Message language (ja, en)
+
+Endpoints (JSON):
+  GET /v1/assessment?city=tokyo&date=tomorrow&time=morning&distance=10k
+      The assessment of -output=json (all but city are optional)
+  GET /v1/cities
+      Supported cities and the custom locations of the config file
+  GET /healthz
+      Health check
+
+Examples:
+  runcast serve
+  runcast serve -addr=:8080 -cache-ttl=5m`,
+	"help.now": `🏃‍♂️ runcast now - current running conditions
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+Usage:
+  runcast now [options]
+
+Options:
+  -city string      City, municipality, custom location or coordinates (default: tokyo)
+  -lat, -lon string Latitude and longitude (instead of -city)
+  -distance string  Target distance (5k, 10k, half, full)
+  -pace string      Target pace (e.g. 5:30)
+  -bearing string   Outbound direction of an out-and-back course (e.g. NE or 45)
+  -output string    Output format (text, json)
+  -offline          Use cached forecast data only
+  -lang string      Display language (ja, en)
+
+Examples:
+  runcast now -city=osaka -distance=10k`,
+	"help.plan": `🏃‍♂️ runcast plan - plan your run
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+Usage:
+  runcast plan [options]
+
+Options:
+  -date string      Date (today, tomorrow, 2026-10-20, sat, +5d, ...)
+  -time string      Time of day (morning, noon, evening, night or a [periods] name)
+  -best             Rank the best start times over several days
+  -days int         Days to search with -best (default: 7)
+  -top int          Start times to show with -best (default: 3)
+  -city, -lat, -lon, -distance, -pace, -bearing, -output, -offline, -lang
+                    Same as runcast now
+
+Without -date, -time or -best the forecast for the whole of today is shown.
+
+Examples:
+  runcast plan -date=tomorrow -time=morning -distance=10k
+  runcast plan -best -distance=full -pace=5:30`,
+	"help.cities": `🏃‍♂️ runcast cities - list the cities and custom locations
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+Usage:
+  runcast cities [options]
+
+Options:
+  -output string    Output format (text, json)
+  -lang string      Display language (ja, en)`,
+	"help.config": `🏃‍♂️ runcast config - check the config file
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+Usage:
+  runcast config [options]
+
+Shows where the config file in use is and whether it is valid.
+Exits with status 1 when the config file has an error.
+
+Options:
+  -lang string      Display language (ja, en)`,
+	"cities.title":     "📍 Cities",
+	"cities.custom":    "🏠 Custom locations (config file)",
+	"config.path":      "⚙️ Config file: %s",
+	"config.not_found": "⚙️ No config file. These locations are searched in order:",
+	"config.valid":     "✅ The configuration is valid",
+	"config.invalid":   "❌ The configuration has an error: %v",
+	"config.language":  "   Language: %s",
+	"config.locations": "   Custom locations: %s",
+	"config.periods":   "   Time periods: %s",
+	"config.pace":      "   Target pace: %s /km",
+	"config.distance":  "   Preferred distance: %s",
+	"config.bearing":   "   Course direction: %s",
+	"help": `🏃‍♂️ runcast - weather forecasts for runners
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+Usage:
+  runcast <command> [options]
+  runcast [options]    # the original invocation, accepting all the options below
+
+Commands (see runcast <command> -help):
+  now       Current running conditions
+  plan      Plan by date, time of day or best start time (today by default)
+  cities    List the cities and custom locations
+  config    Check the config file in use
+  serve     HTTP API server
+
+Options:
+  -city string
+      City or municipality name (default: tokyo)
+      Coordinates (35.68,139.76) and geo URIs (geo:35.68,139.76) work too
+  -lat string, -lon string
+      Latitude and longitude (instead of -city)
+  -time string
+      Time of day (morning, noon, evening, night)
+      Periods defined in the [periods] config section work too
+  -date string
+      Date (today, tomorrow, day-after-tomorrow)
+      Also accepts a date (2026-10-20), a weekday (sat, next-sun) or days ahead (+5d)
+  -distance string
+      Target distance (5k, 10k, half, full)
+      Defaults to the distance in the [profile] config section
+  -output string
+      Output format (text, json) (default: text)
+  -offline
+      Use cached forecast data only (no network access)
+  -lang string
+      Display language (ja, en) (default: config file or LANG, otherwise ja)
+  -pace string
+      Target pace per km as M:SS (e.g. 5:30)
+      Shows the pace adjusted for heat, humidity and wind and the estimated finish time
+  -bearing string
+      Outbound direction of an out-and-back route as a compass point (N, NE, ...) or degrees
+      Shows the head- and crosswind and which way to start for a tailwind home
+  -best
+      Scores every hour of the coming days over the whole run and ranks the best start times
+      With -time only the hours of that period are scanned
+  -days int
+      Days scanned by -best (default: 7)
+  -top int
+      Start times listed by -best (default: 3)
+  -help
+      Show this help
+
+Supported cities:
+%s
+
+Custom locations:
+  Create a .runcast.conf file to add your own locations
+  Config file locations (in order of precedence):
+    1. Current directory: .runcast.conf
+    2. Home directory: ~/.runcast.conf
+    3. Config directory: ~/.config/runcast/config.toml
+
+  Example config file:
+    language = "en"  # optional: display language (ja, en)
+
+    [locations]
+    home = { name = "Home", lat = 35.6762, lon = 139.6503 }
+    office = { name = "Office", lat = 35.6584, lon = 139.7016 }
+
+    [provider]  # optional: use a mirror server
+    forecast_url = "http://localhost:8080/v1/jma"
+
+    [cache]  # optional: forecast cache settings
+    ttl = "30m"
+
+    [profile]  # optional: about you
+    pace = "5:30"  # typical pace per km
+    body_weight = 62  # kg
+    sweat_rate = 1.0  # L/h measured on a mild day
+    distance = "10k"  # used when -distance is omitted
+    heat_tolerance = "high"  # how well you handle heat (low, normal, high)
+    cold_tolerance = "low"  # how well you handle cold (low, normal, high)
+    sensitive_airways = true  # asthma or pollen allergy
+
+    [route]  # optional: your usual course
+    bearing = "NE"  # outbound direction (compass point or degrees)
+
+    [periods]  # optional: your own time periods (use with -time=lunch)
+    lunch = { start = "12:00", end = "13:30", name = "Lunch break" }
+
+    [scoring]  # optional: your own scoring (only what you set changes)
+    cool = 12  # below this counts as cool (°C)
+
+Examples:
+  runcast -city=osaka
+  runcast -city=tokyo -time=morning
+  runcast -city=kyoto -date=tomorrow -distance=10k
+  runcast -city=tokyo -date=sat -time=morning
+  runcast -city=home    # use a custom location
+  runcast -city=35.68,139.76    # use coordinates directly
+  runcast -city=tokyo -time=morning -output=json
+  runcast -city=tokyo -lang=ja
+  runcast -city=tokyo -date=sun -time=morning -distance=half -pace=5:00
+  runcast -city=tokyo -time=evening -bearing=NE
+  runcast -city=tokyo -best -distance=full -pace=5:30
+  runcast -city=tokyo -time=lunch    # a period from [periods]
+  runcast serve -addr=:8080    # start the HTTP API server
+  runcast plan -date=sat -time=morning    # same as runcast -date=sat -time=morning`,
+}
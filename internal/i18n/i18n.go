@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is used when no language is configured
+const DefaultLanguage = "ja"
+
+// catalogs holds the messages of every supported language
+var catalogs = map[string]map[string]string{
+	"ja": ja,
+	"en": en,
+}
+
+// current is the language used by T
+var current = DefaultLanguage
+
+// SupportedLanguages returns the codes of all supported languages
+func SupportedLanguages() []string {
+	languages := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// IsSupported reports whether a message catalog exists for lang
+func IsSupported(lang string) bool {
+	_, exists := catalogs[lang]
+	return exists
+}
+
+// SetLanguage selects the language used by T
+func SetLanguage(lang string) error {
+	if !IsSupported(lang) {
+		return fmt.Errorf("unsupported language: %s", lang)
+	}
+	current = lang
+	return nil
+}
+
+// Language returns the currently selected language
+func Language() string {
+	return current
+}
+
+// T returns the message for key in the current language, formatted with args.
+// Missing messages fall back to the default language and then to the key itself.
+func T(key string, args ...any) string {
+	message, exists := Lookup(key)
+	if !exists {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Lookup returns the unformatted message for key in the current language,
+// falling back to the default language
+func Lookup(key string) (string, bool) {
+	if message, exists := catalogs[current][key]; exists {
+		return message, true
+	}
+	message, exists := catalogs[DefaultLanguage][key]
+	return message, exists
+}
+
+// DetectLanguage picks the language from the -lang flag, the config file or the
+// locale environment (LC_ALL, LC_MESSAGES, LANG), in that order
+func DetectLanguage(flagValue, configValue string, getenv func(string) string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if configValue != "" {
+		return configValue
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		// The first locale variable that is set wins, even if unsupported
+		if lang := FromLocale(value); lang != "" {
+			return lang
+		}
+		break
+	}
+	return DefaultLanguage
+}
+
+// FromLocale extracts a supported language from a POSIX locale such as en_US.UTF-8
+func FromLocale(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	if IsSupported(lang) {
+		return lang
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+// verbPattern matches printf verbs, including explicit argument indexes such as %[2]s
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*[\d.]*[a-zA-Z%]`)
+
+func TestCatalogsAreComplete(t *testing.T) {
+	for key, message := range ja {
+		translated, exists := en[key]
+		if !exists {
+			t.Errorf("Missing en message for %s", key)
+			continue
+		}
+
+		jaVerbs := verbPattern.FindAllString(message, -1)
+		enVerbs := verbPattern.FindAllString(translated, -1)
+		sort.Strings(jaVerbs)
+		sort.Strings(enVerbs)
+		if len(jaVerbs) != len(enVerbs) {
+			t.Errorf("Message %s has verbs %v in ja but %v in en", key, jaVerbs, enVerbs)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLanguage(DefaultLanguage)
+
+	if got := T("level.excellent"); got != "最高" {
+		t.Errorf("Expected 最高, got %s", got)
+	}
+	if got := T("date.days_later", 5); got != "5日後の" {
+		t.Errorf("Expected 5日後の, got %s", got)
+	}
+
+	if err := SetLanguage("en"); err != nil {
+		t.Fatalf("SetLanguage failed: %v", err)
+	}
+	if got := T("date.days_later", 5); got != "in 5 days" {
+		t.Errorf("Expected 'in 5 days', got %s", got)
+	}
+	if got := T("date.iso", 10, 20, "Tue", "Oct"); got != "Tue, Oct 20" {
+		t.Errorf("Expected 'Tue, Oct 20', got %s", got)
+	}
+
+	// Unknown keys are returned as-is
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected the key back, got %s", got)
+	}
+
+	if err := SetLanguage("fr"); err == nil {
+		t.Error("Expected an error for an unsupported language")
+	}
+	if Language() != "en" {
+		t.Errorf("Failed SetLanguage must keep the current language, got %s", Language())
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		config   string
+		env      map[string]string
+		expected string
+	}{
+		{name: "default", expected: "ja"},
+		{name: "flag wins", flag: "en", config: "ja", env: map[string]string{"LANG": "ja_JP.UTF-8"}, expected: "en"},
+		{name: "config over env", config: "ja", env: map[string]string{"LANG": "en_US.UTF-8"}, expected: "ja"},
+		{name: "LANG", env: map[string]string{"LANG": "en_US.UTF-8"}, expected: "en"},
+		{name: "LC_ALL over LANG", env: map[string]string{"LC_ALL": "en_GB", "LANG": "ja_JP.UTF-8"}, expected: "en"},
+		{name: "C locale", env: map[string]string{"LANG": "C.UTF-8"}, expected: "ja"},
+		{name: "unsupported locale", env: map[string]string{"LC_ALL": "fr_FR.UTF-8", "LANG": "en_US.UTF-8"}, expected: "ja"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string { return tt.env[name] }
+			if got := DetectLanguage(tt.flag, tt.config, getenv); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
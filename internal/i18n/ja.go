@@ -0,0 +1,543 @@
+package i18n
+
+// ja is the Japanese message catalog
+var ja = map[string]string{
+	// Weather descriptions (WMO weather codes)
+	"weather.code.0":  "快晴",
+	"weather.code.1":  "晴れ",
+	"weather.code.2":  "一部曇り",
+	"weather.code.3":  "曇り",
+	"weather.code.45": "霧",
+	"weather.code.48": "着氷霧",
+	"weather.code.51": "弱い霧雨",
+	"weather.code.53": "霧雨",
+	"weather.code.55": "強い霧雨",
+	"weather.code.56": "軽い着氷霧雨",
+	"weather.code.57": "着氷霧雨",
+	"weather.code.61": "弱い雨",
+	"weather.code.63": "雨",
+	"weather.code.65": "強い雨",
+	"weather.code.66": "軽い着氷雨",
+	"weather.code.67": "着氷雨",
+	"weather.code.71": "弱い雪",
+	"weather.code.73": "雪",
+	"weather.code.75": "強い雪",
+	"weather.code.77": "雪つぶ",
+	"weather.code.80": "弱いにわか雨",
+	"weather.code.81": "にわか雨",
+	"weather.code.82": "強いにわか雨",
+	"weather.code.85": "弱いにわか雪",
+	"weather.code.86": "にわか雪",
+	"weather.code.95": "雷雨",
+	"weather.code.96": "雹を伴う雷雨",
+	"weather.code.99": "強い雹を伴う雷雨",
+	"weather.unknown": "不明",
+
+	// Wind directions, clockwise from north
+	"wind.0":  "北",
+	"wind.1":  "北北東",
+	"wind.2":  "北東",
+	"wind.3":  "東北東",
+	"wind.4":  "東",
+	"wind.5":  "東南東",
+	"wind.6":  "南東",
+	"wind.7":  "南南東",
+	"wind.8":  "南",
+	"wind.9":  "南南西",
+	"wind.10": "南西",
+	"wind.11": "西南西",
+	"wind.12": "西",
+	"wind.13": "西北西",
+	"wind.14": "北西",
+	"wind.15": "北北西",
+
+	// Dust levels
+	"dust.0.name": "なし",
+	"dust.0.desc": "黄砂の影響なし",
+	"dust.1.name": "少ない",
+	"dust.1.desc": "わずかに飛来",
+	"dust.2.name": "やや多い",
+	"dust.2.desc": "視程に影響の可能性",
+	"dust.3.name": "多い",
+	"dust.3.desc": "外出時に注意が必要",
+	"dust.4.name": "非常に多い",
+	"dust.4.desc": "屋外活動は控えるべき",
+
+	// Time periods
+	"period.morning": "早朝",
+	"period.noon":    "昼",
+	"period.evening": "夕方",
+	"period.night":   "夜",
+
+	// Dates
+	"date.today":              "今日の",
+	"date.tomorrow":           "明日の",
+	"date.day_after_tomorrow": "明後日の",
+	"date.days_later":         "%d日後の",
+	"date.weekday":            "%sの",
+	"date.next_weekday":       "来週%sの",
+	"date.iso":                "%[1]d月%[2]d日(%[3]s)の",
+	"date.other":              "%sの",
+	"date.layout":             "01月02日",
+	"weekday.0":               "日曜日",
+	"weekday.1":               "月曜日",
+	"weekday.2":               "火曜日",
+	"weekday.3":               "水曜日",
+	"weekday.4":               "木曜日",
+	"weekday.5":               "金曜日",
+	"weekday.6":               "土曜日",
+	"weekday.short.0":         "日",
+	"weekday.short.1":         "月",
+	"weekday.short.2":         "火",
+	"weekday.short.3":         "水",
+	"weekday.short.4":         "木",
+	"weekday.short.5":         "金",
+	"weekday.short.6":         "土",
+
+	// Distance categories
+	"distance.5k.name":   "5キロ",
+	"distance.5k.desc":   "短距離ランニング - 比較的軽い負荷",
+	"distance.10k.name":  "10キロ",
+	"distance.10k.desc":  "中距離ランニング - 中程度の負荷",
+	"distance.half.name": "ハーフマラソン",
+	"distance.half.desc": "長距離ランニング - 高い負荷",
+	"distance.full.name": "フルマラソン",
+	"distance.full.desc": "超長距離ランニング - 非常に高い負荷",
+
+	// Running levels and recommendations
+	"level.excellent":                   "最高",
+	"level.good":                        "良好",
+	"level.fair":                        "普通",
+	"level.caution":                     "注意",
+	"level.danger":                      "危険",
+	"recommendation.excellent":          "ランニングに最適な天候です！",
+	"recommendation.good":               "良好な天候です。ランニングを楽しんでください",
+	"recommendation.fair":               "注意事項を確認してからランニングしてください",
+	"recommendation.caution":            "警告事項があります。ランニングは控えめに",
+	"recommendation.danger":             "天候が悪いため、ランニングは控えることをお勧めします",
+	"recommendation.distance.excellent": "%s実行に最適な天候です！",
+	"recommendation.distance.good":      "%s実行に良好な天候です",
+	"recommendation.distance.fair":      "%s実行は慎重に、体調と相談して判断してください",
+	"recommendation.distance.caution":   "%s実行は控えめに、短縮も検討してください",
+	"recommendation.distance.danger":    "%s実行は控えることをお勧めします",
+
+	// Running warnings
+	"warning.cold_severe":       "🥶 低温注意: 防寒対策を十分に行ってください",
+	"warning.cold":              "🌡️ 寒冷注意: 適切な服装で体温調節してください",
+	"warning.humidity":          "💧 高湿度: 汗が乾きにくい状態です",
+	"warning.dew_point":         "🥵 露点%.1f°C: 汗による冷却がほとんど効きません。ペースを大きく落としてください",
+	"warning.wind_strong":       "💨 強風注意: 転倒や怪我のリスクがあります",
+	"warning.wind":              "💨 風が強め: 注意してランニングしてください",
+	"warning.gust.gusty":        "💨 突風 %.1f m/s: 帽子や持ち物が飛ばされないよう注意してください",
+	"warning.gust.strong":       "💨 強い突風 %.1f m/s: ふらつきや飛来物に注意し、海沿いや橋の上は避けてください",
+	"warning.gust.dangerous":    "🌪️ 危険な突風 %.1f m/s: 転倒の危険があります。屋外でのランニングは控えてください",
+	"warning.rain_heavy":        "☔ 大雨: ランニングは控えることをお勧めします",
+	"warning.rain":              "🌧️ 雨: 滑りやすい路面に注意してください",
+	"warning.rain_light":        "🌦️ 小雨: 軽い雨具があると良いでしょう",
+	"warning.rain_chance":       "🌂 降水確率%.0f%%: 折りたたみの雨具があると安心です",
+	"warning.rain_chance_run":   "🌂 走行中に雨に降られる確率 %.0f%%: 折りたたみの雨具があると安心です",
+	"warning.rain_total":        "🌧️ 走行中の降水量は計 %.1f mm: 雨具と着替えを用意してください",
+	"warning.temperature_rise":  "📈 走行中に気温が %.1f°C から %.1f°C まで上がります: 脱ぎやすい服装で後半の暑さに備えてください",
+	"warning.thunderstorm":      "⚡ 雷雨: 絶対に屋外でのランニングは避けてください",
+	"warning.showers":           "🌧️ にわか雨: 突然の雨に注意してください",
+	"warning.uv.high":           "☀️ UV指数%.0f (強い): 日焼け止めを塗り、帽子とサングラスで日差しを防いでください",
+	"warning.uv.very_high":      "☀️ UV指数%.0f (非常に強い): 日中の日差しを避け、日陰の多いコースを選んでください",
+	"warning.uv.extreme":        "☀️ UV指数%.0f (極端に強い): 日中のランニングは避け、朝夕に走りましょう",
+	"warning.darkness":          "🔦 暗い時間帯: 明るくなるのは%s、暗くなるのは%s頃です。街灯のある道を選び、車や段差に注意してください",
+	"warning.long_heat":         "🏃‍♂️ 長距離警告: 高温下での長時間運動は危険です",
+	"warning.long_humidity":     "💦 長距離警告: 高湿度により脱水リスクが高まります",
+	"warning.full_heat":         "🏃‍♂️ フルマラソン警告: 高温下での長時間運動は危険です",
+	"warning.dust_mask":         "🌫️ 黄砂が飛来しています。マスク着用を推奨します",
+	"warning.dust_breathing":    "🌫️ 呼吸器系に不安がある方は屋内トレーニングを検討してください",
+	"warning.dust_severe":       "⚠️ 黄砂が非常に多いため、屋外でのランニングは避けてください",
+	"warning.pm25_alert":        "⚠️ PM2.5が注意喚起レベル(%.0fμg/m³超)です。屋外での激しい運動は避けてください",
+	"warning.pm25_high":         "😷 PM2.5が高め(%.0fμg/m³超)です。長時間の屋外運動に注意してください",
+	"warning.pm25_over_limit":   "😷 PM2.5が基準値(%.0fμg/m³)を超えています。敏感な方は注意してください",
+	"warning.sensitive_airways": "🫁 喘息・花粉症の設定: 大気の汚れが呼吸器に響きやすいため、発作止めを携帯し強度を抑えてください",
+	"warning.cold_tolerance":    "🥶 寒さに弱い設定: 最低 %.1f°C でも冷えやすいため、一枚多めに着てください",
+	"warning.heat_tolerance":    "🥵 暑さに弱い設定: WBGT %.1f°C でも負担が大きいため、ペースを落とし早めに給水してください",
+	"warning.wbgt.caution":      "💧 暑さ指数 注意 (WBGT %.1f): 積極的に水分・塩分を補給してください",
+	"warning.wbgt.warning":      "🔥 暑さ指数 警戒 (WBGT %.1f): 積極的に休憩し、早朝や夕方の涼しい時間帯を推奨",
+	"warning.wbgt.severe":       "🥵 暑さ指数 厳重警戒 (WBGT %.1f): 激しい運動や長距離走は避けてください",
+	"warning.wbgt.danger":       "🚨 暑さ指数 危険 (WBGT %.1f): 熱中症の危険が高く、運動は原則中止です",
+
+	// Dew point comfort bands
+	"dew_point.comfortable": "快適",
+	"dew_point.humid":       "やや蒸す",
+	"dew_point.muggy":       "蒸し暑い",
+	"dew_point.oppressive":  "不快",
+	"dew_point.dangerous":   "非常に不快",
+
+	// UV index bands (WHO)
+	"uv.low":       "弱い",
+	"uv.moderate":  "中程度",
+	"uv.high":      "強い",
+	"uv.very_high": "非常に強い",
+	"uv.extreme":   "極端に強い",
+
+	// Heat stress (WBGT) bands
+	"heat.level.safe":       "ほぼ安全",
+	"heat.level.caution":    "注意",
+	"heat.level.warning":    "警戒",
+	"heat.level.severe":     "厳重警戒",
+	"heat.level.danger":     "危険",
+	"heat.guidance.safe":    "適宜水分補給",
+	"heat.guidance.caution": "積極的に水分補給",
+	"heat.guidance.warning": "積極的に休憩",
+	"heat.guidance.severe":  "激しい運動は中止",
+	"heat.guidance.danger":  "運動は原則中止",
+
+	// Clothing
+	"clothing.long_sleeves":       "長袖",
+	"clothing.long_pants":         "ロングパンツ",
+	"clothing.gloves":             "手袋",
+	"clothing.hat":                "帽子",
+	"clothing.light_gloves":       "軽い手袋",
+	"clothing.thin_long_sleeves":  "薄手の長袖",
+	"clothing.shorts":             "ショートパンツ",
+	"clothing.thin_short_sleeves": "薄手の半袖",
+	"clothing.hat_recommended":    "帽子推奨",
+	"clothing.hat_required":       "帽子必須",
+	"clothing.sunglasses":         "サングラス",
+	"clothing.reflective":         "反射材付きのウェア・ベスト",
+	"clothing.headlamp":           "ヘッドランプ・ライト",
+	"clothing.sunscreen":          "日焼け止め (SPF30以上)",
+	"clothing.cap":                "キャップ",
+	"clothing.hydration":          "水分補給用品",
+	"clothing.energy":             "エネルギー補給品",
+	"clothing.cooling_towel":      "冷却タオル",
+	"clothing.salt":               "塩分補給品",
+	"clothing.sports_mask":        "スポーツマスク",
+	"clothing.eye_protection":     "サングラス（目の保護）",
+
+	// Running report output
+	"report.title.current":             "🏃‍♂️ %[1]s のランニング情報%[2]s",
+	"report.title.time":                "🏃‍♂️ %[1]s の%[2]s時間帯ランニング情報%[3]s",
+	"report.title.date":                "🏃‍♂️ %[1]s の%[2]sランニング情報%[3]s",
+	"report.title.datetime":            "🏃‍♂️ %[1]s の%[2]s%[3]s時間帯ランニング情報%[4]s",
+	"report.title.best":                "🏃‍♂️ %[1]s の%[2]d日間のおすすめスタート時刻%[3]s",
+	"report.title.distance":            "(%s)",
+	"report.timezone":                  "🌐 時刻は現地時間です (%s, UTC%s)",
+	"report.data_as_of":                "🕒 データ取得時刻: %s (キャッシュ)",
+	"report.target_distance":           "📏 目標距離: %s (%.1f-%.1fkm)",
+	"report.score":                     "🏆 ランニング指数: %d/100 (%s)",
+	"report.temperature":               "🌡️ 気温: %.1f°C (体感: %.1f°C)",
+	"report.humidity":                  "💧 湿度: %d%%",
+	"report.dew_point":                 "💧 露点: %.1f°C (%s)",
+	"report.uv":                        "☀️ UV指数: %.1f (%s)",
+	"report.uv_max":                    "☀️ 最大UV指数: %.1f (%s)",
+	"report.wind":                      "🌬️ 風: %s %.1f m/s",
+	"report.gusts":                     "   最大瞬間風速: %.1f m/s",
+	"report.route_wind.head":           "🧭 コース %s (%.0f°): 往路は向かい風 %.1f m/s / 横風 %.1f m/s",
+	"report.route_wind.tail":           "🧭 コース %s (%.0f°): 往路は追い風 %.1f m/s / 横風 %.1f m/s",
+	"report.hour.gusts":                " (突風 %.0f)",
+	"route.advice.start":               "   ➡️ %sへ向かってスタートすると、帰りが追い風になります",
+	"route.advice.either":              "   ➡️ 横風が中心のため、どちら向きにスタートしても大差ありません",
+	"report.weather":                   "☁️ 天気: %s",
+	"report.precipitation":             "🌧️ 降水量: %.1f mm",
+	"report.precipitation_probability": "☂️ 降水確率(最大): %.0f%%",
+	"report.run_window":                "⏳ 走行中 (%d時間): 気温 %.1f〜%.1f°C / WBGT 最高 %.1f / 降水量 計 %.1f mm / 雨に降られる確率 %.0f%%",
+	"report.dry_window":                "🌂 雨の心配が少ない時間: %s〜%s (%d時間・降らない確率 %.0f%%・信頼度 %s)",
+	"report.dry_window_short":          "🌂 雨の心配が少ない時間は最長 %s〜%s (%d時間) で、想定 %d分の完走には足りません",
+	"report.dry_window_none":           "🌂 雨の心配が少ない時間帯はありません",
+	"dry_window.confidence.high":       "高",
+	"dry_window.confidence.medium":     "中",
+	"dry_window.confidence.low":        "低",
+	"report.temperature_range":         "🌡️ %s%.1f°C〜%.1f°C",
+	"report.max_wind":                  "🌬️ 最大風速: %.1f m/s",
+	"report.daylight":                  "🌅 日の出 %s / 日の入り %s (昼の長さ %d時間%02d分)",
+	"report.twilight":                  "   薄明: %s〜%s",
+	"report.wbgt":                      "🥵 暑さ指数(WBGT): %.1f°C (%s: %s)",
+	"report.wbgt_max":                  "🥵 最高暑さ指数(WBGT): %.1f°C (%s: %s)",
+	"report.dust":                      "🌫️ 黄砂: %s (%.0f μg/m³)",
+	"report.clothing":                  "👕 推奨ウェア:",
+	"report.warnings":                  "⚠️ 注意事項:",
+	"report.hours.time":                "⏰ %[1]s時間帯詳細 (%[2]s)",
+	"report.hours.datetime":            "⏰ %[1]s%[2]s時間帯詳細 (%[3]s)",
+	"report.hour":                      "🕐 %s時: %d/100 (%s)",
+	"report.hour.details":              "   🌡️ %.1f°C (体感: %.1f°C) | 💧 %d%% (露点 %.1f°C) | 🌬️ %s %.1fm/s",
+	"report.hour.wbgt":                 " | 🥵 WBGT %.1f (%s)",
+	"report.hour.uv":                   " | ☀️ UV %.0f (%s)",
+	"report.hour.rain_chance":          " | ☂️ %.0f%%",
+	"report.pace":                      "⏱️ ペース: 目標 %s/km → 調整後 %s/km (%+.1f%%)",
+	"report.pace_breakdown":            "   暑さ・湿度 %+.1f%% / 風 %+.1f%%",
+	"report.finish_time":               "🏁 予想タイム (%s): %s",
+	"report.hour.pace":                 "   ⏱️ %s/km (%+.1f%%)",
+	"report.hydration":                 "🥤 給水: %d ml/時 (合計 約%d ml / 想定 %d分)",
+	"report.hydration_none":            "🥤 給水: 走行中は不要 (想定 %d分、前後にコップ1〜2杯)",
+	"report.sweat_rate":                "   発汗量: %.1f L/時 (%s)",
+	"report.sodium":                    "🧂 ナトリウム: %d mg/時 (塩タブレットや経口補水液で)",
+	"report.gels":                      "⚡ 補給食: ジェル%d個 (%d分後から%d分ごと)",
+	"hydration.sweat.estimated":        "推定",
+	"hydration.sweat.configured":       "設定値から",
+	"report.best_time":                 "🏆 最適時間: %s時 (スコア: %d/100)",
+	"report.best.scope_all":            "🗓️ 対象: 各日の %s の全時間 (走行時間 約%d分で評価)",
+	"report.best.scope_period":         "🗓️ 対象: 各日の%s (%s) (走行時間 約%d分で評価)",
+	"report.best.rank":                 "%d. %s (%s) %s時 スタート: %d/100 (%s)",
+	"report.best.worst":                "   走行中の最低スコアは %s時",
+	"reason.temperature":               "🌡️ 気温 %.1f〜%.1f°C / WBGT 最高 %.1f",
+	"reason.wind":                      "🌬️ 風速 最大 %.1f m/s",
+	"reason.rain_chance":               "☂️ 雨に降られる確率 %.0f%%",
+	"reason.dust":                      "🌫️ 黄砂: %s",
+	"reason.light.day":                 "☀️ 明るい時間帯",
+	"reason.light.twilight":            "🌆 薄明の時間帯を含む",
+	"reason.light.dark":                "🌙 暗い時間帯を含む",
+	"reason.no_warnings":               "✅ 注意事項なし",
+	"weather.precipitation_short":      " | 🌧️ %.1fmm",
+
+	// Errors and warnings
+	"error.city_not_found":      "都市が見つかりません: %s\n対応都市: %v\n市区町村名 (例: setagaya, 世田谷区) も指定できます",
+	"error.invalid_coordinate":  "座標が正しくありません: %s (%v)",
+	"error.lat_lon_pair":        "-lat と -lon は両方指定してください",
+	"error.city_ambiguous":      "「%s」に該当する市区町村が複数あります: %s",
+	"error.invalid_time":        "無効な時間指定です: %s",
+	"error.invalid_date":        "無効な日付指定です: %s",
+	"error.past_date":           "過去の日付は指定できません: %s",
+	"error.date_out_of_range":   "指定された日付は予報期間外です: %s (取得済みの予報: %s〜%s)",
+	"error.date_incomplete":     "指定された日付の予報データが不完全です: %s",
+	"error.missing_city":        "city を指定してください",
+	"error.forecast_horizon":    "指定された日付は予報期間外です (最大%d日先まで)",
+	"error.no_time_data":        "指定された時間帯のデータが見つかりません",
+	"error.no_date_data":        "指定された日付のデータが見つかりません",
+	"error.no_datetime_data":    "指定された日付・時間帯のデータが見つかりません",
+	"error.no_cached_data":      "キャッシュされた予報データがありません",
+	"error.cache_dir":           "キャッシュディレクトリを特定できません: %v",
+	"error.invalid_output":      "無効な出力形式です: %s",
+	"error.invalid_distance":    "無効な距離です: %s",
+	"error.invalid_language":    "無効な言語です: %s",
+	"error.invalid_pace":        "無効なペースです: %s",
+	"error.invalid_bearing":     "無効なコースの向きです: %s",
+	"error.invalid_days":        "無効な日数です: %d",
+	"error.invalid_top":         "無効な件数です: %d",
+	"error.best_with_date":      "-best と -date は同時に指定できません",
+	"warning.config_load":       "警告: 設定ファイルの読み込みに失敗しました: %v",
+	"warning.air_quality_fetch": "警告: 大気質データの取得に失敗しました: %v",
+	"hint.valid_output":         "有効な出力形式: text, json",
+	"hint.valid_distance":       "有効な距離: 5k, 10k, half, full",
+	"hint.valid_date":           "有効な日付: today, tomorrow, day-after-tomorrow, 2026-10-20, sat, next-sun, +5d",
+	"hint.valid_time":           "有効な時間: %s",
+	"hint.valid_language":       "有効な言語: %s",
+	"hint.valid_pace":           "有効なペース: 1kmあたりの M:SS 形式 (2:00〜15:00)",
+	"hint.valid_days":           "有効な日数: 1〜%d",
+	"hint.valid_top":            "有効な件数: 1以上",
+	"hint.valid_bearing":        "有効な向き: 北から時計回りの角度 (0〜360) または N, NE, ENE などの16方位",
+
+	// Command line help
+	"flag.lat":        "緯度 (-lon と組み合わせて -city の代わりに指定)",
+	"flag.lon":        "経度 (-lat と組み合わせて -city の代わりに指定)",
+	"flag.city":       "都市名・市区町村名を指定",
+	"flag.time":       "時間帯を指定 (morning, noon, evening, night または [periods] の名前)",
+	"flag.date":       "日付を指定 (today, tomorrow, day-after-tomorrow, 2026-10-20, sat, next-sun, +5d)",
+	"flag.distance":   "目標距離を指定 (5k, 10k, half, full) (デフォルト: 設定ファイルの [profile] distance)",
+	"flag.offline":    "キャッシュ済みの予報データのみを使用",
+	"flag.output":     "出力形式を指定 (text, json)",
+	"flag.lang":       "表示言語を指定 (ja, en)",
+	"flag.pace":       "目標ペースを指定 (例: 5:30 = 1kmあたり5分30秒)",
+	"flag.bearing":    "往復コースの往路の向きを指定 (例: NE または 45)",
+	"flag.best":       "数日間の中からおすすめのスタート時刻を探す",
+	"flag.days":       "-best で探す日数",
+	"flag.top":        "-best で表示する件数",
+	"flag.help":       "ヘルプを表示",
+	"flag.addr":       "待ち受けるアドレス",
+	"flag.cache_ttl":  "同じ問い合わせに評価を使い回す時間 (0 で無効)",
+	"serve.listening": "runcast API を %s で待ち受けています",
+	"serve.stopped":   "runcast API を停止しました",
+	"help.serve": `🏃‍♂️ runcast serve - ランニング天気予報の HTTP API
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+使用方法:
+  runcast serve [オプション]
+
+オプション:
+  -addr string
+      待ち受けるアドレス (デフォルト: 127.0.0.1:8080)
+  -cache-ttl duration
+      同じ問い合わせに評価を使い回す時間 (デフォルト: 10m、0 で無効)
+  -offline
+      キャッシュ済みの予報データのみを使用 (通信しない)
+  -lang string
+      メッセージの言語を指定 (ja, en)
+
+エンドポイント (JSON):
+  GET /v1/assessment?city=tokyo&date=tomorrow&time=morning&distance=10k
+      -output=json と同じ評価 (city 以外は省略可)
+  GET /v1/cities
+      対応都市と設定ファイルのカスタム位置
+  GET /healthz
+      稼働確認
+
+例:
+  runcast serve
+  runcast serve -addr=:8080 -cache-ttl=5m`,
+	"help.now": `🏃‍♂️ runcast now - 現在のランニングコンディション
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+使用方法:
+  runcast now [オプション]
+
+オプション:
+  -city string      都市名・市区町村名・カスタム位置または座標 (デフォルト: tokyo)
+  -lat, -lon string 緯度と経度 (-city の代わりに使用)
+  -distance string  目標距離 (5k, 10k, half, full)
+  -pace string      目標ペース (例: 5:30)
+  -bearing string   往復コースの往路の向き (例: NE または 45)
+  -output string    出力形式 (text, json)
+  -offline          キャッシュ済みの予報データのみを使用
+  -lang string      表示言語 (ja, en)
+
+例:
+  runcast now -city=osaka -distance=10k`,
+	"help.plan": `🏃‍♂️ runcast plan - ランニングの計画
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+使用方法:
+  runcast plan [オプション]
+
+オプション:
+  -date string      日付 (today, tomorrow, 2026-10-20, sat, +5d など)
+  -time string      時間帯 (morning, noon, evening, night または [periods] の名前)
+  -best             数日間のおすすめスタート時刻を順位付け
+  -days int         -best で探す日数 (デフォルト: 7)
+  -top int          -best で表示する件数 (デフォルト: 3)
+  -city, -lat, -lon, -distance, -pace, -bearing, -output, -offline, -lang
+                    runcast now と同じ
+
+-date, -time, -best のいずれも指定しない場合は今日の1日の予報を表示します。
+
+例:
+  runcast plan -date=tomorrow -time=morning -distance=10k
+  runcast plan -best -distance=full -pace=5:30`,
+	"help.cities": `🏃‍♂️ runcast cities - 対応都市とカスタム位置の一覧
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+使用方法:
+  runcast cities [オプション]
+
+オプション:
+  -output string    出力形式 (text, json)
+  -lang string      表示言語 (ja, en)`,
+	"help.config": `🏃‍♂️ runcast config - 設定ファイルの確認
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+使用方法:
+  runcast config [オプション]
+
+使用中の設定ファイルの場所と、その内容が有効かどうかを表示します。
+設定ファイルにエラーがある場合は終了コード 1 で終了します。
+
+オプション:
+  -lang string      表示言語 (ja, en)`,
+	"cities.title":     "📍 対応都市",
+	"cities.custom":    "🏠 カスタム位置 (設定ファイル)",
+	"config.path":      "⚙️ 設定ファイル: %s",
+	"config.not_found": "⚙️ 設定ファイルはありません。次の場所を順に探します:",
+	"config.valid":     "✅ 設定は有効です",
+	"config.invalid":   "❌ 設定にエラーがあります: %v",
+	"config.language":  "   言語: %s",
+	"config.locations": "   カスタム位置: %s",
+	"config.periods":   "   時間帯: %s",
+	"config.pace":      "   目標ペース: %s /km",
+	"config.distance":  "   よく走る距離: %s",
+	"config.bearing":   "   コースの向き: %s",
+	"help": `🏃‍♂️ runcast - ランニング天気予報
+━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
+使用方法:
+  runcast <コマンド> [オプション]
+  runcast [オプション]    # 従来の指定方法 (下記のオプションをすべて使用可能)
+
+コマンド (runcast <コマンド> -help で詳細):
+  now       現在のランニングコンディション
+  plan      日付・時間帯・おすすめスタート時刻で計画 (省略時は今日)
+  cities    対応都市とカスタム位置の一覧
+  config    使用中の設定ファイルの確認
+  serve     HTTP API サーバー
+
+オプション:
+  -city string
+      都市名・市区町村名を指定 (デフォルト: tokyo)
+      座標 (35.68,139.76) や geo URI (geo:35.68,139.76) も指定可能
+  -lat string, -lon string
+      緯度と経度を指定 (-city の代わりに使用)
+  -time string
+      時間帯を指定 (morning, noon, evening, night)
+      設定ファイルの [periods] で定義した時間帯も指定可能
+  -date string
+      日付を指定 (today, tomorrow, day-after-tomorrow)
+      日付 (2026-10-20)、曜日 (sat, next-sun)、相対日数 (+5d) も指定可能
+  -distance string
+      目標距離を指定 (5k, 10k, half, full)
+      省略時は設定ファイルの [profile] の distance を使用
+  -output string
+      出力形式を指定 (text, json) (デフォルト: text)
+  -offline
+      キャッシュ済みの予報データのみを使用 (通信しない)
+  -lang string
+      表示言語を指定 (ja, en) (デフォルト: 設定ファイルまたは LANG、なければ ja)
+  -pace string
+      目標ペースを 1kmあたりの M:SS で指定 (例: 5:30)
+      暑さ・湿度・風を考慮した調整後ペースと予想タイムを表示
+  -bearing string
+      往復コースの往路の向きを方位 (N, NE など) または角度で指定
+      向かい風・横風と、帰りが追い風になるスタート方向を表示
+  -best
+      数日間の毎時を走行時間ごと評価し、おすすめのスタート時刻を順位付け
+      -time を指定するとその時間帯だけを対象にします
+  -days int
+      -best で探す日数 (デフォルト: 7)
+  -top int
+      -best で表示する件数 (デフォルト: 3)
+  -help
+      このヘルプを表示
+
+対応都市:
+%s
+
+カスタム位置設定:
+  .runcast.conf ファイルを作成することで任意の位置を追加できます
+  設定ファイルの配置場所（優先順）:
+    1. カレントディレクトリ: .runcast.conf
+    2. ホームディレクトリ: ~/.runcast.conf
+    3. 設定ディレクトリ: ~/.config/runcast/config.toml
+
+  設定ファイルの例:
+    language = "ja"  # 任意: 表示言語 (ja, en)
+
+    [locations]
+    home = { name = "自宅", lat = 35.6762, lon = 139.6503 }
+    office = { name = "会社", lat = 35.6584, lon = 139.7016 }
+
+    [provider]  # 任意: ミラーサーバーなどを利用する場合
+    forecast_url = "http://localhost:8080/v1/jma"
+
+    [cache]  # 任意: 予報データのキャッシュ設定
+    ttl = "30m"
+
+    [profile]  # 任意: ランナー情報
+    pace = "5:30"  # 普段のペース (1kmあたり)
+    body_weight = 62  # 体重 (kg)
+    sweat_rate = 1.0  # 涼しい日の発汗量 (L/時)
+    distance = "10k"  # -distance 省略時の距離
+    heat_tolerance = "high"  # 暑さへの強さ (low, normal, high)
+    cold_tolerance = "low"  # 寒さへの強さ (low, normal, high)
+    sensitive_airways = true  # 喘息・花粉症
+
+    [route]  # 任意: いつものコース
+    bearing = "NE"  # 往路の向き (方位または角度)
+
+    [periods]  # 任意: 独自の時間帯 (-time=lunch で指定)
+    lunch = { start = "12:00", end = "13:30", name = "昼休み" }
+
+    [scoring]  # 任意: 採点基準の変更 (書いた項目のみ)
+    cool = 12  # これ未満を涼しいと判定 (°C)
+
+例:
+  runcast -city=osaka
+  runcast -city=tokyo -time=morning
+  runcast -city=kyoto -date=tomorrow -distance=10k
+  runcast -city=tokyo -date=sat -time=morning
+  runcast -city=home    # カスタム位置を使用
+  runcast -city=35.68,139.76    # 座標を直接指定
+  runcast -city=tokyo -time=morning -output=json
+  runcast -city=tokyo -lang=en
+  runcast -city=tokyo -date=sun -time=morning -distance=half -pace=5:00
+  runcast -city=tokyo -time=evening -bearing=NE
+  runcast -city=tokyo -best -distance=full -pace=5:30
+  runcast -city=tokyo -time=lunch    # [periods] の時間帯を使用
+  runcast serve -addr=:8080    # HTTP API サーバーを起動
+  runcast plan -date=sat -time=morning    # runcast -date=sat -time=morning と同じ`,
+}
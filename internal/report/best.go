@@ -0,0 +1,144 @@
+package report
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"runcast/internal/i18n"
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// Defaults of the best start time finder
+const (
+	DefaultBestDays = 7
+	DefaultBestTop  = 3
+)
+
+// Candidate is a start time ranked by the best start time finder
+type Candidate struct {
+	Entry
+	Rank     int
+	Duration time.Duration // expected duration of the run
+	Reasons  []string
+}
+
+// lightRank orders the light conditions from the best to the worst for breaking ties
+var lightRank = map[string]int{running.LightDay: 0, "": 1, running.LightTwilight: 2, running.LightDark: 3}
+
+// BuildBest scans every runnable hour of the requested days, or only the hours of the time period when one is given,
+// and ranks the top start times whose runs do not overlap by the assessment over the hours each run covers
+func BuildBest(req Request, weatherData *types.WeatherData, airQuality *types.AirQualityData) (*Report, error) {
+	report := newReport(ModeBest, req, weatherData)
+	report.Days = req.Days
+
+	var hours []types.TimeBasedWeather
+	if req.TimeOfDay != "" {
+		period, exists := weather.GetTimePeriods()[req.TimeOfDay]
+		if !exists {
+			return nil, errors.New(i18n.T("error.invalid_time", req.TimeOfDay))
+		}
+		report.Period = &period
+		hours = weather.ExtractTimeBasedWeather(weatherData, req.TimeOfDay, req.Days)
+	} else {
+		for i, date := range weatherData.Daily.Time {
+			if i >= req.Days {
+				break
+			}
+			hours = append(hours, weather.ExtractRunnableHours(weatherData, date)...)
+		}
+	}
+
+	// Hours that have already started are not worth recommending
+	currentHour := req.Now.In(weather.ForecastLocation(weatherData)).Truncate(time.Hour).Format(weather.ForecastTimeLayout)
+	var candidates []Candidate
+	for _, data := range hours {
+		if !req.Now.IsZero() && data.Time < currentHour {
+			continue
+		}
+		candidates = append(candidates, assessCandidate(data, weatherData, airQuality, req))
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New(i18n.T("error.no_time_data"))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Condition.Score != candidates[j].Condition.Score {
+			return candidates[i].Condition.Score > candidates[j].Condition.Score
+		}
+		return lightRank[candidates[i].Light] < lightRank[candidates[j].Light]
+	})
+
+	top := req.Top
+	if top <= 0 {
+		top = DefaultBestTop
+	}
+	for _, candidate := range candidates {
+		if len(report.Candidates) >= top {
+			break
+		}
+		if overlapsCandidates(candidate, report.Candidates) {
+			continue
+		}
+		candidate.Rank = len(report.Candidates) + 1
+		report.Candidates = append(report.Candidates, candidate)
+	}
+	best := report.Candidates[0].Entry
+	report.Best = &best
+
+	return report, nil
+}
+
+// assessCandidate assesses a run starting with data
+func assessCandidate(data types.TimeBasedWeather, weatherData *types.WeatherData, airQuality *types.AirQualityData, req Request) Candidate {
+	entry := assessEntry(data, weatherData, weather.GetDustLevelAt(airQuality, data.Time), req)
+	return Candidate{
+		Entry:    entry,
+		Duration: runDuration(entry.Pace, req),
+		Reasons:  candidateReasons(entry),
+	}
+}
+
+// candidateReasons summarizes the conditions over the run that the ranking is based on, followed by its warnings
+func candidateReasons(entry Entry) []string {
+	var reasons []string
+	if window := entry.Condition.Window; window != nil {
+		reasons = append(reasons,
+			i18n.T("reason.temperature", window.MinTemperature, window.MaxTemperature, window.PeakWBGT),
+			i18n.T("reason.wind", window.MaxWindSpeed),
+			i18n.T("reason.rain_chance", window.RainChance),
+		)
+	}
+	if entry.Dust != nil {
+		reasons = append(reasons, i18n.T("reason.dust", entry.Dust.DisplayName))
+	}
+	if entry.Light != "" {
+		reasons = append(reasons, i18n.T("reason.light."+entry.Light))
+	}
+	if len(entry.Condition.Warnings) == 0 {
+		return append(reasons, i18n.T("reason.no_warnings"))
+	}
+	return append(reasons, entry.Condition.Warnings...)
+}
+
+// overlapsCandidates reports whether the run of the candidate overlaps a run already ranked
+func overlapsCandidates(candidate Candidate, ranked []Candidate) bool {
+	start, err := time.Parse(weather.ForecastTimeLayout, candidate.Time)
+	if err != nil {
+		return false
+	}
+	end := start.Add(time.Duration(running.RunWindowHours(candidate.Duration)) * time.Hour)
+	for _, other := range ranked {
+		otherStart, err := time.Parse(weather.ForecastTimeLayout, other.Time)
+		if err != nil {
+			continue
+		}
+		otherEnd := otherStart.Add(time.Duration(running.RunWindowHours(other.Duration)) * time.Hour)
+		if start.Before(otherEnd) && otherStart.Before(end) {
+			return true
+		}
+	}
+	return false
+}
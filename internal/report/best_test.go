@@ -0,0 +1,124 @@
+package report
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// newBestTestWeather returns whole days of mild weather; hot hours are given as "YYYY-MM-DDTHH:00"
+func newBestTestWeather(days int, hotHours ...string) *types.WeatherData {
+	hot := make(map[string]bool)
+	for _, hour := range hotHours {
+		hot[hour] = true
+	}
+
+	weatherData := &types.WeatherData{}
+	for day := 0; day < days; day++ {
+		date := fmt.Sprintf("2025-07-%02d", 5+day)
+		weatherData.Daily.Time = append(weatherData.Daily.Time, date)
+		for hour := 0; hour < 24; hour++ {
+			t := fmt.Sprintf("%sT%02d:00", date, hour)
+			temperature := 15.0 + float64(hour%3)
+			if hot[t] {
+				temperature = 33.0
+			}
+			weatherData.Hourly.Time = append(weatherData.Hourly.Time, t)
+			weatherData.Hourly.Temperature = append(weatherData.Hourly.Temperature, temperature)
+			weatherData.Hourly.ApparentTemp = append(weatherData.Hourly.ApparentTemp, temperature)
+			weatherData.Hourly.Humidity = append(weatherData.Hourly.Humidity, 60)
+			weatherData.Hourly.WindSpeed = append(weatherData.Hourly.WindSpeed, 2.0)
+			weatherData.Hourly.WindDirection = append(weatherData.Hourly.WindDirection, 180)
+			weatherData.Hourly.Precipitation = append(weatherData.Hourly.Precipitation, 0)
+			weatherData.Hourly.WeatherCode = append(weatherData.Hourly.WeatherCode, 0)
+		}
+	}
+	return weatherData
+}
+
+func TestBuildBest(t *testing.T) {
+	weatherData := newBestTestWeather(2, "2025-07-05T07:00")
+	now := time.Date(2025, 7, 5, 5, 30, 0, 0, weather.DefaultLocation)
+
+	report, err := Build(Request{Best: true, Days: 2, Top: 4, Distance: running.GetDistanceCategory("half"), Now: now}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if report.Mode != ModeBest || report.Days != 2 {
+		t.Errorf("Unexpected mode %s over %d days", report.Mode, report.Days)
+	}
+	if len(report.Candidates) != 4 {
+		t.Fatalf("Expected 4 candidates, got %d", len(report.Candidates))
+	}
+
+	for i, candidate := range report.Candidates {
+		if candidate.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, candidate.Rank)
+		}
+		if i > 0 && candidate.Condition.Score > report.Candidates[i-1].Condition.Score {
+			t.Errorf("Candidate %d scores %d, above the previous %d", i+1, candidate.Condition.Score, report.Candidates[i-1].Condition.Score)
+		}
+		if candidate.Time < "2025-07-05T05:00" {
+			t.Errorf("Expected no start before the current hour, got %s", candidate.Time)
+		}
+		// A half marathon at the default pace takes two hours, so the hot 07:00 spoils starts from 06:00
+		if candidate.Time == "2025-07-05T06:00" || candidate.Time == "2025-07-05T07:00" {
+			t.Errorf("Expected runs through the hot hour to rank low, got %s", candidate.Time)
+		}
+		if len(candidate.Reasons) == 0 {
+			t.Errorf("Expected reasons for %s", candidate.Time)
+		}
+	}
+
+	// Ranked runs do not overlap
+	for i, candidate := range report.Candidates {
+		for _, other := range report.Candidates[i+1:] {
+			if overlapsCandidates(candidate, []Candidate{other}) {
+				t.Errorf("Runs at %s and %s overlap", candidate.Time, other.Time)
+			}
+		}
+	}
+	if report.Best == nil || report.Best.Time != report.Candidates[0].Time {
+		t.Errorf("Expected the top candidate as the best entry, got %+v", report.Best)
+	}
+}
+
+func TestBuildBestWorstHour(t *testing.T) {
+	weatherData := newBestTestWeather(1, "2025-07-05T07:00")
+	now := time.Date(2025, 7, 5, 6, 0, 0, 0, weather.DefaultLocation)
+
+	// Every one hour window is ranked, so the start at 06:00 keeps its own score
+	report, err := BuildBest(Request{Days: 1, Top: 20, TimeOfDay: "morning", Now: now}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildBest failed: %v", err)
+	}
+	if report.Period == nil || report.Period.Key != "morning" {
+		t.Errorf("Expected the morning period, got %+v", report.Period)
+	}
+	if len(report.Candidates) != 4 {
+		t.Fatalf("Expected the 4 morning hours from 06:00, got %d", len(report.Candidates))
+	}
+	last := report.Candidates[len(report.Candidates)-1]
+	if last.Time != "2025-07-05T07:00" || last.Condition.Window.WorstTime != last.Time {
+		t.Errorf("Expected the hot hour to rank last, got %s (worst %s)", last.Time, last.Condition.Window.WorstTime)
+	}
+
+	// A two hour run from 06:00 is scored by the hot hour it runs into
+	report, err = BuildBest(Request{Days: 1, Top: 20, TimeOfDay: "morning", Distance: running.GetDistanceCategory("half"), Now: now}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildBest failed: %v", err)
+	}
+	for _, candidate := range report.Candidates {
+		if candidate.Time == "2025-07-05T06:00" && candidate.Condition.Window.WorstTime != "2025-07-05T07:00" {
+			t.Errorf("Expected the run from 06:00 to be scored at 07:00, got %s", candidate.Condition.Window.WorstTime)
+		}
+	}
+
+	if _, err := BuildBest(Request{Days: 1, Now: now.AddDate(0, 0, 2)}, weatherData, nil); err == nil {
+		t.Error("Expected an error when every hour has passed")
+	}
+}
@@ -0,0 +1,352 @@
+package report
+
+import (
+	"errors"
+	"time"
+
+	"runcast/internal/i18n"
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// Report modes
+const (
+	ModeCurrent  = "current"  // current conditions
+	ModeTime     = "time"     // a time period of the coming days
+	ModeDate     = "date"     // a whole day
+	ModeDateTime = "datetime" // a time period of a specific day
+	ModeBest     = "best"     // the best start times over several days
+)
+
+// Request describes what a report should cover
+type Request struct {
+	Location  types.CityCoordinate
+	DateSpec  string // empty for today without a date specification
+	DayOffset int
+	TimeOfDay string // empty for the whole day or current conditions
+	Days      int    // forecast days covered by time-based reports
+	Distance  *types.DistanceCategory
+	Pace      time.Duration // target pace per km, zero to skip pace estimates
+	Profile   types.RunnerProfile
+	Route     *types.Route // nil when the course direction is unknown
+	Best      bool         // rank the best start times instead of assessing a date or time period
+	Top       int          // start times ranked in best mode
+	Now       time.Time    // current time, which the covered days are counted from
+}
+
+// Report is the result of the running analysis, independent of how it is rendered
+type Report struct {
+	Mode       string
+	Location   types.CityCoordinate
+	DateSpec   string
+	DateLabel  string // localized label such as 明日の
+	Date       string // YYYY-MM-DD for date-based reports
+	Period     *types.TimePeriod
+	Days       int // days scanned in best mode
+	Distance   *types.DistanceCategory
+	Daylight   *types.Daylight  // sun times of Date, nil when unknown
+	DryWindow  *types.DryWindow // longest dry window of the covered hours, nil when rain is expected throughout
+	TimeZone   *time.Location   // timezone of the local times in the forecast
+	UTCOffset  int              // UTC offset of the forecast in seconds, as reported by the API
+	FetchedAt  time.Time
+	FromCache  bool
+	Summary    *Entry      // current or daily assessment
+	Hours      []Entry     // hourly assessments for time-based reports
+	Best       *Entry      // best hour among Hours, or the top candidate
+	Candidates []Candidate // ranked start times in best mode
+}
+
+// Entry is the assessment of one hour or one day
+type Entry struct {
+	Time      string
+	Weather   types.TimeBasedWeather
+	Daily     *DailySummary // set for whole-day entries
+	Condition types.RunningCondition
+	Dust      *types.DustLevel
+	Pace      *types.PaceEstimate // nil without a target pace
+	Hydration *types.HydrationPlan
+	Light     string           // running.Light* key for hourly entries, empty when unknown
+	RouteWind *types.RouteWind // nil without a route
+}
+
+// DailySummary holds daily values that have no hourly equivalent
+type DailySummary struct {
+	TemperatureMin float64
+	TemperatureMax float64
+}
+
+// Build produces the report for the request, choosing the mode like the CLI does:
+// date and time, date only, time only, or current conditions.
+// The date specification is resolved again against the forecast's own timezone, which may differ from the one
+// expected before fetching it.
+func Build(req Request, weatherData *types.WeatherData, airQuality *types.AirQualityData) (*Report, error) {
+	if req.DateSpec != "" {
+		dayOffset, err := weather.ParseDateOffset(req.DateSpec, req.Now.In(weather.ForecastLocation(weatherData)))
+		if err != nil {
+			return nil, err
+		}
+		req.DayOffset = dayOffset
+	}
+
+	// The other modes cover the days from today on, which a forecast served from the cache
+	// may no longer start with
+	if req.DateSpec == "" {
+		current, err := weather.ExtractForecastDays(weatherData, weather.ForecastDate(weatherData, req.Now, 0), req.Days)
+		if err != nil {
+			return nil, err
+		}
+		weatherData = current
+	}
+
+	if req.Best {
+		return BuildBest(req, weatherData, airQuality)
+	}
+	if req.DateSpec != "" {
+		if req.TimeOfDay != "" {
+			return BuildDateTimeBased(req, weatherData, airQuality)
+		}
+		return BuildDateBased(req, weatherData, airQuality)
+	}
+	if req.TimeOfDay != "" {
+		return BuildTimeBased(req, weatherData, airQuality)
+	}
+	return BuildCurrent(req, weatherData, airQuality), nil
+}
+
+// BuildCurrent assesses the current conditions
+func BuildCurrent(req Request, weatherData *types.WeatherData, airQuality *types.AirQualityData) *Report {
+	report := newReport(ModeCurrent, req, weatherData)
+
+	current := weatherData.Current
+	data := types.TimeBasedWeather{
+		Time:               req.Now.In(weather.ForecastLocation(weatherData)).Format(weather.ForecastTimeLayout),
+		Temperature:        current.Temperature,
+		ApparentTemp:       current.ApparentTemp,
+		Humidity:           current.Humidity,
+		WindSpeed:          current.WindSpeed,
+		WindDirection:      current.WindDirection,
+		Precipitation:      current.Precipitation,
+		WeatherCode:        current.WeatherCode,
+		DewPoint:           current.DewPoint,
+		ShortwaveRadiation: current.ShortwaveRadiation,
+		UVIndex:            current.UVIndex,
+		WindGusts:          current.WindGusts,
+		WBGT: weather.EstimateWBGT(
+			current.Temperature,
+			float64(current.Humidity),
+			current.WindSpeed,
+			current.ShortwaveRadiation,
+		),
+	}
+	entry := assessEntry(data, weatherData, weather.GetCurrentDustLevelAt(airQuality, req.Now), req)
+	report.Summary = &entry
+
+	return report
+}
+
+// BuildTimeBased assesses every hour of a time period over the requested days
+func BuildTimeBased(req Request, weatherData *types.WeatherData, airQuality *types.AirQualityData) (*Report, error) {
+	period, exists := weather.GetTimePeriods()[req.TimeOfDay]
+	if !exists {
+		return nil, errors.New(i18n.T("error.invalid_time", req.TimeOfDay))
+	}
+
+	timeData := weather.ExtractTimeBasedWeather(weatherData, req.TimeOfDay, req.Days)
+	if len(timeData) == 0 {
+		return nil, errors.New(i18n.T("error.no_time_data"))
+	}
+
+	report := newReport(ModeTime, req, weatherData)
+	report.Period = &period
+	report.Hours, report.Best = assessHours(timeData, weatherData, airQuality, req)
+	report.DryWindow = findDryWindow(timeData, req)
+
+	return report, nil
+}
+
+// BuildDateBased assesses a whole day from its daily summary
+func BuildDateBased(req Request, weatherData *types.WeatherData, airQuality *types.AirQualityData) (*Report, error) {
+	dateSpecificWeather, err := weather.ExtractDateBasedWeather(weatherData, weather.ForecastDate(weatherData, req.Now, req.DayOffset))
+	if err != nil {
+		return nil, err
+	}
+	if len(dateSpecificWeather.Daily.Time) == 0 {
+		return nil, errors.New(i18n.T("error.no_date_data"))
+	}
+
+	report := newReport(ModeDate, req, weatherData)
+	report.Date = dateSpecificWeather.Daily.Time[0]
+	report.Daylight, _ = weather.GetDaylight(weatherData, report.Date, req.Location.Lat)
+
+	daily := dateSpecificWeather.Daily
+	maxTemp := daily.TemperatureMax[0]
+	minTemp := daily.TemperatureMin[0]
+
+	// Estimate daily running condition (using average temperature)
+	avgTemp := (maxTemp + minTemp) / 2
+	data := types.TimeBasedWeather{
+		Time:          daily.Time[0],
+		Temperature:   avgTemp,
+		ApparentTemp:  avgTemp,
+		Humidity:      60,
+		WindSpeed:     daily.WindSpeedMax[0],
+		Precipitation: daily.PrecipitationSum[0],
+		WeatherCode:   daily.WeatherCode[0],
+	}
+	data.DewPoint = weather.CalculateDewPoint(avgTemp, float64(data.Humidity))
+	if len(daily.UvIndexMax) > 0 {
+		data.UVIndex = daily.UvIndexMax[0]
+	}
+	if len(daily.WindGustMax) > 0 {
+		data.WindGusts = daily.WindGustMax[0]
+	}
+	if len(daily.WindDirectionDominant) > 0 {
+		data.WindDirection = daily.WindDirectionDominant[0]
+	}
+	if len(daily.PrecipitationProbabilityMax) > 0 {
+		data.PrecipitationProbability = daily.PrecipitationProbabilityMax[0]
+	}
+
+	// Heat stress follows the hottest hour of the day rather than the average
+	if peakWBGT, exists := weather.GetPeakWBGT(weatherData, report.Date); exists {
+		data.WBGT = peakWBGT
+	} else {
+		data.WBGT = weather.EstimateWBGT(maxTemp, float64(data.Humidity), data.WindSpeed, 0)
+	}
+	entry := assessEntry(data, weatherData, weather.GetDailyDustLevel(airQuality, report.Date), req)
+	entry.Daily = &DailySummary{
+		TemperatureMin: minTemp,
+		TemperatureMax: maxTemp,
+	}
+	report.Summary = &entry
+	report.DryWindow = findDryWindow(weather.ExtractRunnableHours(weatherData, report.Date), req)
+
+	return report, nil
+}
+
+// BuildDateTimeBased assesses every hour of a time period on a specific day
+func BuildDateTimeBased(req Request, weatherData *types.WeatherData, airQuality *types.AirQualityData) (*Report, error) {
+	dateSpecificWeather, err := weather.ExtractDateBasedWeather(weatherData, weather.ForecastDate(weatherData, req.Now, req.DayOffset))
+	if err != nil {
+		return nil, err
+	}
+
+	period, exists := weather.GetTimePeriods()[req.TimeOfDay]
+	if !exists {
+		return nil, errors.New(i18n.T("error.invalid_time", req.TimeOfDay))
+	}
+
+	timeData := weather.ExtractTimeBasedWeather(dateSpecificWeather, req.TimeOfDay, 1)
+	if len(timeData) == 0 {
+		return nil, errors.New(i18n.T("error.no_datetime_data"))
+	}
+
+	report := newReport(ModeDateTime, req, weatherData)
+	report.Date = dateSpecificWeather.Daily.Time[0]
+	report.Daylight, _ = weather.GetDaylight(weatherData, report.Date, req.Location.Lat)
+	report.Period = &period
+	report.Hours, report.Best = assessHours(timeData, weatherData, airQuality, req)
+	report.DryWindow = findDryWindow(timeData, req)
+
+	return report, nil
+}
+
+// newReport fills the fields shared by every mode
+func newReport(mode string, req Request, weatherData *types.WeatherData) *Report {
+	report := &Report{
+		Mode:      mode,
+		Location:  req.Location,
+		DateSpec:  req.DateSpec,
+		Distance:  req.Distance,
+		TimeZone:  weather.ForecastLocation(weatherData),
+		UTCOffset: weather.ForecastUTCOffset(weatherData),
+		FetchedAt: weatherData.FetchedAt,
+		FromCache: weatherData.FromCache,
+	}
+	if req.DateSpec != "" {
+		report.DateLabel = weather.GetDateDisplayName(req.DateSpec)
+	}
+	return report
+}
+
+// assessHours assesses each hour and returns the entries with the best one
+func assessHours(timeData []types.TimeBasedWeather, weatherData *types.WeatherData, airQuality *types.AirQualityData, req Request) ([]Entry, *Entry) {
+	entries := make([]Entry, 0, len(timeData))
+	bestIndex := -1
+
+	for _, data := range timeData {
+		entry := assessEntry(data, weatherData, weather.GetDustLevelAt(airQuality, data.Time), req)
+		entries = append(entries, entry)
+
+		if bestIndex < 0 || entry.Condition.Score > entries[bestIndex].Condition.Score {
+			bestIndex = len(entries) - 1
+		}
+	}
+
+	if bestIndex < 0 {
+		return entries, nil
+	}
+	best := entries[bestIndex]
+	return entries, &best
+}
+
+// assessEntry runs the distance-, profile- and dust-aware assessment over the hours the run covers, the pace estimate
+// and the hydration plan for a run starting at one hour, or for one day
+func assessEntry(data types.TimeBasedWeather, weatherData *types.WeatherData, dustLevel *types.DustLevel, req Request) Entry {
+	pace := running.EstimatePace(req.Pace, data, req.Distance, req.Route)
+	duration := runDuration(pace, req)
+	window := runWindow(weatherData, data, running.RunWindowHours(duration))
+
+	condition := running.AssessRunWindow(window, req.Distance)
+	running.ApplyProfile(&condition, req.Profile, req.Distance)
+	running.ApplyDustPenalty(&condition, dustLevel, req.Distance, req.Profile)
+
+	light := applyDaylight(&condition, data, weatherData, req, duration)
+
+	return Entry{
+		Time:      data.Time,
+		Weather:   data,
+		Condition: condition,
+		Dust:      dustLevel,
+		Pace:      pace,
+		Hydration: running.PlanHydration(window, duration, req.Profile),
+		Light:     light,
+		RouteWind: running.AnalyzeRouteWind(data, req.Route),
+	}
+}
+
+// runDuration returns the expected duration of the run, the estimated finish time when there is one
+func runDuration(pace *types.PaceEstimate, req Request) time.Duration {
+	if pace != nil && pace.FinishTime > 0 {
+		return pace.FinishTime
+	}
+	return running.EstimateRunDuration(req.Pace, req.Distance)
+}
+
+// applyDaylight flags a run starting at data's hour in the dark.
+// Whole-day entries have no start time and are left as they are.
+func applyDaylight(condition *types.RunningCondition, data types.TimeBasedWeather, weatherData *types.WeatherData, req Request, duration time.Duration) string {
+	start, err := time.ParseInLocation(weather.ForecastTimeLayout, data.Time, weather.ForecastLocation(weatherData))
+	if err != nil {
+		return ""
+	}
+	daylight, _ := weather.GetDaylight(weatherData, start.Format("2006-01-02"), req.Location.Lat)
+	return running.ApplyDaylight(condition, start, duration, daylight)
+}
+
+// findDryWindow looks for the longest dry window of the hours, long enough when it fits a run at the target pace
+func findDryWindow(hours []types.TimeBasedWeather, req Request) *types.DryWindow {
+	return running.FindDryWindow(hours, running.EstimateRunDuration(req.Pace, req.Distance))
+}
+
+// runWindow returns the forecast hours covered by a run starting with data, using data itself for the first hour.
+// Whole-day entries have no start hour and stand for the whole run.
+func runWindow(weatherData *types.WeatherData, data types.TimeBasedWeather, hours int) []types.TimeBasedWeather {
+	window := weather.ExtractRunWindow(weatherData, data.Time, hours)
+	if len(window) == 0 {
+		return []types.TimeBasedWeather{data}
+	}
+	window[0] = data
+	return window
+}
@@ -0,0 +1,457 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// newTestWeather returns two days of forecast data with mornings at 05:00-07:00
+func newTestWeather() *types.WeatherData {
+	weatherData := &types.WeatherData{}
+	weatherData.Current.Temperature = 20.0
+	weatherData.Current.ApparentTemp = 20.0
+	weatherData.Current.Humidity = 50
+	weatherData.Current.WindSpeed = 2.0
+
+	weatherData.Daily.Time = []string{"2025-07-05", "2025-07-06"}
+	weatherData.Daily.TemperatureMax = []float64{30.0, 24.0}
+	weatherData.Daily.TemperatureMin = []float64{22.0, 16.0}
+	weatherData.Daily.WeatherCode = []int{0, 3}
+	weatherData.Daily.WindSpeedMax = []float64{4.0, 3.0}
+	weatherData.Daily.PrecipitationSum = []float64{0, 0}
+
+	weatherData.Hourly.Time = []string{
+		"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00",
+		"2025-07-06T05:00", "2025-07-06T06:00", "2025-07-06T07:00",
+	}
+	weatherData.Hourly.Temperature = []float64{22.0, 24.0, 27.0, 16.0, 12.0, 18.0}
+	weatherData.Hourly.ApparentTemp = []float64{22.0, 24.0, 29.0, 16.0, 12.0, 18.0}
+	weatherData.Hourly.Humidity = []int{70, 65, 60, 55, 55, 50}
+	weatherData.Hourly.WindSpeed = []float64{2.0, 2.0, 3.0, 1.0, 1.0, 2.0}
+	weatherData.Hourly.WindDirection = []float64{0, 90, 180, 0, 90, 180}
+	weatherData.Hourly.Precipitation = []float64{0, 0, 0, 0, 0, 0}
+	weatherData.Hourly.WeatherCode = []int{0, 0, 1, 1, 0, 0}
+
+	return weatherData
+}
+
+// testNow is before the first morning of newTestWeather
+var testNow = time.Date(2025, 7, 5, 4, 0, 0, 0, weather.DefaultLocation)
+
+func TestBuildSelectsMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateSpec  string
+		dayOffset int
+		timeOfDay string
+		expected  string
+	}{
+		{name: "current", expected: ModeCurrent},
+		{name: "time", timeOfDay: "morning", expected: ModeTime},
+		{name: "date", dateSpec: "tomorrow", dayOffset: 1, expected: ModeDate},
+		{name: "datetime", dateSpec: "tomorrow", dayOffset: 1, timeOfDay: "morning", expected: ModeDateTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Build(Request{
+				Location:  types.CityCoordinate{Name: "東京"},
+				DateSpec:  tt.dateSpec,
+				DayOffset: tt.dayOffset,
+				TimeOfDay: tt.timeOfDay,
+				Days:      2,
+				Now:       testNow,
+			}, newTestWeather(), nil)
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			if report.Mode != tt.expected {
+				t.Errorf("Expected mode %s, got %s", tt.expected, report.Mode)
+			}
+		})
+	}
+}
+
+func TestBuildCurrent(t *testing.T) {
+	airQuality := &types.AirQualityData{}
+	airQuality.Hourly.Time = []string{"2025-07-05T06:00", "2025-07-05T07:00"}
+	airQuality.Hourly.Dust = []float64{10, 150}
+
+	now := time.Date(2025, 7, 5, 7, 30, 0, 0, weather.DefaultLocation)
+	report := BuildCurrent(Request{Now: now}, newTestWeather(), airQuality)
+
+	if report.Summary == nil {
+		t.Fatal("Expected a summary entry")
+	}
+	if report.Summary.Time != "2025-07-05T07:30" {
+		t.Errorf("Expected time 2025-07-05T07:30, got %s", report.Summary.Time)
+	}
+	if report.Summary.Dust == nil || report.Summary.Dust.Dust != 150 {
+		t.Errorf("Expected the dust level of the current hour, got %+v", report.Summary.Dust)
+	}
+
+	// The dust penalty is part of the assessment
+	baseline := running.AssessRunningCondition(20.0, 20.0, 50, 2.0, 0, 0)
+	if report.Summary.Condition.Score >= baseline.Score {
+		t.Errorf("Expected dust to lower the score below %d, got %d", baseline.Score, report.Summary.Condition.Score)
+	}
+}
+
+func TestBuildDateBased(t *testing.T) {
+	report, err := BuildDateBased(Request{DateSpec: "tomorrow", DayOffset: 1, Now: testNow}, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+
+	if report.Date != "2025-07-06" || report.DateLabel != "明日の" {
+		t.Errorf("Unexpected date %s (%s)", report.Date, report.DateLabel)
+	}
+	summary := report.Summary
+	if summary == nil || summary.Daily == nil {
+		t.Fatal("Expected a daily summary entry")
+	}
+	if summary.Daily.TemperatureMin != 16.0 || summary.Daily.TemperatureMax != 24.0 {
+		t.Errorf("Unexpected min/max: %+v", summary.Daily)
+	}
+	if summary.Weather.Temperature != 20.0 {
+		t.Errorf("Expected average temperature 20.0, got %.1f", summary.Weather.Temperature)
+	}
+
+	// Heat stress follows the hottest hour rather than the average temperature
+	expectedWBGT := weather.EstimateWBGT(18.0, 50, 2.0, 0)
+	if heatStress := summary.Condition.HeatStress; heatStress == nil || heatStress.WBGT != expectedWBGT {
+		t.Errorf("Expected peak WBGT %.1f, got %+v", expectedWBGT, heatStress)
+	}
+
+	if _, err := BuildDateBased(Request{DateSpec: "+5d", DayOffset: 5, Now: testNow}, newTestWeather(), nil); err == nil {
+		t.Error("Expected an error for a date outside the forecast")
+	}
+}
+
+func TestBuildDateTimeBased(t *testing.T) {
+	report, err := BuildDateTimeBased(Request{
+		DateSpec:  "tomorrow",
+		DayOffset: 1,
+		TimeOfDay: "morning",
+		Distance:  running.GetDistanceCategory("half"),
+		Now:       testNow,
+	}, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("BuildDateTimeBased failed: %v", err)
+	}
+
+	if report.Period == nil || report.Period.Key != "morning" {
+		t.Errorf("Unexpected period: %+v", report.Period)
+	}
+	if len(report.Hours) != 3 {
+		t.Fatalf("Expected 3 hours of the requested day, got %d", len(report.Hours))
+	}
+	for _, entry := range report.Hours {
+		if weather.ExtractHour(entry.Time) == "" || entry.Time[:10] != "2025-07-06" {
+			t.Errorf("Unexpected hour %s", entry.Time)
+		}
+	}
+
+	// The best entry is the highest-scoring hour
+	if report.Best == nil {
+		t.Fatal("Expected a best entry")
+	}
+	for _, entry := range report.Hours {
+		if entry.Condition.Score > report.Best.Condition.Score {
+			t.Errorf("Hour %s scores %d, above best %d", entry.Time, entry.Condition.Score, report.Best.Condition.Score)
+		}
+	}
+
+	if _, err := BuildDateTimeBased(Request{DayOffset: 1, TimeOfDay: "midnight"}, newTestWeather(), nil); err == nil {
+		t.Error("Expected an error for an unknown time period")
+	}
+}
+
+func TestBuildHydrationPlans(t *testing.T) {
+	// A half marathon at 5:00/km takes about 1:45
+	req := Request{TimeOfDay: "morning", Days: 2, Distance: running.GetDistanceCategory("half"), Pace: 5 * time.Minute}
+	report, err := BuildTimeBased(req, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("BuildTimeBased failed: %v", err)
+	}
+
+	for _, entry := range report.Hours {
+		plan := entry.Hydration
+		if plan == nil {
+			t.Fatalf("Expected a hydration plan at %s", entry.Time)
+		}
+		if plan.Duration != entry.Pace.FinishTime {
+			t.Errorf("Expected the plan to cover the finish time %v, got %v at %s", entry.Pace.FinishTime, plan.Duration, entry.Time)
+		}
+		if plan.FluidPerHour == 0 || plan.GelCount == 0 {
+			t.Errorf("Expected fluids and gels for a half marathon at %s, got %+v", entry.Time, plan)
+		}
+	}
+
+	// A measured sweat rate is used instead of the estimate
+	req.Profile = types.RunnerProfile{SweatRate: 1.8}
+	report, err = BuildDateBased(Request{DayOffset: 1, Profile: req.Profile, Now: testNow}, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	if plan := report.Summary.Hydration; plan == nil || plan.SweatRateEstimated || plan.Duration != running.DefaultRunDuration {
+		t.Errorf("Expected a one hour plan from the measured sweat rate, got %+v", plan)
+	}
+}
+
+func TestBuildDryWindow(t *testing.T) {
+	weatherData := newTestWeather()
+	weatherData.Hourly.PrecipitationProbability = []float64{10, 60, 0, 0, 10, 20}
+	weatherData.Daily.PrecipitationProbabilityMax = []float64{60, 20}
+
+	// The two days do not join, so tomorrow's three hours are the longest window
+	report, err := BuildTimeBased(Request{TimeOfDay: "morning", Days: 2}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildTimeBased failed: %v", err)
+	}
+	window := report.DryWindow
+	if window == nil || window.Start != "2025-07-06T05:00" || window.End != "2025-07-06T08:00" {
+		t.Fatalf("Expected tomorrow 05:00-08:00, got %+v", window)
+	}
+	if !window.LongEnough || window.RunDuration != running.DefaultRunDuration {
+		t.Errorf("Expected a one hour run to fit, got %+v", window)
+	}
+
+	// A half marathon at 6:00/km does not fit into today's two dry hours
+	report, err = BuildDateBased(Request{DateSpec: "today", Distance: running.GetDistanceCategory("half"), Now: testNow}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	if window := report.DryWindow; window == nil || window.Hours != 1 || window.LongEnough {
+		t.Errorf("Expected a window too short for a half marathon, got %+v", window)
+	}
+	if probability := report.Summary.Weather.PrecipitationProbability; probability != 60 {
+		t.Errorf("Expected the daily maximum chance of rain 60%%, got %.0f", probability)
+	}
+}
+
+func TestBuildDateBasedUV(t *testing.T) {
+	weatherData := newTestWeather()
+	weatherData.Daily.UvIndexMax = []float64{9.1, 4.0}
+
+	report, err := BuildDateBased(Request{DayOffset: 0, Now: testNow}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	if report.Summary.Weather.UVIndex != 9.1 {
+		t.Errorf("Expected the daily maximum UV index, got %.1f", report.Summary.Weather.UVIndex)
+	}
+
+	// Missing UV data counts as low
+	weatherData.Daily.UvIndexMax = nil
+	report, err = BuildDateBased(Request{DayOffset: 1, Now: testNow}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	if report.Summary.Weather.UVIndex != 0 {
+		t.Errorf("Expected no UV index without data, got %.1f", report.Summary.Weather.UVIndex)
+	}
+}
+
+func TestBuildDateBasedDust(t *testing.T) {
+	// Heavy dust now, clearing later today, and no air quality forecast for tomorrow
+	airQuality := &types.AirQualityData{}
+	airQuality.Hourly.Time = []string{"2025-07-05T04:00", "2025-07-05T05:00", "2025-07-05T06:00"}
+	airQuality.Hourly.Dust = []float64{30, 250, 20}
+	airQuality.Hourly.PM2_5 = []float64{40, 10, 5}
+
+	today, err := BuildDateBased(Request{Now: testNow}, newTestWeather(), airQuality)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	if dust := today.Summary.Dust; dust == nil || dust.Dust != 250 || dust.PM2_5 != 40 || dust.Level != 3 {
+		t.Errorf("Expected the worst dust of the day, got %+v", dust)
+	}
+
+	tomorrow, err := BuildDateBased(Request{DayOffset: 1, Now: testNow}, newTestWeather(), airQuality)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	if tomorrow.Summary.Dust != nil {
+		t.Errorf("Expected no dust beyond the air quality forecast, got %+v", tomorrow.Summary.Dust)
+	}
+}
+
+func TestBuildUVOnlyAtMidday(t *testing.T) {
+	// A very high UV index in every field the forecast has
+	weatherData := newTestWeather()
+	weatherData.Daily.UvIndexMax = []float64{10, 10}
+	weatherData.Hourly.UVIndex = []float64{10, 10, 10, 10, 10, 10}
+	withoutUV := newTestWeather()
+
+	morning, err := BuildDateTimeBased(Request{TimeOfDay: "morning", Now: testNow}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildDateTimeBased failed: %v", err)
+	}
+	expected, _ := BuildDateTimeBased(Request{TimeOfDay: "morning", Now: testNow}, withoutUV, nil)
+	for i, entry := range morning.Hours {
+		if entry.Condition.Score != expected.Hours[i].Condition.Score {
+			t.Errorf("Expected no UV penalty at %s, got %d vs %d", entry.Weather.Time, entry.Condition.Score, expected.Hours[i].Condition.Score)
+		}
+	}
+
+	// The whole day shows the peak but does not know when the run is
+	day, err := BuildDateBased(Request{Now: testNow}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildDateBased failed: %v", err)
+	}
+	expected, _ = BuildDateBased(Request{Now: testNow}, withoutUV, nil)
+	if day.Summary.Weather.UVIndex != 10 || day.Summary.Condition.Score != expected.Summary.Condition.Score {
+		t.Errorf("Expected the peak UV index without a penalty, got %.1f and %d vs %d", day.Summary.Weather.UVIndex, day.Summary.Condition.Score, expected.Summary.Condition.Score)
+	}
+}
+
+func TestBuildDaylight(t *testing.T) {
+	weatherData := newTestWeather()
+	weatherData.Daily.SunriseTime = []string{"2025-07-05T06:10", "2025-07-06T06:10"}
+	weatherData.Daily.SunsetTime = []string{"2025-07-05T18:50", "2025-07-06T18:50"}
+	req := Request{
+		Location:  types.CityCoordinate{Name: "東京", Lat: 35.6762, Lon: 139.6503},
+		DateSpec:  "tomorrow",
+		DayOffset: 1,
+		TimeOfDay: "morning",
+		Now:       testNow,
+	}
+
+	report, err := BuildDateTimeBased(req, weatherData, nil)
+	if err != nil {
+		t.Fatalf("BuildDateTimeBased failed: %v", err)
+	}
+	if report.Daylight == nil || report.Daylight.CivilDawn.Format("15:04") != "05:40" {
+		t.Fatalf("Expected civil dawn at 05:40, got %+v", report.Daylight)
+	}
+
+	// One hour runs from 05:00, 06:00 and 07:00
+	expected := []string{running.LightDark, running.LightTwilight, running.LightDay}
+	if len(report.Hours) != len(expected) {
+		t.Fatalf("Expected %d hours, got %d", len(expected), len(report.Hours))
+	}
+	for i, entry := range report.Hours {
+		if entry.Light != expected[i] {
+			t.Errorf("Expected %s at %s, got %s", expected[i], entry.Time, entry.Light)
+		}
+	}
+	if len(report.Hours[0].Condition.Clothing) <= len(report.Hours[2].Condition.Clothing) {
+		t.Errorf("Expected extra gear in the dark, got %v", report.Hours[0].Condition.Clothing)
+	}
+}
+
+func TestBuildPaceEstimates(t *testing.T) {
+	req := Request{TimeOfDay: "morning", Days: 2, Distance: running.GetDistanceCategory("10k"), Pace: 5 * time.Minute}
+	report, err := BuildTimeBased(req, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("BuildTimeBased failed: %v", err)
+	}
+
+	for _, entry := range report.Hours {
+		if entry.Pace == nil || entry.Pace.FinishTime == 0 {
+			t.Fatalf("Expected a pace estimate with a finish time at %s", entry.Time)
+		}
+		if entry.Pace.AdjustedPace < req.Pace {
+			t.Errorf("Adjusted pace %v should not be faster than the target at %s", entry.Pace.AdjustedPace, entry.Time)
+		}
+	}
+	if report.Best == nil || report.Best.Pace == nil {
+		t.Error("Expected the best hour to carry its pace estimate")
+	}
+
+	// No estimates without a target pace
+	req.Pace = 0
+	report, err = BuildTimeBased(req, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("BuildTimeBased failed: %v", err)
+	}
+	for _, entry := range report.Hours {
+		if entry.Pace != nil {
+			t.Errorf("Expected no pace estimate without a target pace at %s", entry.Time)
+		}
+	}
+}
+
+func TestBuildForecastTimezone(t *testing.T) {
+	// A forecast for New York, fetched at 10:30 in Japan on the 5th, which is still the evening of the 4th there
+	weatherData := newTestWeather()
+	weatherData.Timezone = "America/New_York"
+	weatherData.UTCOffsetSeconds = -4 * 60 * 60
+	weatherData.Daily.Time = []string{"2025-07-04", "2025-07-05"}
+	weatherData.Hourly.Time = []string{
+		"2025-07-04T05:00", "2025-07-04T06:00", "2025-07-04T07:00",
+		"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00",
+	}
+	now := time.Date(2025, 7, 5, 10, 30, 0, 0, weather.DefaultLocation)
+
+	airQuality := &types.AirQualityData{Timezone: "America/New_York", UTCOffsetSeconds: -4 * 60 * 60}
+	airQuality.Hourly.Time = []string{"2025-07-04T21:00", "2025-07-05T10:00"}
+	airQuality.Hourly.Dust = []float64{150, 10}
+
+	current := BuildCurrent(Request{Now: now}, weatherData, airQuality)
+	if current.Summary.Time != "2025-07-04T21:30" {
+		t.Errorf("Expected the local time 2025-07-04T21:30, got %s", current.Summary.Time)
+	}
+	if current.Summary.Dust == nil || current.Summary.Dust.Dust != 150 {
+		t.Errorf("Expected the dust level of the local hour, got %+v", current.Summary.Dust)
+	}
+	if _, offset := now.In(current.TimeZone).Zone(); current.TimeZone.String() != "America/New_York" || offset != -4*60*60 {
+		t.Errorf("Expected the forecast's timezone, got %s %d", current.TimeZone, offset)
+	}
+
+	// The 5th is today in Japan but tomorrow in New York, so the date is resolved again against the local date
+	report, err := Build(Request{DateSpec: "2025-07-05", DayOffset: 0, TimeOfDay: "morning", Days: 2, Now: now}, weatherData, nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if report.Date != "2025-07-05" {
+		t.Errorf("Expected 2025-07-05, got %s", report.Date)
+	}
+}
+
+func TestBuildStaleForecast(t *testing.T) {
+	// The forecast of the 5th and 6th, served from the cache on the 6th
+	now := testNow.AddDate(0, 0, 1)
+
+	report, err := Build(Request{Now: now}, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if report.Mode != ModeCurrent {
+		t.Errorf("Expected current conditions, got %s", report.Mode)
+	}
+
+	report, err = Build(Request{TimeOfDay: "morning", Days: 2, Now: now}, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	for _, entry := range report.Hours {
+		if entry.Time[:10] != "2025-07-06" {
+			t.Errorf("Expected only the hours from today on, got %s", entry.Time)
+		}
+	}
+
+	report, err = Build(Request{DateSpec: "today", TimeOfDay: "morning", Now: now}, newTestWeather(), nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if report.Date != "2025-07-06" {
+		t.Errorf("Expected today to be 2025-07-06, got %s", report.Date)
+	}
+
+	// Days the forecast no longer covers are an error rather than another day's data
+	for _, req := range []Request{
+		{DateSpec: "tomorrow", Now: now},
+		{Now: now.AddDate(0, 0, 1)},
+		{TimeOfDay: "morning", Days: 1, Now: now.AddDate(0, 0, 1)},
+	} {
+		if _, err := Build(req, newTestWeather(), nil); err == nil {
+			t.Errorf("Expected an error for %+v", req)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package running
+
+import (
+	"time"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+// Light conditions of a run
+const (
+	LightDay      = "day"      // between sunrise and sunset
+	LightTwilight = "twilight" // partly in civil twilight
+	LightDark     = "dark"     // partly before civil dawn or after civil dusk
+)
+
+// GetLightKey returns the light condition of a run from start lasting duration
+func GetLightKey(start time.Time, duration time.Duration, daylight *types.Daylight) string {
+	end := start.Add(duration)
+	switch {
+	case start.Before(daylight.CivilDawn) || end.After(daylight.CivilDusk):
+		return LightDark
+	case start.Before(daylight.Sunrise) || end.After(daylight.Sunset):
+		return LightTwilight
+	default:
+		return LightDay
+	}
+}
+
+// ApplyDaylight flags a run in the dark and adds reflective gear and a headlamp to the clothing.
+// It returns the light key, or an empty string without sun times.
+func ApplyDaylight(condition *types.RunningCondition, start time.Time, duration time.Duration, daylight *types.Daylight) string {
+	if daylight == nil {
+		return ""
+	}
+
+	light := GetLightKey(start, duration, daylight)
+	switch light {
+	case LightDark:
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.darkness",
+			daylight.CivilDawn.Format("15:04"), daylight.CivilDusk.Format("15:04")))
+		condition.Clothing = append(condition.Clothing, i18n.T("clothing.reflective"), i18n.T("clothing.headlamp"))
+	case LightTwilight:
+		condition.Clothing = append(condition.Clothing, i18n.T("clothing.reflective"))
+	}
+	return light
+}
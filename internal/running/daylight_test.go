@@ -0,0 +1,72 @@
+package running
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+func TestGetLightKey(t *testing.T) {
+	at := func(clock string) time.Time {
+		parsed, _ := time.Parse("2006-01-02T15:04", "2025-07-05T"+clock)
+		return parsed
+	}
+	daylight := &types.Daylight{
+		CivilDawn: at("04:00"),
+		Sunrise:   at("04:30"),
+		Sunset:    at("19:00"),
+		CivilDusk: at("19:30"),
+	}
+
+	tests := []struct {
+		name     string
+		start    string
+		duration time.Duration
+		expected string
+	}{
+		{name: "Before civil dawn", start: "03:30", duration: time.Hour, expected: LightDark},
+		{name: "Dawn twilight", start: "04:00", duration: time.Hour, expected: LightTwilight},
+		{name: "Daytime", start: "06:00", duration: time.Hour, expected: LightDay},
+		{name: "Ends after sunset", start: "18:30", duration: time.Hour, expected: LightTwilight},
+		{name: "Ends after dusk", start: "18:30", duration: 2 * time.Hour, expected: LightDark},
+		{name: "Night", start: "21:00", duration: time.Hour, expected: LightDark},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetLightKey(at(tt.start), tt.duration, daylight); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyDaylight(t *testing.T) {
+	start := time.Date(2025, 7, 5, 21, 0, 0, 0, time.UTC)
+	daylight := &types.Daylight{
+		CivilDawn: start.Add(-17 * time.Hour),
+		Sunrise:   start.Add(-16 * time.Hour),
+		Sunset:    start.Add(-2 * time.Hour),
+		CivilDusk: start.Add(-90 * time.Minute),
+	}
+
+	condition := AssessWeather(types.TimeBasedWeather{Temperature: 20.0, Humidity: 50})
+	if light := ApplyDaylight(&condition, start, time.Hour, daylight); light != LightDark {
+		t.Fatalf("Expected a dark run, got %s", light)
+	}
+	if !strings.Contains(strings.Join(condition.Warnings, "\n"), "🔦") {
+		t.Errorf("Expected a darkness warning, got %v", condition.Warnings)
+	}
+	if len(condition.Clothing) < 2 {
+		t.Errorf("Expected reflective gear and a headlamp, got %v", condition.Clothing)
+	}
+
+	// Without sun times nothing is added
+	condition = AssessWeather(types.TimeBasedWeather{Temperature: 20.0, Humidity: 50})
+	clothing := len(condition.Clothing)
+	if light := ApplyDaylight(&condition, start, time.Hour, nil); light != "" || len(condition.Clothing) != clothing {
+		t.Errorf("Expected no change without sun times, got %s %v", light, condition.Clothing)
+	}
+}
@@ -0,0 +1,41 @@
+package running
+
+import (
+	"runcast/internal/i18n"
+)
+
+// GetDewPointComfortKey returns the language-independent comfort band key for a dew point
+func GetDewPointComfortKey(dewPoint float64) string {
+	thresholds := scoring.Thresholds
+	switch {
+	case dewPoint >= thresholds.DewPointDangerous:
+		return "dangerous"
+	case dewPoint >= thresholds.DewPointOppressive:
+		return "oppressive"
+	case dewPoint >= thresholds.DewPointMuggy:
+		return "muggy"
+	case dewPoint >= thresholds.DewPointHumid:
+		return "humid"
+	default:
+		return "comfortable"
+	}
+}
+
+// GetDewPointComfortName returns the localized comfort band name for a dew point
+func GetDewPointComfortName(dewPoint float64) string {
+	return i18n.T("dew_point." + GetDewPointComfortKey(dewPoint))
+}
+
+// GetDewPointPenalty returns the humidity penalty for a dew point comfort band
+func GetDewPointPenalty(dewPoint float64) int {
+	switch GetDewPointComfortKey(dewPoint) {
+	case "muggy":
+		return scoring.Penalties.HumidityMuggy
+	case "oppressive":
+		return scoring.Penalties.HumidityOppressive
+	case "dangerous":
+		return scoring.Penalties.HumidityDangerous
+	default:
+		return 0
+	}
+}
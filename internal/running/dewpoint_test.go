@@ -0,0 +1,57 @@
+package running
+
+import (
+	"testing"
+
+	"runcast/internal/types"
+)
+
+func TestGetDewPointComfortKey(t *testing.T) {
+	tests := []struct {
+		dewPoint float64
+		expected string
+		penalty  int
+	}{
+		{dewPoint: 5.0, expected: "comfortable", penalty: 0},
+		{dewPoint: 13.0, expected: "humid", penalty: 0},
+		{dewPoint: 16.5, expected: "muggy", penalty: 10},
+		{dewPoint: 20.0, expected: "oppressive", penalty: 20},
+		{dewPoint: 24.0, expected: "dangerous", penalty: 30},
+	}
+
+	for _, tt := range tests {
+		if got := GetDewPointComfortKey(tt.dewPoint); got != tt.expected {
+			t.Errorf("Dew point %.1f: expected %s, got %s", tt.dewPoint, tt.expected, got)
+		}
+		if got := GetDewPointPenalty(tt.dewPoint); got != tt.penalty {
+			t.Errorf("Dew point %.1f: expected penalty %d, got %d", tt.dewPoint, tt.penalty, got)
+		}
+	}
+
+	if name := GetDewPointComfortName(17.0); name != "蒸し暑い" {
+		t.Errorf("Expected 蒸し暑い, got %s", name)
+	}
+}
+
+func TestAssessWeatherDewPoint(t *testing.T) {
+	// Same relative humidity, different dew points
+	dry := types.TimeBasedWeather{Temperature: 18.0, ApparentTemp: 18.0, Humidity: 60, WindSpeed: 2.0, DewPoint: 10.1, WBGT: 15.0}
+	muggy := dry
+	muggy.DewPoint = 16.5
+
+	dryCondition := AssessWeather(dry)
+	muggyCondition := AssessWeather(muggy)
+	if dryCondition.Score-muggyCondition.Score != 10 {
+		t.Errorf("Expected a muggy dew point to cost 10 points, got %d vs %d", dryCondition.Score, muggyCondition.Score)
+	}
+	if len(muggyCondition.Warnings) != 1 || muggyCondition.Warnings[0] != "💧 高湿度: 汗が乾きにくい状態です" {
+		t.Errorf("Expected the humidity warning, got %v", muggyCondition.Warnings)
+	}
+
+	// The heat stress penalty already covers humidity, so the larger of the two applies
+	hot := types.TimeBasedWeather{Temperature: 33.0, ApparentTemp: 38.0, Humidity: 60, WindSpeed: 2.0, DewPoint: 24.2, WBGT: 29.0}
+	hotCondition := AssessWeather(hot)
+	if hotCondition.Score != 100-45 {
+		t.Errorf("Expected only the severe heat stress penalty, got score %d", hotCondition.Score)
+	}
+}
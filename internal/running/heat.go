@@ -0,0 +1,61 @@
+package running
+
+import (
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+// GetHeatStressLevelKey returns the language-independent band key for a WBGT value
+func GetHeatStressLevelKey(wbgt float64) string {
+	thresholds := scoring.Thresholds
+	switch {
+	case wbgt >= thresholds.WBGTDanger:
+		return "danger"
+	case wbgt >= thresholds.WBGTSevere:
+		return "severe"
+	case wbgt >= thresholds.WBGTWarning:
+		return "warning"
+	case wbgt >= thresholds.WBGTCaution:
+		return "caution"
+	default:
+		return "safe"
+	}
+}
+
+// NewHeatStress creates the heat stress assessment for a WBGT value
+func NewHeatStress(wbgt float64) *types.HeatStress {
+	level := GetHeatStressLevelKey(wbgt)
+	return &types.HeatStress{
+		WBGT:        wbgt,
+		Level:       level,
+		DisplayName: i18n.T("heat.level." + level),
+		Guidance:    i18n.T("heat.guidance." + level),
+	}
+}
+
+// GetHeatStressPenalty returns the score penalty for a WBGT band.
+// The caution band only warns since humidity is already penalized separately.
+func GetHeatStressPenalty(heatStress *types.HeatStress) int {
+	if heatStress == nil {
+		return 0
+	}
+
+	switch heatStress.Level {
+	case "warning":
+		return scoring.Penalties.WBGTWarning
+	case "severe":
+		return scoring.Penalties.WBGTSevere
+	case "danger":
+		return scoring.Penalties.WBGTDanger
+	default:
+		return 0
+	}
+}
+
+// getHeatStressWarning returns the warning for a WBGT band, or an empty string when it is safe
+func getHeatStressWarning(heatStress *types.HeatStress) string {
+	if heatStress == nil || heatStress.Level == "safe" {
+		return ""
+	}
+	return i18n.T("warning.wbgt."+heatStress.Level, heatStress.WBGT)
+}
@@ -0,0 +1,69 @@
+package running
+
+import (
+	"testing"
+
+	"runcast/internal/types"
+)
+
+func TestGetHeatStressLevelKey(t *testing.T) {
+	tests := []struct {
+		wbgt     float64
+		expected string
+	}{
+		{wbgt: 15.0, expected: "safe"},
+		{wbgt: 20.9, expected: "safe"},
+		{wbgt: 21.0, expected: "caution"},
+		{wbgt: 25.0, expected: "warning"},
+		{wbgt: 28.0, expected: "severe"},
+		{wbgt: 30.9, expected: "severe"},
+		{wbgt: 31.0, expected: "danger"},
+	}
+
+	for _, tt := range tests {
+		if got := GetHeatStressLevelKey(tt.wbgt); got != tt.expected {
+			t.Errorf("WBGT %.1f: expected %s, got %s", tt.wbgt, tt.expected, got)
+		}
+	}
+}
+
+func TestNewHeatStress(t *testing.T) {
+	heatStress := NewHeatStress(29.5)
+	if heatStress.Level != "severe" || heatStress.DisplayName != "厳重警戒" || heatStress.Guidance != "激しい運動は中止" {
+		t.Errorf("Unexpected heat stress: %+v", heatStress)
+	}
+	if penalty := GetHeatStressPenalty(heatStress); penalty != 45 {
+		t.Errorf("Expected penalty 45, got %d", penalty)
+	}
+	if penalty := GetHeatStressPenalty(nil); penalty != 0 {
+		t.Errorf("Expected no penalty without heat stress, got %d", penalty)
+	}
+}
+
+func TestAssessWeatherHeatStress(t *testing.T) {
+	shade := types.TimeBasedWeather{Temperature: 29.0, ApparentTemp: 31.0, Humidity: 60, WindSpeed: 2.0, WBGT: 24.5}
+	sun := shade
+	sun.WBGT = 28.4
+
+	shadeCondition := AssessWeather(shade)
+	sunCondition := AssessWeather(sun)
+
+	if shadeCondition.HeatStress.Level != "caution" || sunCondition.HeatStress.Level != "severe" {
+		t.Fatalf("Unexpected heat stress levels: %s and %s", shadeCondition.HeatStress.Level, sunCondition.HeatStress.Level)
+	}
+	if shadeCondition.Score-sunCondition.Score != 45 {
+		t.Errorf("Expected the severe band to cost 45 points, got %d vs %d", shadeCondition.Score, sunCondition.Score)
+	}
+
+	expectedWarning := "🥵 暑さ指数 厳重警戒 (WBGT 28.4): 激しい運動や長距離走は避けてください"
+	found := false
+	for _, warning := range sunCondition.Warnings {
+		if warning == expectedWarning {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("Expected warning %q, got %v", expectedWarning, sunCondition.Warnings)
+	}
+}
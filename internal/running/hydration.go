@@ -0,0 +1,120 @@
+package running
+
+import (
+	"math"
+	"time"
+
+	"runcast/internal/types"
+)
+
+// Assumptions used when the runner's profile or target is incomplete
+const (
+	DefaultBodyWeight  = 65.0            // kg
+	DefaultRunDuration = time.Hour       // without a distance category
+	DefaultPace        = 6 * time.Minute // per km, without a target pace
+)
+
+// Hydration guidance after the ACSM fluid replacement position stand
+const (
+	fluidReplacementRatio = 0.7  // share of the sweat loss to replace while running
+	maxFluidPerHour       = 800  // ml/h, more is hard to absorb and risks hyponatremia
+	sodiumPerLiterSweat   = 500  // mg of sodium to replace per liter of sweat
+	minSodiumPerHour      = 300  // mg/h
+	maxSodiumPerHour      = 1000 // mg/h
+	heavySweatRate        = 1.2  // L/h from which sodium is advised even for short runs
+	referenceWBGT         = 20.0 // mild conditions a measured sweat rate is assumed to be taken in
+)
+
+// Fueling guidance
+const (
+	shortRunDuration  = 45 * time.Minute  // drinking before and after is enough below this in mild weather
+	sodiumRunDuration = 90 * time.Minute  // sodium is advised from this duration
+	fuelRunDuration   = 75 * time.Minute  // glycogen lasts up to about this duration
+	longFuelDuration  = 150 * time.Minute // gels are taken more often beyond this duration
+	firstGel          = 45 * time.Minute
+	gelInterval       = 45 * time.Minute
+	longGelInterval   = 30 * time.Minute
+	lastGelBeforeEnd  = 15 * time.Minute // a gel this close to the finish does not help
+)
+
+// EstimateRunDuration returns the expected duration of a run in the distance category at the pace per km
+func EstimateRunDuration(pace time.Duration, distanceCategory *types.DistanceCategory) time.Duration {
+	if distanceCategory == nil {
+		return DefaultRunDuration
+	}
+	if pace <= 0 {
+		pace = DefaultPace
+	}
+	return time.Duration(float64(pace) * distanceCategory.RaceKm).Round(time.Minute)
+}
+
+// RunWindowHours returns the number of forecast hours a run of the given duration covers
+func RunWindowHours(duration time.Duration) int {
+	hours := int(math.Ceil(duration.Hours()))
+	if hours < 1 {
+		return 1
+	}
+	return hours
+}
+
+// EstimateSweatRate returns the typical sweat rate (L/h) of a runner of the body weight (kg) at the WBGT
+func EstimateSweatRate(wbgt, bodyWeight float64) float64 {
+	if bodyWeight <= 0 {
+		bodyWeight = DefaultBodyWeight
+	}
+	rate := bodyWeight / 70 * (0.4 + 0.06*math.Max(0, wbgt-10))
+	return math.Min(math.Max(rate, 0.3), 2.5)
+}
+
+// PlanHydration plans fluid, sodium and gels for a run over the forecast hours it covers.
+// A measured sweat rate from the profile is scaled by how much hotter or cooler each hour is than mild conditions.
+func PlanHydration(window []types.TimeBasedWeather, duration time.Duration, profile types.RunnerProfile) *types.HydrationPlan {
+	if len(window) == 0 || duration <= 0 {
+		return nil
+	}
+
+	var sweatRate, peakWBGT float64
+	for _, data := range window {
+		rate := EstimateSweatRate(data.WBGT, profile.BodyWeight)
+		if profile.SweatRate > 0 {
+			rate = profile.SweatRate * rate / EstimateSweatRate(referenceWBGT, profile.BodyWeight)
+		}
+		sweatRate += rate
+		peakWBGT = math.Max(peakWBGT, data.WBGT)
+	}
+	sweatRate /= float64(len(window))
+
+	plan := &types.HydrationPlan{
+		Duration:           duration,
+		SweatRate:          math.Round(sweatRate*10) / 10,
+		SweatRateEstimated: profile.SweatRate <= 0,
+	}
+
+	if duration >= shortRunDuration || peakWBGT >= scoring.Thresholds.WBGTWarning {
+		plan.FluidPerHour = int(math.Min(roundTo(sweatRate*fluidReplacementRatio*1000, 50), maxFluidPerHour))
+		plan.TotalFluid = int(roundTo(float64(plan.FluidPerHour)*duration.Hours(), 50))
+	}
+
+	if duration >= sodiumRunDuration || sweatRate >= heavySweatRate {
+		sodium := roundTo(sweatRate*sodiumPerLiterSweat, 50)
+		plan.SodiumPerHour = int(math.Min(math.Max(sodium, minSodiumPerHour), maxSodiumPerHour))
+	}
+
+	if duration > fuelRunDuration {
+		plan.FirstGel = firstGel
+		plan.GelInterval = gelInterval
+		if duration > longFuelDuration {
+			plan.GelInterval = longGelInterval
+		}
+		for at := plan.FirstGel; at <= duration-lastGelBeforeEnd; at += plan.GelInterval {
+			plan.GelCount++
+		}
+	}
+
+	return plan
+}
+
+// roundTo rounds value to the nearest multiple of step
+func roundTo(value, step float64) float64 {
+	return math.Round(value/step) * step
+}
@@ -0,0 +1,135 @@
+package running
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+func TestEstimateRunDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		pace     time.Duration
+		distance string
+		expected time.Duration
+	}{
+		{name: "No distance", pace: 5 * time.Minute, distance: "", expected: DefaultRunDuration},
+		{name: "10k at target pace", pace: 5 * time.Minute, distance: "10k", expected: 50 * time.Minute},
+		{name: "Half at default pace", pace: 0, distance: "half", expected: 127 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateRunDuration(tt.pace, GetDistanceCategory(tt.distance)); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunWindowHours(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected int
+	}{
+		{0, 1},
+		{30 * time.Minute, 1},
+		{2 * time.Hour, 2},
+		{127 * time.Minute, 3},
+	}
+
+	for _, tt := range tests {
+		if got := RunWindowHours(tt.duration); got != tt.expected {
+			t.Errorf("RunWindowHours(%v) = %d, expected %d", tt.duration, got, tt.expected)
+		}
+	}
+}
+
+func TestEstimateSweatRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		wbgt       float64
+		bodyWeight float64
+		expected   float64
+	}{
+		{name: "Mild", wbgt: 20, bodyWeight: 70, expected: 1.0},
+		{name: "Cool default weight", wbgt: 10, bodyWeight: 0, expected: 0.3714},
+		{name: "Hot", wbgt: 40, bodyWeight: 70, expected: 2.2},
+		{name: "Capped", wbgt: 50, bodyWeight: 100, expected: 2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateSweatRate(tt.wbgt, tt.bodyWeight); math.Abs(got-tt.expected) > 0.0001 {
+				t.Errorf("Expected %.4f, got %.4f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlanHydration(t *testing.T) {
+	tests := []struct {
+		name     string
+		wbgts    []float64
+		duration time.Duration
+		profile  types.RunnerProfile
+		expected types.HydrationPlan
+	}{
+		{
+			name:     "Short cool run",
+			wbgts:    []float64{14},
+			duration: 30 * time.Minute,
+			expected: types.HydrationPlan{SweatRate: 0.6, SweatRateEstimated: true},
+		},
+		{
+			name:     "Short hot run",
+			wbgts:    []float64{26},
+			duration: 30 * time.Minute,
+			expected: types.HydrationPlan{SweatRate: 1.3, SweatRateEstimated: true, FluidPerHour: 800, TotalFluid: 400, SodiumPerHour: 650},
+		},
+		{
+			name:     "Hot half marathon",
+			wbgts:    []float64{28, 29},
+			duration: 2 * time.Hour,
+			profile:  types.RunnerProfile{BodyWeight: 70},
+			expected: types.HydrationPlan{
+				SweatRate: 1.5, SweatRateEstimated: true, FluidPerHour: 800, TotalFluid: 1600, SodiumPerHour: 750,
+				GelCount: 2, FirstGel: 45 * time.Minute, GelInterval: 45 * time.Minute,
+			},
+		},
+		{
+			name:     "Marathon with a measured sweat rate",
+			wbgts:    []float64{20},
+			duration: 4 * time.Hour,
+			profile:  types.RunnerProfile{SweatRate: 0.8},
+			expected: types.HydrationPlan{
+				SweatRate: 0.8, FluidPerHour: 550, TotalFluid: 2200, SodiumPerHour: 400,
+				GelCount: 7, FirstGel: 45 * time.Minute, GelInterval: 30 * time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var window []types.TimeBasedWeather
+			for _, wbgt := range tt.wbgts {
+				window = append(window, types.TimeBasedWeather{WBGT: wbgt})
+			}
+
+			plan := PlanHydration(window, tt.duration, tt.profile)
+			if plan == nil {
+				t.Fatal("Expected a plan")
+			}
+			tt.expected.Duration = tt.duration
+			if *plan != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *plan)
+			}
+		})
+	}
+
+	if PlanHydration(nil, time.Hour, types.RunnerProfile{}) != nil {
+		t.Error("Expected no plan without forecast hours")
+	}
+}
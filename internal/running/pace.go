@@ -0,0 +1,103 @@
+package running
+
+import (
+	"math"
+	"time"
+
+	"runcast/internal/types"
+)
+
+// heatPaceTable maps temperature plus dew point (°F) to the expected slowdown ratio,
+// following the widely used running rule of thumb
+var heatPaceTable = []struct {
+	combinedF float64
+	slowdown  float64
+}{
+	{100, 0},
+	{110, 0.005},
+	{120, 0.01},
+	{130, 0.02},
+	{140, 0.03},
+	{150, 0.045},
+	{160, 0.06},
+	{170, 0.08},
+	{180, 0.10},
+}
+
+// maxHeatSlowdown caps the heat adjustment; hard running is not advised beyond the table
+const maxHeatSlowdown = 0.12
+
+// airResistanceFactor scales the share of running energy spent on air resistance
+// (about 3% at 5 m/s in still air, after Pugh 1971)
+const airResistanceFactor = 0.03 / (5 * 5)
+
+// tailwindBenefit is the fraction of the air resistance saving a tailwind actually gives back
+const tailwindBenefit = 0.5
+
+// GetHeatPaceAdjustment returns the slowdown ratio for the temperature and dew point (°C)
+func GetHeatPaceAdjustment(temp, dewPoint float64) float64 {
+	combinedF := celsiusToFahrenheit(temp) + celsiusToFahrenheit(dewPoint)
+
+	if combinedF <= heatPaceTable[0].combinedF {
+		return 0
+	}
+	for i := 1; i < len(heatPaceTable); i++ {
+		low, high := heatPaceTable[i-1], heatPaceTable[i]
+		if combinedF <= high.combinedF {
+			ratio := (combinedF - low.combinedF) / (high.combinedF - low.combinedF)
+			return low.slowdown + ratio*(high.slowdown-low.slowdown)
+		}
+	}
+
+	// Extrapolate beyond the table with its last slope
+	last, previous := heatPaceTable[len(heatPaceTable)-1], heatPaceTable[len(heatPaceTable)-2]
+	slope := (last.slowdown - previous.slowdown) / (last.combinedF - previous.combinedF)
+	return math.Min(last.slowdown+slope*(combinedF-last.combinedF), maxHeatSlowdown)
+}
+
+// GetWindPaceAdjustment returns the slowdown ratio for a headwind (m/s, negative for a tailwind)
+// at the given running speed (m/s)
+func GetWindPaceAdjustment(headwind, runningSpeed float64) float64 {
+	airSpeed := runningSpeed + headwind
+	adjustment := airResistanceFactor * (airSpeed*math.Abs(airSpeed) - runningSpeed*runningSpeed)
+	if adjustment < 0 {
+		adjustment *= tailwindBenefit
+	}
+	return adjustment
+}
+
+// EstimatePace adjusts the target pace per km for the weather.
+// The run is taken as an out-and-back: half of it against the headwind and half with it behind.
+// Without a route the whole wind speed is taken as the headwind; with one only its component along the route.
+// The finish time is estimated when a distance category is given.
+func EstimatePace(targetPace time.Duration, data types.TimeBasedWeather, distanceCategory *types.DistanceCategory, route *types.Route) *types.PaceEstimate {
+	if targetPace <= 0 {
+		return nil
+	}
+
+	headwind := data.WindSpeed
+	if route != nil {
+		headwind, _ = GetWindComponents(data.WindSpeed, data.WindDirection, route.Bearing)
+	}
+
+	runningSpeed := 1000 / targetPace.Seconds()
+	heat := GetHeatPaceAdjustment(data.Temperature, data.DewPoint)
+	wind := (GetWindPaceAdjustment(headwind, runningSpeed) + GetWindPaceAdjustment(-headwind, runningSpeed)) / 2
+
+	estimate := &types.PaceEstimate{
+		TargetPace:     targetPace,
+		AdjustedPace:   time.Duration(float64(targetPace) * (1 + heat + wind)).Round(time.Second),
+		HeatAdjustment: heat,
+		WindAdjustment: wind,
+	}
+	if distanceCategory != nil {
+		estimate.DistanceKm = distanceCategory.RaceKm
+		estimate.FinishTime = time.Duration(float64(estimate.AdjustedPace) * distanceCategory.RaceKm).Round(time.Second)
+	}
+	return estimate
+}
+
+// celsiusToFahrenheit converts a temperature from °C to °F
+func celsiusToFahrenheit(celsius float64) float64 {
+	return celsius*9/5 + 32
+}
@@ -0,0 +1,77 @@
+package running
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+func TestGetHeatPaceAdjustment(t *testing.T) {
+	tests := []struct {
+		name     string
+		temp     float64
+		dewPoint float64
+		expected float64
+	}{
+		{name: "Cool", temp: 10.0, dewPoint: 5.0, expected: 0},                 // 50°F + 41°F = 91°F
+		{name: "Warm", temp: 20.0, dewPoint: 10.0, expected: 0.009},            // 68°F + 50°F = 118°F
+		{name: "Humid", temp: 25.0, dewPoint: 15.0, expected: 0.026},           // 77°F + 59°F = 136°F
+		{name: "Beyond the table", temp: 40.0, dewPoint: 32.0, expected: 0.12}, // capped
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetHeatPaceAdjustment(tt.temp, tt.dewPoint); math.Abs(got-tt.expected) > 0.0001 {
+				t.Errorf("Expected %.4f, got %.4f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetWindPaceAdjustment(t *testing.T) {
+	speed := 1000.0 / 300 // 5:00/km
+
+	if got := GetWindPaceAdjustment(0, speed); got != 0 {
+		t.Errorf("Expected no adjustment without wind, got %.4f", got)
+	}
+
+	headwind := GetWindPaceAdjustment(5, speed)
+	tailwind := GetWindPaceAdjustment(-5, speed)
+	if headwind <= 0 || tailwind >= 0 {
+		t.Fatalf("Expected a headwind to slow down and a tailwind to help, got %.4f and %.4f", headwind, tailwind)
+	}
+	if -tailwind >= headwind {
+		t.Errorf("A tailwind should help less than a headwind hurts, got %.4f and %.4f", headwind, tailwind)
+	}
+}
+
+func TestEstimatePace(t *testing.T) {
+	data := types.TimeBasedWeather{Temperature: 28.0, DewPoint: 22.0, WindSpeed: 4.0}
+
+	if EstimatePace(0, data, nil, nil) != nil {
+		t.Error("Expected no estimate without a target pace")
+	}
+
+	target := 5 * time.Minute
+	estimate := EstimatePace(target, data, GetDistanceCategory("half"), nil)
+	if estimate.AdjustedPace <= target {
+		t.Errorf("Expected a slower pace in the heat, got %v", estimate.AdjustedPace)
+	}
+	if estimate.HeatAdjustment <= 0 || estimate.WindAdjustment <= 0 {
+		t.Errorf("Expected heat and wind adjustments, got %+v", estimate)
+	}
+	if estimate.DistanceKm != 21.0975 {
+		t.Errorf("Expected the half marathon distance, got %.4f", estimate.DistanceKm)
+	}
+	expectedFinish := time.Duration(float64(estimate.AdjustedPace) * 21.0975).Round(time.Second)
+	if estimate.FinishTime != expectedFinish {
+		t.Errorf("Expected finish time %v, got %v", expectedFinish, estimate.FinishTime)
+	}
+
+	// Without a distance only the pace is estimated
+	if estimate := EstimatePace(target, data, nil, nil); estimate.FinishTime != 0 || estimate.DistanceKm != 0 {
+		t.Errorf("Expected no finish time without a distance, got %+v", estimate)
+	}
+}
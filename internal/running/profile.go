@@ -0,0 +1,99 @@
+package running
+
+import (
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+// Temperature tolerance keys of the runner profile
+const (
+	ToleranceLow    = "low"
+	ToleranceNormal = "normal"
+	ToleranceHigh   = "high"
+)
+
+// coldToleranceShift is how much colder (°C) a runner with a low cold tolerance feels, and warmer with a high one
+const coldToleranceShift = 3.0
+
+// sensitiveAirwaysMultiplier scales the dust and PM2.5 penalties for runners with asthma or a pollen allergy
+const sensitiveAirwaysMultiplier = 1.5
+
+// ApplyProfile tailors the assessment of a run to the runner's tolerance of cold and heat.
+// The coldest hour is assessed as if it were 3°C colder for a low cold tolerance, or warmer for a high one,
+// and the clothing is chosen for that temperature. A low heat tolerance takes half the heat stress penalty
+// again and warns from the caution band, while a high one gives half of it back.
+func ApplyProfile(condition *types.RunningCondition, profile types.RunnerProfile, distanceCategory *types.DistanceCategory) {
+	score := condition.Score
+
+	if shift := toleranceShift(profile.ColdTolerance); shift != 0 && condition.Window != nil {
+		temp := condition.Window.MinTemperature
+		actualPenalty, _, actualClothing := getTemperatureBand(temp)
+		feltPenalty, _, feltClothing := getTemperatureBand(temp - shift)
+		score -= feltPenalty - actualPenalty
+		condition.Clothing = appendUnique(feltClothing, removeItems(condition.Clothing, actualClothing)...)
+		if feltPenalty > actualPenalty {
+			condition.Warnings = append(condition.Warnings, i18n.T("warning.cold_tolerance", temp))
+		}
+	}
+
+	if heatStress := condition.HeatStress; heatStress != nil {
+		switch profile.HeatTolerance {
+		case ToleranceLow:
+			score -= GetHeatStressPenalty(heatStress) / 2
+			if heatStress.Level != "safe" {
+				condition.Warnings = append(condition.Warnings, i18n.T("warning.heat_tolerance", heatStress.WBGT))
+			}
+		case ToleranceHigh:
+			score += GetHeatStressPenalty(heatStress) / 2
+		}
+	}
+
+	if score == condition.Score {
+		return
+	}
+	condition.Score = min(max(score, 0), 100)
+	updateLevel(condition, distanceCategory)
+}
+
+// toleranceShift returns the temperature shift (°C) of a cold tolerance, positive when the runner feels colder
+func toleranceShift(tolerance string) float64 {
+	switch tolerance {
+	case ToleranceLow:
+		return coldToleranceShift
+	case ToleranceHigh:
+		return -coldToleranceShift
+	default:
+		return 0
+	}
+}
+
+// updateLevel sets the level and recommendation of the condition from its score
+func updateLevel(condition *types.RunningCondition, distanceCategory *types.DistanceCategory) {
+	level := GetLevelKey(condition.Score)
+	condition.Level = i18n.T("level." + level)
+	condition.Recommendation = i18n.T("recommendation." + level)
+	if distanceCategory != nil {
+		condition.Recommendation = generateDistanceRecommendation(distanceCategory, level)
+	}
+}
+
+// removeItems returns the list without the given items
+func removeItems(list []string, items []string) []string {
+	var kept []string
+	for _, existing := range list {
+		if !containsString(items, existing) {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
+
+// containsString reports whether the list contains the item
+func containsString(list []string, item string) bool {
+	for _, existing := range list {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package running
+
+import (
+	"testing"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+func TestApplyProfileCold(t *testing.T) {
+	tests := []struct {
+		name          string
+		tolerance     string
+		expectedScore int
+		expectWarning bool
+		expectedItem  string
+	}{
+		// 12°C is cool; felt 3°C colder it is cold and calls for light gloves
+		{"normal", ToleranceNormal, 95, false, "clothing.long_sleeves"},
+		{"low", ToleranceLow, 85, true, "clothing.light_gloves"},
+		// Felt 3°C warmer it is mild enough for thin long sleeves
+		{"high", ToleranceHigh, 100, false, "clothing.thin_long_sleeves"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := AssessRunWindow([]types.TimeBasedWeather{newTimeBasedWeather(12, 12, 50, 2, 0, 1)}, nil)
+			ApplyProfile(&condition, types.RunnerProfile{ColdTolerance: tt.tolerance}, nil)
+
+			if condition.Score != tt.expectedScore {
+				t.Errorf("Expected score %d, got %d", tt.expectedScore, condition.Score)
+			}
+			if warned := containsItem(condition.Warnings, i18n.T("warning.cold_tolerance", 12.0)); warned != tt.expectWarning {
+				t.Errorf("Expected cold tolerance warning %v, got %v", tt.expectWarning, condition.Warnings)
+			}
+			if !containsItem(condition.Clothing, i18n.T(tt.expectedItem)) {
+				t.Errorf("Expected %s in %v", i18n.T(tt.expectedItem), condition.Clothing)
+			}
+		})
+	}
+}
+
+func TestApplyProfileHeat(t *testing.T) {
+	tests := []struct {
+		name          string
+		tolerance     string
+		expectedDelta int
+	}{
+		{"normal", "", 0},
+		{"low", ToleranceLow, -7},
+		{"high", ToleranceHigh, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newTimeBasedWeather(27, 27, 40, 2, 0, 1)
+			data.WBGT = 26 // warning band, 15 points
+			base := AssessRunWindow([]types.TimeBasedWeather{data}, GetDistanceCategory("10k"))
+			condition := base
+			ApplyProfile(&condition, types.RunnerProfile{HeatTolerance: tt.tolerance}, GetDistanceCategory("10k"))
+
+			if condition.Score != base.Score+tt.expectedDelta {
+				t.Errorf("Expected score %d, got %d", base.Score+tt.expectedDelta, condition.Score)
+			}
+			warned := containsItem(condition.Warnings, i18n.T("warning.heat_tolerance", 26.0))
+			if warned != (tt.tolerance == ToleranceLow) {
+				t.Errorf("Unexpected heat tolerance warning: %v", condition.Warnings)
+			}
+		})
+	}
+}
+
+func TestApplyDustPenaltySensitiveAirways(t *testing.T) {
+	dustLevel := &types.DustLevel{Level: 1, Dust: 60, PM2_5: 40}
+
+	condition := types.RunningCondition{Score: 80}
+	ApplyDustPenalty(&condition, dustLevel, nil, types.RunnerProfile{})
+	if condition.Score != 70 || containsItem(condition.Clothing, i18n.T("clothing.sports_mask")) {
+		t.Errorf("Expected 70 without a mask, got %d %v", condition.Score, condition.Clothing)
+	}
+
+	// Light dust and PM2.5 over the standard: (5 + 5) x 1.5
+	sensitive := types.RunningCondition{Score: 80}
+	ApplyDustPenalty(&sensitive, dustLevel, nil, types.RunnerProfile{SensitiveAirways: true})
+	if sensitive.Score != 66 {
+		t.Errorf("Expected score 66, got %d", sensitive.Score)
+	}
+	if !containsItem(sensitive.Clothing, i18n.T("clothing.sports_mask")) {
+		t.Errorf("Expected a mask for sensitive airways, got %v", sensitive.Clothing)
+	}
+	if !containsItem(sensitive.Warnings, i18n.T("warning.sensitive_airways")) {
+		t.Errorf("Expected a sensitive airways warning, got %v", sensitive.Warnings)
+	}
+}
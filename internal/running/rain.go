@@ -0,0 +1,118 @@
+package running
+
+import (
+	"math"
+	"time"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// Dry window confidence thresholds, the chance (%) that the whole window stays dry
+const (
+	dryConfidenceHigh   = 70.0
+	dryConfidenceMedium = 40.0
+)
+
+// IsDryHour reports whether an hour is expected to stay dry
+func IsDryHour(data types.TimeBasedWeather) bool {
+	return data.Precipitation == 0 && data.PrecipitationProbability < scoring.Thresholds.DryProbability
+}
+
+// GetDryConfidenceKey returns the language-independent confidence key for the chance (%) of staying dry
+func GetDryConfidenceKey(dryProbability float64) string {
+	switch {
+	case dryProbability >= dryConfidenceHigh:
+		return "high"
+	case dryProbability >= dryConfidenceMedium:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// getRainPenalty returns the score penalty and the warning key for an amount of precipitation (mm)
+func getRainPenalty(precipitation float64) (int, string) {
+	switch {
+	case precipitation > scoring.Thresholds.RainHeavy:
+		return scoring.Penalties.RainHeavy, "warning.rain_heavy"
+	case precipitation > scoring.Thresholds.Rain:
+		return scoring.Penalties.Rain, "warning.rain"
+	case precipitation > 0:
+		return scoring.Penalties.RainLight, "warning.rain_light"
+	default:
+		return 0, ""
+	}
+}
+
+// getRainChanceWarning returns the localized warning for a likely rain without forecast precipitation,
+// or an empty string when rain is unlikely
+func getRainChanceWarning(probability float64) string {
+	if probability < scoring.Thresholds.RainLikely {
+		return ""
+	}
+	return i18n.T("warning.rain_chance", probability)
+}
+
+// FindDryWindow returns the longest run of consecutive dry hours, each hour covering the hour it starts.
+// LongEnough tells whether a run of the given duration fits into it. It returns nil without any dry hour.
+func FindDryWindow(hours []types.TimeBasedWeather, duration time.Duration) *types.DryWindow {
+	var best *types.DryWindow
+	var current []types.TimeBasedWeather
+	var previous time.Time
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		if best == nil || len(current) > best.Hours || (len(current) == best.Hours && dryProbability(current) > best.DryProbability) {
+			best = newDryWindow(current)
+		}
+		current = nil
+	}
+
+	for _, data := range hours {
+		start, err := time.Parse(weather.ForecastTimeLayout, data.Time)
+		if err != nil || !IsDryHour(data) {
+			flush()
+			continue
+		}
+		// Hours of different days or with gaps in the data do not join
+		if len(current) > 0 && start.Sub(previous) != time.Hour {
+			flush()
+		}
+		current = append(current, data)
+		previous = start
+	}
+	flush()
+
+	if best != nil {
+		best.RunDuration = duration
+		best.LongEnough = time.Duration(best.Hours)*time.Hour >= duration
+	}
+	return best
+}
+
+// newDryWindow builds the window over the consecutive dry hours
+func newDryWindow(hours []types.TimeBasedWeather) *types.DryWindow {
+	last, _ := time.Parse(weather.ForecastTimeLayout, hours[len(hours)-1].Time)
+	probability := dryProbability(hours)
+	return &types.DryWindow{
+		Start:          hours[0].Time,
+		End:            last.Add(time.Hour).Format(weather.ForecastTimeLayout),
+		Hours:          len(hours),
+		DryProbability: probability,
+		Confidence:     GetDryConfidenceKey(probability),
+	}
+}
+
+// dryProbability returns the chance (%) that none of the hours sees rain, treating the hours as independent.
+// This is a conservative estimate since rainy hours tend to cluster.
+func dryProbability(hours []types.TimeBasedWeather) float64 {
+	probability := 1.0
+	for _, data := range hours {
+		probability *= 1 - data.PrecipitationProbability/100
+	}
+	return math.Round(probability * 100)
+}
@@ -0,0 +1,160 @@
+package running
+
+import (
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+// newRainHours returns consecutive hours from 06:00 with the given precipitation probabilities
+func newRainHours(probabilities ...float64) []types.TimeBasedWeather {
+	start := time.Date(2025, 7, 5, 6, 0, 0, 0, time.UTC)
+	hours := make([]types.TimeBasedWeather, len(probabilities))
+	for i, probability := range probabilities {
+		hours[i] = types.TimeBasedWeather{
+			Time:                     start.Add(time.Duration(i) * time.Hour).Format("2006-01-02T15:04"),
+			PrecipitationProbability: probability,
+		}
+	}
+	return hours
+}
+
+func TestIsDryHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     types.TimeBasedWeather
+		expected bool
+	}{
+		{"clear", types.TimeBasedWeather{}, true},
+		{"low chance", types.TimeBasedWeather{PrecipitationProbability: 20}, true},
+		{"likely", types.TimeBasedWeather{PrecipitationProbability: scoring.Thresholds.DryProbability}, false},
+		{"drizzle despite low chance", types.TimeBasedWeather{Precipitation: 0.2, PrecipitationProbability: 10}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsDryHour(tt.data); result != tt.expected {
+				t.Errorf("IsDryHour(%+v) = %v, expected %v", tt.data, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindDryWindow(t *testing.T) {
+	tests := []struct {
+		name           string
+		hours          []types.TimeBasedWeather
+		duration       time.Duration
+		expectedStart  string
+		expectedEnd    string
+		expectedHours  int
+		expectedLong   bool
+		expectedChance float64
+	}{
+		{
+			name:           "whole period dry",
+			hours:          newRainHours(0, 0, 0),
+			duration:       time.Hour,
+			expectedStart:  "2025-07-05T06:00",
+			expectedEnd:    "2025-07-05T09:00",
+			expectedHours:  3,
+			expectedLong:   true,
+			expectedChance: 100,
+		},
+		{
+			name:           "longest of two windows",
+			hours:          newRainHours(10, 80, 10, 20, 0, 50),
+			duration:       2 * time.Hour,
+			expectedStart:  "2025-07-05T08:00",
+			expectedEnd:    "2025-07-05T11:00",
+			expectedHours:  3,
+			expectedLong:   true,
+			expectedChance: 72,
+		},
+		{
+			name:           "too short for the run",
+			hours:          newRainHours(60, 10, 70),
+			duration:       90 * time.Minute,
+			expectedStart:  "2025-07-05T07:00",
+			expectedEnd:    "2025-07-05T08:00",
+			expectedHours:  1,
+			expectedLong:   false,
+			expectedChance: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := FindDryWindow(tt.hours, tt.duration)
+			if window == nil {
+				t.Fatal("Expected a dry window")
+			}
+			if window.Start != tt.expectedStart || window.End != tt.expectedEnd || window.Hours != tt.expectedHours {
+				t.Errorf("Expected %s-%s (%d h), got %s-%s (%d h)",
+					tt.expectedStart, tt.expectedEnd, tt.expectedHours, window.Start, window.End, window.Hours)
+			}
+			if window.LongEnough != tt.expectedLong {
+				t.Errorf("Expected LongEnough %v, got %v", tt.expectedLong, window.LongEnough)
+			}
+			if window.DryProbability != tt.expectedChance {
+				t.Errorf("Expected dry probability %.0f%%, got %.0f%%", tt.expectedChance, window.DryProbability)
+			}
+			if window.RunDuration != tt.duration {
+				t.Errorf("Expected run duration %v, got %v", tt.duration, window.RunDuration)
+			}
+		})
+	}
+
+	if window := FindDryWindow(newRainHours(50, 90), time.Hour); window != nil {
+		t.Errorf("Expected no dry window, got %+v", window)
+	}
+
+	// Hours of different days do not join into one window
+	hours := newRainHours(0, 0)
+	hours = append(hours, types.TimeBasedWeather{Time: "2025-07-06T06:00"})
+	if window := FindDryWindow(hours, time.Hour); window == nil || window.Hours != 2 {
+		t.Errorf("Expected a two hour window, got %+v", window)
+	}
+}
+
+func TestGetDryConfidenceKey(t *testing.T) {
+	tests := []struct {
+		dryProbability float64
+		expected       string
+	}{
+		{100, "high"},
+		{70, "high"},
+		{69, "medium"},
+		{40, "medium"},
+		{39, "low"},
+	}
+
+	for _, tt := range tests {
+		if result := GetDryConfidenceKey(tt.dryProbability); result != tt.expected {
+			t.Errorf("GetDryConfidenceKey(%.0f) = %s, expected %s", tt.dryProbability, result, tt.expected)
+		}
+	}
+}
+
+func TestAssessWeatherRainChance(t *testing.T) {
+	data := newTimeBasedWeather(18, 18, 50, 2, 0, 1)
+	baseline := AssessWeather(data)
+
+	data.PrecipitationProbability = 80
+	condition := AssessWeather(data)
+	if condition.Score != baseline.Score-scoring.Penalties.RainChance {
+		t.Errorf("Expected a %d point penalty for a likely rain, got %d -> %d", scoring.Penalties.RainChance, baseline.Score, condition.Score)
+	}
+	if len(condition.Warnings) != len(baseline.Warnings)+1 {
+		t.Errorf("Expected a rain chance warning, got %v", condition.Warnings)
+	}
+
+	// Forecast precipitation already carries its own penalty
+	data.Precipitation = 2
+	rainy := AssessWeather(data)
+	data.PrecipitationProbability = 0
+	if rainy.Score != AssessWeather(data).Score {
+		t.Error("Expected no extra penalty on top of forecast precipitation")
+	}
+}
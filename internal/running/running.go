@@ -1,60 +1,60 @@
 package running
 
 import (
+	"math"
+
+	"runcast/internal/i18n"
 	"runcast/internal/types"
+	"runcast/internal/weather"
 )
 
 // DistanceCategory is an alias for types.DistanceCategory for backward compatibility
 type DistanceCategory = types.DistanceCategory
 
-// GetDistanceCategories returns all available distance categories
+// GetDistanceCategories returns all available distance categories with the penalties of the scoring in use
 func GetDistanceCategories() []types.DistanceCategory {
-	return []types.DistanceCategory{
+	categories := []types.DistanceCategory{
 		{
-			Key:             "5k",
-			DisplayName:     "5キロ",
-			Description:     "短距離ランニング - 比較的軽い負荷",
-			MinKm:           3.0,
-			MaxKm:           7.0,
-			TempPenalty:     0,
-			HumidityPenalty: 0,
-			WindPenalty:     0,
-			HeatIndexPenalty: 0,
+			Key:         "5k",
+			DisplayName: i18n.T("distance.5k.name"),
+			Description: i18n.T("distance.5k.desc"),
+			MinKm:       3.0,
+			MaxKm:       7.0,
+			RaceKm:      5.0,
 		},
 		{
-			Key:             "10k",
-			DisplayName:     "10キロ",
-			Description:     "中距離ランニング - 中程度の負荷",
-			MinKm:           8.0,
-			MaxKm:           12.0,
-			TempPenalty:     3,
-			HumidityPenalty: 2,
-			WindPenalty:     1,
-			HeatIndexPenalty: 5,
+			Key:         "10k",
+			DisplayName: i18n.T("distance.10k.name"),
+			Description: i18n.T("distance.10k.desc"),
+			MinKm:       8.0,
+			MaxKm:       12.0,
+			RaceKm:      10.0,
 		},
 		{
-			Key:             "half",
-			DisplayName:     "ハーフマラソン",
-			Description:     "長距離ランニング - 高い負荷",
-			MinKm:           19.0,
-			MaxKm:           23.0,
-			TempPenalty:     7,
-			HumidityPenalty: 5,
-			WindPenalty:     3,
-			HeatIndexPenalty: 10,
+			Key:         "half",
+			DisplayName: i18n.T("distance.half.name"),
+			Description: i18n.T("distance.half.desc"),
+			MinKm:       19.0,
+			MaxKm:       23.0,
+			RaceKm:      21.0975,
 		},
 		{
-			Key:             "full",
-			DisplayName:     "フルマラソン",
-			Description:     "超長距離ランニング - 非常に高い負荷",
-			MinKm:           40.0,
-			MaxKm:           44.0,
-			TempPenalty:     15,
-			HumidityPenalty: 10,
-			WindPenalty:     5,
-			HeatIndexPenalty: 20,
+			Key:         "full",
+			DisplayName: i18n.T("distance.full.name"),
+			Description: i18n.T("distance.full.desc"),
+			MinKm:       40.0,
+			MaxKm:       44.0,
+			RaceKm:      42.195,
 		},
 	}
+	for i, category := range categories {
+		penalties := scoring.Distances[category.Key]
+		categories[i].TempPenalty = penalties.Temperature
+		categories[i].HumidityPenalty = penalties.Humidity
+		categories[i].WindPenalty = penalties.Wind
+		categories[i].HeatIndexPenalty = penalties.HeatIndex
+	}
+	return categories
 }
 
 // GetDistanceCategory returns distance category by key
@@ -68,221 +68,247 @@ func GetDistanceCategory(distance string) *types.DistanceCategory {
 	return nil
 }
 
-// AssessRunningCondition evaluates running conditions
+// AssessRunningCondition evaluates running conditions.
+// Without solar radiation data the WBGT is estimated for the shade.
 func AssessRunningCondition(temp, apparentTemp, humidity float64, windSpeed, precipitation float64, weatherCode int) types.RunningCondition {
+	return AssessWeather(newTimeBasedWeather(temp, apparentTemp, humidity, windSpeed, precipitation, weatherCode))
+}
+
+// newTimeBasedWeather builds weather data from the positional assessment arguments
+func newTimeBasedWeather(temp, apparentTemp, humidity float64, windSpeed, precipitation float64, weatherCode int) types.TimeBasedWeather {
+	return types.TimeBasedWeather{
+		Temperature:   temp,
+		ApparentTemp:  apparentTemp,
+		Humidity:      int(math.Round(humidity)),
+		WindSpeed:     windSpeed,
+		Precipitation: precipitation,
+		WeatherCode:   weatherCode,
+		DewPoint:      weather.CalculateDewPoint(temp, humidity),
+		WBGT:          weather.EstimateWBGT(temp, humidity, windSpeed, 0),
+	}
+}
+
+// AssessWeather evaluates running conditions for one hour or day of weather data
+func AssessWeather(data types.TimeBasedWeather) types.RunningCondition {
 	score := 100
 	var warnings []string
-	var clothing []string
-	
+
+	temp := data.Temperature
+	windSpeed := data.WindSpeed
+	precipitation := data.Precipitation
+	weatherCode := data.WeatherCode
+
 	// Temperature assessment
-	if temp < 5 {
-		score -= 30
-		warnings = append(warnings, "🥶 低温注意: 防寒対策を十分に行ってください")
-		clothing = append(clothing, "長袖", "ロングパンツ", "手袋", "帽子")
-	} else if temp < 10 {
-		score -= 15
-		warnings = append(warnings, "🌡️ 寒冷注意: 適切な服装で体温調節してください")
-		clothing = append(clothing, "長袖", "ロングパンツ", "軽い手袋")
-	} else if temp < 15 {
-		score -= 5
-		clothing = append(clothing, "長袖", "ロングパンツ")
-	} else if temp < 20 {
-		clothing = append(clothing, "薄手の長袖", "ショートパンツ")
-	} else if temp < 25 {
-		clothing = append(clothing, "薄手の半袖", "ショートパンツ")
-	} else if temp < 30 {
-		clothing = append(clothing, "薄手の半袖", "帽子推奨")
-	} else {
-		score -= 20
-		warnings = append(warnings, "🔥 高温注意: 早朝や夕方の涼しい時間帯を推奨")
-		clothing = append(clothing, "薄手の半袖", "帽子必須", "サングラス")
-	}
-	
-	// Apparent temperature (heat index) assessment
-	if apparentTemp > 35 {
-		score -= 30
-		warnings = append(warnings, "⚠️ 熱中症注意: 体感温度が高すぎます")
-	} else if apparentTemp > 32 {
-		score -= 15
-		warnings = append(warnings, "⚠️ 熱中症注意: 体感温度が高すぎます")
-	}
-	
-	// Humidity assessment
-	if humidity > 85 {
-		score -= 20
-		warnings = append(warnings, "💧 高湿度: 汗が乾きにくい状態です")
-	} else if humidity > 70 {
-		score -= 10
-		warnings = append(warnings, "💧 高湿度: 汗が乾きにくい状態です")
-	}
-	
+	temperaturePenalty, warningKey, clothing := getTemperatureBand(temp)
+	score -= temperaturePenalty
+	if warningKey != "" {
+		warnings = append(warnings, i18n.T(warningKey))
+	}
+
+	// Heat stress assessment based on the WBGT bands
+	heatStress := NewHeatStress(data.WBGT)
+	heatPenalty := GetHeatStressPenalty(heatStress)
+	score -= heatPenalty
+	if warning := getHeatStressWarning(heatStress); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	// UV assessment based on the WHO bands. The penalty and warning only apply around midday,
+	// so that neither an early run nor a whole day is marked down for the day's peak.
+	if weather.IsMiddayTime(data.Time) {
+		score -= GetUVPenalty(data.UVIndex)
+		if warning := getUVWarning(data.UVIndex); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	clothing = appendUnique(clothing, getUVClothing(data.UVIndex)...)
+
+	// Humidity assessment based on the dew point comfort band.
+	// WBGT already accounts for humidity, so only the excess over the heat penalty counts.
+	if humidityPenalty := GetDewPointPenalty(data.DewPoint); humidityPenalty > heatPenalty {
+		score -= humidityPenalty - heatPenalty
+	}
+	if data.DewPoint >= scoring.Thresholds.DewPointDangerous {
+		warnings = append(warnings, i18n.T("warning.dew_point", data.DewPoint))
+	} else if data.DewPoint >= scoring.Thresholds.DewPointMuggy {
+		warnings = append(warnings, i18n.T("warning.humidity"))
+	}
+
 	// Wind assessment
-	if windSpeed > 10 {
-		score -= 25
-		warnings = append(warnings, "💨 強風注意: 転倒や怪我のリスクがあります")
-	} else if windSpeed > 7 {
-		score -= 10
-		warnings = append(warnings, "💨 風が強め: 注意してランニングしてください")
-	}
-	
+	windPenalty := GetWindPenalty(windSpeed)
+	score -= windPenalty
+	if windSpeed > scoring.Thresholds.WindStrong {
+		warnings = append(warnings, i18n.T("warning.wind_strong"))
+	} else if windSpeed > scoring.Thresholds.Wind {
+		warnings = append(warnings, i18n.T("warning.wind"))
+	}
+
+	// Gusts only count where they are worse than the sustained wind
+	if gustPenalty := GetGustPenalty(data.WindGusts); gustPenalty > windPenalty {
+		score -= gustPenalty - windPenalty
+	}
+	if warning := getGustWarning(data.WindGusts); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
 	// Precipitation assessment
-	if precipitation > 5 {
-		score -= 40
-		warnings = append(warnings, "☔ 大雨: ランニングは控えることをお勧めします")
-	} else if precipitation > 1 {
-		score -= 25
-		warnings = append(warnings, "🌧️ 雨: 滑りやすい路面に注意してください")
-	} else if precipitation > 0 {
-		score -= 10
-		warnings = append(warnings, "🌦️ 小雨: 軽い雨具があると良いでしょう")
-	}
-	
+	if penalty, warningKey := getRainPenalty(precipitation); penalty > 0 {
+		score -= penalty
+		warnings = append(warnings, i18n.T(warningKey))
+	} else if warning := getRainChanceWarning(data.PrecipitationProbability); warning != "" {
+		score -= scoring.Penalties.RainChance
+		warnings = append(warnings, warning)
+	}
+
 	// Weather code assessment
 	if weatherCode >= 95 {
-		score -= 50
-		warnings = append(warnings, "⚡ 雷雨: 絶対に屋外でのランニングは避けてください")
+		score -= scoring.Penalties.Thunderstorm
+		warnings = append(warnings, i18n.T("warning.thunderstorm"))
 	} else if weatherCode >= 80 {
-		score -= 30
-		warnings = append(warnings, "🌧️ にわか雨: 突然の雨に注意してください")
+		score -= scoring.Penalties.Showers
+		warnings = append(warnings, i18n.T("warning.showers"))
 	}
-	
+
 	// Ensure score doesn't go below 0
 	if score < 0 {
 		score = 0
 	}
-	
+
 	// Determine level and recommendation
-	var level, recommendation string
-	switch {
-	case score >= 80:
-		level = "最高"
-		recommendation = "ランニングに最適な天候です！"
-	case score >= 60:
-		level = "良好"
-		recommendation = "良好な天候です。ランニングを楽しんでください"
-	case score >= 40:
-		level = "普通"
-		recommendation = "注意事項を確認してからランニングしてください"
-	case score >= 20:
-		level = "注意"
-		recommendation = "警告事項があります。ランニングは控えめに"
-	default:
-		level = "危険"
-		recommendation = "天候が悪いため、ランニングは控えることをお勧めします"
-	}
-	
+	level := GetLevelKey(score)
+
 	return types.RunningCondition{
 		Score:          score,
-		Level:          level,
-		Recommendation: recommendation,
+		Level:          i18n.T("level." + level),
+		Recommendation: i18n.T("recommendation." + level),
 		Warnings:       warnings,
 		Clothing:       clothing,
+		HeatStress:     heatStress,
+	}
+}
+
+// getTemperatureBand returns the score penalty, the warning key and the clothing for a temperature (°C)
+func getTemperatureBand(temp float64) (int, string, []string) {
+	switch {
+	case temp < scoring.Thresholds.ColdSevere:
+		return scoring.Penalties.ColdSevere, "warning.cold_severe", []string{i18n.T("clothing.long_sleeves"), i18n.T("clothing.long_pants"), i18n.T("clothing.gloves"), i18n.T("clothing.hat")}
+	case temp < scoring.Thresholds.Cold:
+		return scoring.Penalties.Cold, "warning.cold", []string{i18n.T("clothing.long_sleeves"), i18n.T("clothing.long_pants"), i18n.T("clothing.light_gloves")}
+	case temp < scoring.Thresholds.Cool:
+		return scoring.Penalties.Cool, "", []string{i18n.T("clothing.long_sleeves"), i18n.T("clothing.long_pants")}
+	case temp < scoring.Thresholds.Mild:
+		return 0, "", []string{i18n.T("clothing.thin_long_sleeves"), i18n.T("clothing.shorts")}
+	case temp < scoring.Thresholds.Warm:
+		return 0, "", []string{i18n.T("clothing.thin_short_sleeves"), i18n.T("clothing.shorts")}
+	case temp < scoring.Thresholds.Summer:
+		return 0, "", []string{i18n.T("clothing.thin_short_sleeves"), i18n.T("clothing.hat_recommended")}
+	default:
+		return 0, "", []string{i18n.T("clothing.thin_short_sleeves"), i18n.T("clothing.hat_required"), i18n.T("clothing.sunglasses")}
+	}
+}
+
+// GetLevelKey returns a language-independent key for the level of the given score
+func GetLevelKey(score int) string {
+	switch {
+	case score >= scoring.Levels.Excellent:
+		return "excellent"
+	case score >= scoring.Levels.Good:
+		return "good"
+	case score >= scoring.Levels.Fair:
+		return "fair"
+	case score >= scoring.Levels.Caution:
+		return "caution"
+	default:
+		return "danger"
 	}
 }
 
 // AssessDistanceBasedRunningCondition evaluates running conditions with distance-specific penalties
 func AssessDistanceBasedRunningCondition(temp, apparentTemp, humidity float64, windSpeed, precipitation float64, weatherCode int, distanceCategory *types.DistanceCategory) types.RunningCondition {
+	return AssessDistanceBasedWeather(newTimeBasedWeather(temp, apparentTemp, humidity, windSpeed, precipitation, weatherCode), distanceCategory)
+}
+
+// AssessDistanceBasedWeather evaluates weather data with distance-specific penalties
+func AssessDistanceBasedWeather(data types.TimeBasedWeather, distanceCategory *types.DistanceCategory) types.RunningCondition {
 	// Start with base assessment
-	condition := AssessRunningCondition(temp, apparentTemp, humidity, windSpeed, precipitation, weatherCode)
-	
+	condition := AssessWeather(data)
+
 	if distanceCategory == nil {
 		return condition
 	}
-	
+
+	temp := data.Temperature
+	dewPoint := data.DewPoint
+
 	// Apply distance-specific penalties
 	condition.Score -= distanceCategory.TempPenalty
 	condition.Score -= distanceCategory.HumidityPenalty
 	condition.Score -= distanceCategory.WindPenalty
 	condition.Score -= distanceCategory.HeatIndexPenalty
-	
+
 	// Distance-specific temperature penalties
-	if temp > 28 {
+	if temp > scoring.Thresholds.Hot {
 		condition.Score -= distanceCategory.TempPenalty
 	}
-	if temp > 32 {
+	if temp > scoring.Thresholds.VeryHot {
 		condition.Score -= distanceCategory.TempPenalty * 2
 	}
-	
-	// Distance-specific humidity penalties
-	if humidity > 80 {
+
+	// Distance-specific humidity penalties from the dew point
+	if dewPoint >= scoring.Thresholds.DewPointOppressive {
 		condition.Score -= distanceCategory.HumidityPenalty
 	}
-	if humidity > 90 {
+	if dewPoint >= scoring.Thresholds.DewPointDangerous {
 		condition.Score -= distanceCategory.HumidityPenalty * 2
 	}
-	
-	// Distance-specific heat index penalties
-	if apparentTemp > 30 {
+
+	// Distance-specific heat stress penalties from the WBGT bands
+	if condition.HeatStress.WBGT >= scoring.Thresholds.WBGTWarning {
 		condition.Score -= distanceCategory.HeatIndexPenalty
 	}
-	if apparentTemp > 35 {
+	if condition.HeatStress.WBGT >= scoring.Thresholds.WBGTSevere {
 		condition.Score -= distanceCategory.HeatIndexPenalty * 2
 	}
-	
+
 	// Add distance-specific warnings
 	if distanceCategory.Key == "half" || distanceCategory.Key == "full" {
-		if temp > 25 {
-			condition.Warnings = append(condition.Warnings, "🏃‍♂️ 長距離警告: 高温下での長時間運動は危険です")
+		if temp > scoring.Thresholds.LongRunHot {
+			condition.Warnings = append(condition.Warnings, i18n.T("warning.long_heat"))
 		}
-		if humidity > 70 {
-			condition.Warnings = append(condition.Warnings, "💦 長距離警告: 高湿度により脱水リスクが高まります")
+		if dewPoint >= scoring.Thresholds.DewPointMuggy {
+			condition.Warnings = append(condition.Warnings, i18n.T("warning.long_humidity"))
 		}
-		if distanceCategory.Key == "full" && temp > 22 {
-			condition.Warnings = append(condition.Warnings, "🏃‍♂️ フルマラソン警告: 高温下での長時間運動は危険です")
+		if distanceCategory.Key == "full" && temp > scoring.Thresholds.MarathonHot {
+			condition.Warnings = append(condition.Warnings, i18n.T("warning.full_heat"))
 		}
 	}
-	
+
 	// Add distance-specific clothing recommendations
 	if distanceCategory.Key == "half" || distanceCategory.Key == "full" {
-		if temp > 20 {
-			condition.Clothing = append(condition.Clothing, "水分補給用品", "エネルギー補給品")
+		if temp > scoring.Thresholds.LongRunFuel {
+			condition.Clothing = append(condition.Clothing, i18n.T("clothing.hydration"), i18n.T("clothing.energy"))
 		}
-		if temp > 25 {
-			condition.Clothing = append(condition.Clothing, "冷却タオル", "塩分補給品")
+		if temp > scoring.Thresholds.LongRunHot {
+			condition.Clothing = append(condition.Clothing, i18n.T("clothing.cooling_towel"), i18n.T("clothing.salt"))
 		}
 	}
-	
+
 	// Ensure score doesn't go below 0
 	if condition.Score < 0 {
 		condition.Score = 0
 	}
-	
+
 	// Update level and recommendation based on new score
-	switch {
-	case condition.Score >= 80:
-		condition.Level = "最高"
-		condition.Recommendation = generateDistanceRecommendation(distanceCategory, "最高")
-	case condition.Score >= 60:
-		condition.Level = "良好"
-		condition.Recommendation = generateDistanceRecommendation(distanceCategory, "良好")
-	case condition.Score >= 40:
-		condition.Level = "普通"
-		condition.Recommendation = generateDistanceRecommendation(distanceCategory, "普通")
-	case condition.Score >= 20:
-		condition.Level = "注意"
-		condition.Recommendation = generateDistanceRecommendation(distanceCategory, "注意")
-	default:
-		condition.Level = "危険"
-		condition.Recommendation = generateDistanceRecommendation(distanceCategory, "危険")
-	}
-	
+	level := GetLevelKey(condition.Score)
+	condition.Level = i18n.T("level." + level)
+	condition.Recommendation = generateDistanceRecommendation(distanceCategory, level)
+
 	return condition
 }
 
-// generateDistanceRecommendation generates distance-specific recommendations
+// generateDistanceRecommendation generates distance-specific recommendations for a level key
 func generateDistanceRecommendation(distanceCategory *types.DistanceCategory, level string) string {
-	switch level {
-	case "最高":
-		return distanceCategory.DisplayName + "実行に最適な天候です！"
-	case "良好":
-		return distanceCategory.DisplayName + "実行に良好な天候です"
-	case "普通":
-		return distanceCategory.DisplayName + "実行は慎重に、体調と相談して判断してください"
-	case "注意":
-		return distanceCategory.DisplayName + "実行は控えめに、短縮も検討してください"
-	default:
-		return distanceCategory.DisplayName + "実行は控えることをお勧めします"
-	}
+	return i18n.T("recommendation.distance."+level, distanceCategory.DisplayName)
 }
 
 // GetDustPenalty calculates dust penalty for running score
@@ -293,13 +319,13 @@ func GetDustPenalty(dustLevel *types.DustLevel) int {
 
 	switch dustLevel.Level {
 	case 1:
-		return 5
+		return scoring.Penalties.DustLow
 	case 2:
-		return 15
+		return scoring.Penalties.DustModerate
 	case 3:
-		return 30
+		return scoring.Penalties.DustHigh
 	case 4:
-		return 50
+		return scoring.Penalties.DustVeryHigh
 	default:
 		return 0
 	}
@@ -324,25 +350,27 @@ func GetDistanceDustMultiplier(distanceCategory *types.DistanceCategory) float64
 }
 
 // GetPM25Penalty calculates PM2.5 penalty for running score
-// Based on Japan's environmental standards:
+// Based on Japan's environmental standards, by default:
 // - 35 μg/m³ or below: Good (environmental standard)
 // - 36-50 μg/m³: Slightly elevated
 // - 51-70 μg/m³: High (caution)
 // - 71+ μg/m³: Very high (alert level)
 func GetPM25Penalty(pm25 float64) int {
-	if pm25 <= 35 {
+	thresholds := scoring.Thresholds
+	if pm25 <= thresholds.PM25Standard {
 		return 0
-	} else if pm25 <= 50 {
-		return 5
-	} else if pm25 <= 70 {
-		return 15
+	} else if pm25 <= thresholds.PM25High {
+		return scoring.Penalties.PM25Elevated
+	} else if pm25 <= thresholds.PM25Alert {
+		return scoring.Penalties.PM25High
 	} else {
-		return 30
+		return scoring.Penalties.PM25Alert
 	}
 }
 
-// ApplyDustPenalty applies dust and PM2.5 penalty to running condition
-func ApplyDustPenalty(condition *types.RunningCondition, dustLevel *types.DustLevel, distanceCategory *types.DistanceCategory) {
+// ApplyDustPenalty applies dust and PM2.5 penalty to running condition.
+// Runners with sensitive airways are penalized more and told to wear a mask sooner.
+func ApplyDustPenalty(condition *types.RunningCondition, dustLevel *types.DustLevel, distanceCategory *types.DistanceCategory, profile types.RunnerProfile) {
 	if dustLevel == nil {
 		return
 	}
@@ -350,6 +378,9 @@ func ApplyDustPenalty(condition *types.RunningCondition, dustLevel *types.DustLe
 	// Calculate dust penalty
 	basePenalty := GetDustPenalty(dustLevel)
 	multiplier := GetDistanceDustMultiplier(distanceCategory)
+	if profile.SensitiveAirways {
+		multiplier *= sensitiveAirwaysMultiplier
+	}
 	dustPenalty := int(float64(basePenalty) * multiplier)
 
 	// Calculate PM2.5 penalty
@@ -364,49 +395,43 @@ func ApplyDustPenalty(condition *types.RunningCondition, dustLevel *types.DustLe
 
 	// Add dust-related warnings
 	if dustLevel.Level >= 2 {
-		condition.Warnings = append(condition.Warnings, "🌫️ 黄砂が飛来しています。マスク着用を推奨します")
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.dust_mask"))
 	}
 	if dustLevel.Level >= 3 {
-		condition.Warnings = append(condition.Warnings, "🌫️ 呼吸器系に不安がある方は屋内トレーニングを検討してください")
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.dust_breathing"))
 	}
 	if dustLevel.Level >= 4 {
-		condition.Warnings = append(condition.Warnings, "⚠️ 黄砂が非常に多いため、屋外でのランニングは避けてください")
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.dust_severe"))
 	}
 
 	// Add PM2.5-related warnings based on Japan's environmental standards
-	if dustLevel.PM2_5 > 70 {
-		condition.Warnings = append(condition.Warnings, "⚠️ PM2.5が注意喚起レベル(70μg/m³超)です。屋外での激しい運動は避けてください")
-	} else if dustLevel.PM2_5 > 50 {
-		condition.Warnings = append(condition.Warnings, "😷 PM2.5が高め(50μg/m³超)です。長時間の屋外運動に注意してください")
-	} else if dustLevel.PM2_5 > 35 {
-		condition.Warnings = append(condition.Warnings, "😷 PM2.5が環境基準(35μg/m³)を超えています。敏感な方は注意してください")
+	thresholds := scoring.Thresholds
+	if dustLevel.PM2_5 > thresholds.PM25Alert {
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.pm25_alert", thresholds.PM25Alert))
+	} else if dustLevel.PM2_5 > thresholds.PM25High {
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.pm25_high", thresholds.PM25High))
+	} else if dustLevel.PM2_5 > thresholds.PM25Standard {
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.pm25_over_limit", thresholds.PM25Standard))
+	}
+
+	if profile.SensitiveAirways && totalPenalty > 0 {
+		condition.Warnings = append(condition.Warnings, i18n.T("warning.sensitive_airways"))
 	}
 
 	// Add clothing recommendations for air quality
-	needsMask := dustLevel.Level >= 2 || dustLevel.PM2_5 > 50
+	needsMask := dustLevel.Level >= 2 || dustLevel.PM2_5 > thresholds.PM25High
+	if profile.SensitiveAirways {
+		needsMask = dustLevel.Level >= 1 || dustLevel.PM2_5 > thresholds.PM25Standard
+	}
 	if needsMask {
-		condition.Clothing = append(condition.Clothing, "スポーツマスク")
+		condition.Clothing = append(condition.Clothing, i18n.T("clothing.sports_mask"))
 	}
 	if dustLevel.Level >= 3 {
-		condition.Clothing = append(condition.Clothing, "サングラス（目の保護）")
+		condition.Clothing = append(condition.Clothing, i18n.T("clothing.eye_protection"))
 	}
 
 	// Update level and recommendation based on new score
-	switch {
-	case condition.Score >= 80:
-		condition.Level = "最高"
-		condition.Recommendation = "ランニングに最適な天候です！"
-	case condition.Score >= 60:
-		condition.Level = "良好"
-		condition.Recommendation = "良好な天候です。ランニングを楽しんでください"
-	case condition.Score >= 40:
-		condition.Level = "普通"
-		condition.Recommendation = "注意事項を確認してからランニングしてください"
-	case condition.Score >= 20:
-		condition.Level = "注意"
-		condition.Recommendation = "警告事項があります。ランニングは控えめに"
-	default:
-		condition.Level = "危険"
-		condition.Recommendation = "天候が悪いため、ランニングは控えることをお勧めします"
-	}
-}
\ No newline at end of file
+	level := GetLevelKey(condition.Score)
+	condition.Level = i18n.T("level." + level)
+	condition.Recommendation = i18n.T("recommendation." + level)
+}
@@ -1,6 +1,7 @@
 package running
 
 import (
+	"runcast/internal/i18n"
 	"runcast/internal/types"
 	"testing"
 )
@@ -351,7 +352,7 @@ func TestApplyDustPenalty(t *testing.T) {
 		PM2_5:       35,
 	}
 
-	ApplyDustPenalty(&condition, dustLevel, nil)
+	ApplyDustPenalty(&condition, dustLevel, nil, types.RunnerProfile{})
 
 	// Score should be reduced by 15 (level 2 penalty)
 	if condition.Score != 65 {
@@ -460,7 +461,7 @@ func TestApplyDustPenaltyWithPM25Warning(t *testing.T) {
 		PM2_5:       55, // Above 50, should trigger warning
 	}
 
-	ApplyDustPenalty(&condition, dustLevel, nil)
+	ApplyDustPenalty(&condition, dustLevel, nil, types.RunnerProfile{})
 
 	// Score should be reduced by PM2.5 penalty (15)
 	if condition.Score != 85 {
@@ -511,7 +512,7 @@ func TestApplyDustPenaltyWithAlertLevelPM25(t *testing.T) {
 		PM2_5:       75, // Above 70, alert level
 	}
 
-	ApplyDustPenalty(&condition, dustLevel, nil)
+	ApplyDustPenalty(&condition, dustLevel, nil, types.RunnerProfile{})
 
 	// Score should be reduced by PM2.5 penalty (30)
 	if condition.Score != 70 {
@@ -551,7 +552,7 @@ func TestApplyDustPenaltyWithDistance(t *testing.T) {
 	}
 
 	categoryFull := GetDistanceCategory("full")
-	ApplyDustPenalty(&condition, dustLevel, categoryFull)
+	ApplyDustPenalty(&condition, dustLevel, categoryFull, types.RunnerProfile{})
 
 	// Score should be reduced by dust penalty only: 30 * 2.0 = 60
 	if condition.Score != 40 {
@@ -584,4 +585,46 @@ func TestApplyDustPenaltyWithDistance(t *testing.T) {
 	if !hasMask || !hasSunglasses {
 		t.Errorf("Expected sports mask and sunglasses in clothing")
 	}
-}
\ No newline at end of file
+}
+
+func TestGetLevelKey(t *testing.T) {
+	tests := []struct {
+		score    int
+		expected string
+	}{
+		{100, "excellent"},
+		{80, "excellent"},
+		{79, "good"},
+		{60, "good"},
+		{59, "fair"},
+		{40, "fair"},
+		{39, "caution"},
+		{20, "caution"},
+		{19, "danger"},
+		{0, "danger"},
+	}
+
+	for _, tt := range tests {
+		if result := GetLevelKey(tt.score); result != tt.expected {
+			t.Errorf("Expected %s for score %d, got %s", tt.expected, tt.score, result)
+		}
+	}
+}
+
+func TestAssessDistanceBasedRunningConditionInEnglish(t *testing.T) {
+	i18n.SetLanguage("en")
+	defer i18n.SetLanguage(i18n.DefaultLanguage)
+
+	distance := GetDistanceCategory("5k")
+	condition := AssessDistanceBasedRunningCondition(15.0, 15.0, 50, 2.0, 0, 0, distance)
+
+	if condition.Level != "Excellent" {
+		t.Errorf("Expected level Excellent, got %s", condition.Level)
+	}
+	if condition.Recommendation != "Perfect weather for a 5K!" {
+		t.Errorf("Unexpected recommendation: %s", condition.Recommendation)
+	}
+	if len(condition.Clothing) == 0 || condition.Clothing[0] != "Light long sleeves" {
+		t.Errorf("Expected English clothing, got %v", condition.Clothing)
+	}
+}
@@ -0,0 +1,18 @@
+package running
+
+import (
+	"runcast/internal/types"
+)
+
+// scoring holds the thresholds, penalties and level cutoffs used by the assessments
+var scoring = types.DefaultScoring()
+
+// SetScoring replaces the built-in scoring, such as with the [scoring] section of the config file
+func SetScoring(s types.Scoring) {
+	scoring = s
+}
+
+// GetScoring returns the scoring in use
+func GetScoring() types.Scoring {
+	return scoring
+}
@@ -0,0 +1,102 @@
+package running
+
+import (
+	"testing"
+
+	"runcast/internal/types"
+)
+
+func TestSetScoring(t *testing.T) {
+	defer SetScoring(types.DefaultScoring())
+
+	// A cold-loving runner does not mind 8°C and calls 70 excellent
+	scoring := types.DefaultScoring()
+	scoring.Thresholds.ColdSevere = 0
+	scoring.Thresholds.Cold = 5
+	scoring.Thresholds.Cool = 8
+	scoring.Levels.Excellent = 70
+	scoring.Distances["10k"] = types.DistancePenalties{}
+	SetScoring(scoring)
+
+	condition := AssessRunningCondition(8, 8, 50, 2, 0, 1)
+	if condition.Score != 100 {
+		t.Errorf("Expected 8°C to cost nothing, got %d", condition.Score)
+	}
+	if key := GetLevelKey(70); key != "excellent" {
+		t.Errorf("Expected 70 to be excellent, got %s", key)
+	}
+	if category := GetDistanceCategory("10k"); category.TempPenalty != 0 || category.HeatIndexPenalty != 0 {
+		t.Errorf("Expected no 10k penalties, got %+v", category)
+	}
+
+	SetScoring(types.DefaultScoring())
+	if condition := AssessRunningCondition(8, 8, 50, 2, 0, 1); condition.Score != 85 {
+		t.Errorf("Expected the default cold penalty, got %d", condition.Score)
+	}
+	if category := GetDistanceCategory("10k"); category.TempPenalty != 3 {
+		t.Errorf("Expected the default 10k penalties, got %+v", category)
+	}
+}
+
+func TestSetScoringHeatAndAirBands(t *testing.T) {
+	defer SetScoring(types.DefaultScoring())
+
+	// A heat-acclimatized runner moves the WBGT and dew point bands up by a few degrees
+	scoring := types.DefaultScoring()
+	scoring.Thresholds.WBGTWarning = 27
+	scoring.Thresholds.WBGTSevere = 30
+	scoring.Thresholds.WBGTDanger = 33
+	scoring.Thresholds.DewPointMuggy = 17
+	scoring.Thresholds.PM25Standard = 20
+	scoring.Penalties.DustModerate = 40
+	SetScoring(scoring)
+
+	if key := GetHeatStressLevelKey(26); key != "caution" {
+		t.Errorf("Expected WBGT 26 to be caution, got %s", key)
+	}
+	if key := GetDewPointComfortKey(16.5); key != "humid" {
+		t.Errorf("Expected a dew point of 16.5 to be humid, got %s", key)
+	}
+	if penalty := GetPM25Penalty(25); penalty != scoring.Penalties.PM25Elevated {
+		t.Errorf("Expected PM2.5 of 25 to exceed the standard, got %d", penalty)
+	}
+	if penalty := GetDustPenalty(&types.DustLevel{Level: 2}); penalty != 40 {
+		t.Errorf("Expected the configured dust penalty 40, got %d", penalty)
+	}
+
+	SetScoring(types.DefaultScoring())
+	if key := GetHeatStressLevelKey(26); key != "warning" {
+		t.Errorf("Expected WBGT 26 to be warning by default, got %s", key)
+	}
+}
+
+func TestSetScoringUVGustAndRainBands(t *testing.T) {
+	defer SetScoring(types.DefaultScoring())
+
+	scoring := types.DefaultScoring()
+	scoring.Thresholds.UVHigh = 7
+	scoring.Penalties.UVVeryHigh = 25
+	scoring.Thresholds.Gust = 10
+	scoring.Penalties.Gust = 8
+	scoring.Thresholds.DryProbability = 20
+	scoring.Thresholds.RainLikely = 50
+	SetScoring(scoring)
+
+	tests := []struct {
+		name     string
+		actual   any
+		expected any
+	}{
+		{"uv 6 below the raised high band", GetUVLevelKey(6), "moderate"},
+		{"uv 9 very high penalty", GetUVPenalty(9), 25},
+		{"gusts of 11 m/s", GetGustLevelKey(11), "gusty"},
+		{"gust penalty", GetGustPenalty(11), 8},
+		{"25% chance is not dry", IsDryHour(types.TimeBasedWeather{PrecipitationProbability: 25}), false},
+		{"50% chance warns", getRainChanceWarning(50) != "", true},
+	}
+	for _, tt := range tests {
+		if tt.actual != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.actual)
+		}
+	}
+}
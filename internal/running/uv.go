@@ -0,0 +1,83 @@
+package running
+
+import (
+	"math"
+
+	"runcast/internal/i18n"
+)
+
+// GetUVLevelKey returns the language-independent WHO band key for a UV index.
+// The index is rounded to a whole number as it is published.
+func GetUVLevelKey(uvIndex float64) string {
+	uv := math.Round(uvIndex)
+	switch {
+	case uv >= scoring.Thresholds.UVExtreme:
+		return "extreme"
+	case uv >= scoring.Thresholds.UVVeryHigh:
+		return "very_high"
+	case uv >= scoring.Thresholds.UVHigh:
+		return "high"
+	case uv >= scoring.Thresholds.UVModerate:
+		return "moderate"
+	default:
+		return "low"
+	}
+}
+
+// GetUVLevelName returns the localized WHO band name for a UV index
+func GetUVLevelName(uvIndex float64) string {
+	return i18n.T("uv." + GetUVLevelKey(uvIndex))
+}
+
+// GetUVPenalty returns the score penalty for a UV index
+func GetUVPenalty(uvIndex float64) int {
+	switch GetUVLevelKey(uvIndex) {
+	case "high":
+		return scoring.Penalties.UVHigh
+	case "very_high":
+		return scoring.Penalties.UVVeryHigh
+	case "extreme":
+		return scoring.Penalties.UVExtreme
+	default:
+		return 0
+	}
+}
+
+// getUVWarning returns the localized warning for a UV index, or an empty string below the high band
+func getUVWarning(uvIndex float64) string {
+	switch level := GetUVLevelKey(uvIndex); level {
+	case "high", "very_high", "extreme":
+		return i18n.T("warning.uv."+level, uvIndex)
+	default:
+		return ""
+	}
+}
+
+// getUVClothing returns the sun protection to wear for a UV index
+func getUVClothing(uvIndex float64) []string {
+	switch GetUVLevelKey(uvIndex) {
+	case "low":
+		return nil
+	case "moderate":
+		return []string{i18n.T("clothing.sunscreen")}
+	default:
+		return []string{i18n.T("clothing.sunscreen"), i18n.T("clothing.cap"), i18n.T("clothing.sunglasses")}
+	}
+}
+
+// appendUnique appends the items that are not in the list yet
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		exists := false
+		for _, existing := range list {
+			if existing == item {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,101 @@
+package running
+
+import (
+	"strings"
+	"testing"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+func TestGetUVLevelKey(t *testing.T) {
+	tests := []struct {
+		uvIndex       float64
+		expectedLevel string
+		expectedScore int
+	}{
+		{0, "low", 0},
+		{2.4, "low", 0},
+		{2.6, "moderate", 0}, // rounded to 3
+		{5.0, "moderate", 0},
+		{6.0, "high", 5},
+		{8.0, "very_high", 10},
+		{10.4, "very_high", 10},
+		{11.0, "extreme", 20},
+	}
+
+	for _, tt := range tests {
+		if got := GetUVLevelKey(tt.uvIndex); got != tt.expectedLevel {
+			t.Errorf("GetUVLevelKey(%.1f) = %s, expected %s", tt.uvIndex, got, tt.expectedLevel)
+		}
+		if got := GetUVPenalty(tt.uvIndex); got != tt.expectedScore {
+			t.Errorf("GetUVPenalty(%.1f) = %d, expected %d", tt.uvIndex, got, tt.expectedScore)
+		}
+	}
+}
+
+func TestAssessWeatherUV(t *testing.T) {
+	base := types.TimeBasedWeather{Time: "2025-07-05T12:00", Temperature: 22.0, ApparentTemp: 22.0, Humidity: 50, DewPoint: 11.0, WBGT: 18.0}
+
+	calm := AssessWeather(base)
+
+	moderate := base
+	moderate.UVIndex = 4.0
+	condition := AssessWeather(moderate)
+	if condition.Score != calm.Score {
+		t.Errorf("Expected no penalty for a moderate UV index, got %d vs %d", condition.Score, calm.Score)
+	}
+	if !containsItem(condition.Clothing, i18n.T("clothing.sunscreen")) || containsItem(condition.Clothing, i18n.T("clothing.cap")) {
+		t.Errorf("Expected only sunscreen for a moderate UV index, got %v", condition.Clothing)
+	}
+
+	veryHigh := base
+	veryHigh.UVIndex = 9.0
+	condition = AssessWeather(veryHigh)
+	if condition.Score != calm.Score-10 {
+		t.Errorf("Expected a penalty of 10 for a very high UV index, got %d vs %d", condition.Score, calm.Score)
+	}
+	if !strings.Contains(strings.Join(condition.Warnings, "\n"), "☀️") {
+		t.Errorf("Expected a UV warning, got %v", condition.Warnings)
+	}
+	for _, item := range []string{"clothing.sunscreen", "clothing.cap", "clothing.sunglasses"} {
+		if !containsItem(condition.Clothing, i18n.T(item)) {
+			t.Errorf("Expected %s for a very high UV index, got %v", item, condition.Clothing)
+		}
+	}
+
+	// Sunglasses recommended for the heat are not listed twice
+	hot := veryHigh
+	hot.Temperature = 32.0
+	condition = AssessWeather(hot)
+	count := 0
+	for _, item := range condition.Clothing {
+		if item == i18n.T("clothing.sunglasses") {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected sunglasses once, got %v", condition.Clothing)
+	}
+
+	// Outside the noon period the same index only calls for sun protection
+	morning := veryHigh
+	morning.Time = "2025-07-05T06:00"
+	condition = AssessWeather(morning)
+	if condition.Score != calm.Score || strings.Contains(strings.Join(condition.Warnings, "\n"), "☀️") {
+		t.Errorf("Expected no UV penalty or warning at 06:00, got %d vs %d and %v", condition.Score, calm.Score, condition.Warnings)
+	}
+	if !containsItem(condition.Clothing, i18n.T("clothing.sunscreen")) {
+		t.Errorf("Expected sunscreen at 06:00, got %v", condition.Clothing)
+	}
+}
+
+// containsItem reports whether the list contains the item
+func containsItem(list []string, item string) bool {
+	for _, existing := range list {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package running
+
+import (
+	"math"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+// calmComponent is the wind component (m/s) below which the start direction hardly matters
+const calmComponent = 0.5
+
+// Route wind advice keys
+const (
+	WindAdviceIntoWind = "into_wind" // start along the bearing
+	WindAdviceReverse  = "reverse"   // start in the opposite direction
+	WindAdviceEither   = "either"    // mostly crosswind
+)
+
+// GetWindPenalty returns the score penalty for the sustained wind speed (m/s)
+func GetWindPenalty(windSpeed float64) int {
+	switch {
+	case windSpeed > scoring.Thresholds.WindStrong:
+		return scoring.Penalties.WindStrong
+	case windSpeed > scoring.Thresholds.Wind:
+		return scoring.Penalties.Wind
+	default:
+		return 0
+	}
+}
+
+// GetGustLevelKey returns the language-independent gust level key for a gust speed (m/s)
+func GetGustLevelKey(gusts float64) string {
+	switch {
+	case gusts >= scoring.Thresholds.GustDangerous:
+		return "dangerous"
+	case gusts >= scoring.Thresholds.GustStrong:
+		return "strong"
+	case gusts >= scoring.Thresholds.Gust:
+		return "gusty"
+	default:
+		return "calm"
+	}
+}
+
+// GetGustPenalty returns the score penalty for a gust speed (m/s)
+func GetGustPenalty(gusts float64) int {
+	switch GetGustLevelKey(gusts) {
+	case "gusty":
+		return scoring.Penalties.Gust
+	case "strong":
+		return scoring.Penalties.GustStrong
+	case "dangerous":
+		return scoring.Penalties.GustDangerous
+	default:
+		return 0
+	}
+}
+
+// getGustWarning returns the localized warning for a gust speed, or an empty string when calm
+func getGustWarning(gusts float64) string {
+	level := GetGustLevelKey(gusts)
+	if level == "calm" {
+		return ""
+	}
+	return i18n.T("warning.gust."+level, gusts)
+}
+
+// GetWindComponents splits the wind into the headwind (negative for a tailwind) and the crosswind
+// (positive from the right) for a runner heading in the bearing. The wind direction is where the wind comes from.
+func GetWindComponents(windSpeed, windDirection, bearing float64) (float64, float64) {
+	angle := (windDirection - bearing) * math.Pi / 180
+	return windSpeed * math.Cos(angle), windSpeed * math.Sin(angle)
+}
+
+// AnalyzeRouteWind returns the wind along an out-and-back route and which way to start
+// so that the tailwind comes on the way home. It returns nil without a route.
+func AnalyzeRouteWind(data types.TimeBasedWeather, route *types.Route) *types.RouteWind {
+	if route == nil {
+		return nil
+	}
+
+	headwind, crosswind := GetWindComponents(data.WindSpeed, data.WindDirection, route.Bearing)
+	routeWind := &types.RouteWind{
+		Bearing:      route.Bearing,
+		Headwind:     headwind,
+		Crosswind:    crosswind,
+		StartBearing: route.Bearing,
+		Advice:       WindAdviceIntoWind,
+	}
+	switch {
+	case math.Abs(headwind) < calmComponent:
+		routeWind.Advice = WindAdviceEither
+	case headwind < 0:
+		routeWind.StartBearing = math.Mod(route.Bearing+180, 360)
+		routeWind.Advice = WindAdviceReverse
+	}
+	return routeWind
+}
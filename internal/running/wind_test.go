@@ -0,0 +1,113 @@
+package running
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+func TestGetWindComponents(t *testing.T) {
+	tests := []struct {
+		name              string
+		windDirection     float64
+		bearing           float64
+		expectedHeadwind  float64
+		expectedCrosswind float64
+	}{
+		{name: "Headwind", windDirection: 0, bearing: 0, expectedHeadwind: 4, expectedCrosswind: 0},
+		{name: "Tailwind", windDirection: 180, bearing: 0, expectedHeadwind: -4, expectedCrosswind: 0},
+		{name: "From the right", windDirection: 90, bearing: 0, expectedHeadwind: 0, expectedCrosswind: 4},
+		{name: "From the left across north", windDirection: 315, bearing: 45, expectedHeadwind: 0, expectedCrosswind: -4},
+		{name: "Quartering headwind", windDirection: 60, bearing: 0, expectedHeadwind: 2, expectedCrosswind: 3.4641},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headwind, crosswind := GetWindComponents(4, tt.windDirection, tt.bearing)
+			if math.Abs(headwind-tt.expectedHeadwind) > 0.0001 || math.Abs(crosswind-tt.expectedCrosswind) > 0.0001 {
+				t.Errorf("Expected %.4f/%.4f, got %.4f/%.4f", tt.expectedHeadwind, tt.expectedCrosswind, headwind, crosswind)
+			}
+		})
+	}
+}
+
+func TestAnalyzeRouteWind(t *testing.T) {
+	data := types.TimeBasedWeather{WindSpeed: 5, WindDirection: 225} // from the southwest
+
+	tests := []struct {
+		name          string
+		bearing       float64
+		expectedStart float64
+		expectedKey   string
+	}{
+		{name: "Route into the wind", bearing: 200, expectedStart: 200, expectedKey: WindAdviceIntoWind},
+		{name: "Route with the wind", bearing: 45, expectedStart: 225, expectedKey: WindAdviceReverse},
+		{name: "Route across the wind", bearing: 135, expectedStart: 135, expectedKey: WindAdviceEither},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routeWind := AnalyzeRouteWind(data, &types.Route{Bearing: tt.bearing})
+			if routeWind.StartBearing != tt.expectedStart || routeWind.Advice != tt.expectedKey {
+				t.Errorf("Expected to start at %.0f (%s), got %.0f (%s)", tt.expectedStart, tt.expectedKey, routeWind.StartBearing, routeWind.Advice)
+			}
+		})
+	}
+
+	if AnalyzeRouteWind(data, nil) != nil {
+		t.Error("Expected no analysis without a route")
+	}
+}
+
+func TestAssessWeatherGusts(t *testing.T) {
+	base := types.TimeBasedWeather{Temperature: 18.0, ApparentTemp: 18.0, Humidity: 50, DewPoint: 7.0, WBGT: 15.0, WindSpeed: 5}
+	calm := AssessWeather(base)
+
+	tests := []struct {
+		name          string
+		windSpeed     float64
+		gusts         float64
+		expectedDelta int
+	}{
+		{name: "Light gusts", windSpeed: 5, gusts: 9, expectedDelta: 0},
+		{name: "Gusty", windSpeed: 5, gusts: 13, expectedDelta: 5},
+		{name: "Dangerous gusts", windSpeed: 5, gusts: 22, expectedDelta: 30},
+		// A strong sustained wind already costs 25 of the 30 points
+		{name: "Dangerous gusts in a gale", windSpeed: 11, gusts: 22, expectedDelta: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base
+			data.WindSpeed = tt.windSpeed
+			data.WindGusts = tt.gusts
+			condition := AssessWeather(data)
+			if delta := calm.Score - condition.Score; delta != tt.expectedDelta {
+				t.Errorf("Expected a penalty of %d, got %d", tt.expectedDelta, delta)
+			}
+			hasWarning := strings.Contains(strings.Join(condition.Warnings, "\n"), "m/s")
+			if hasWarning != (tt.expectedDelta > 0) {
+				t.Errorf("Unexpected gust warnings: %v", condition.Warnings)
+			}
+		})
+	}
+}
+
+func TestEstimatePaceWithRoute(t *testing.T) {
+	data := types.TimeBasedWeather{Temperature: 10.0, DewPoint: 0, WindSpeed: 6, WindDirection: 90}
+	target := 5 * time.Minute
+
+	unknown := EstimatePace(target, data, nil, nil)
+	along := EstimatePace(target, data, nil, &types.Route{Bearing: 90})
+	across := EstimatePace(target, data, nil, &types.Route{Bearing: 0})
+
+	if along.WindAdjustment != unknown.WindAdjustment {
+		t.Errorf("A route along the wind should match the unknown route, got %.4f vs %.4f", along.WindAdjustment, unknown.WindAdjustment)
+	}
+	if math.Abs(across.WindAdjustment) > 0.0001 {
+		t.Errorf("Expected no wind adjustment for a pure crosswind, got %.4f", across.WindAdjustment)
+	}
+}
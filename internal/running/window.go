@@ -0,0 +1,83 @@
+package running
+
+import (
+	"math"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+// AssessRunWindow assesses a run over the forecast hours it covers, the first being the start.
+// The run takes the assessment of its worst hour, so the hottest part of a long run counts,
+// and is further marked down for the rain it meets in total and for a rising temperature.
+// The clothing is chosen for the start and completed with what the worst hour calls for.
+func AssessRunWindow(window []types.TimeBasedWeather, distanceCategory *types.DistanceCategory) types.RunningCondition {
+	if len(window) == 0 {
+		return types.RunningCondition{}
+	}
+
+	start := AssessDistanceBasedWeather(window[0], distanceCategory)
+	condition := start
+	summary := &types.RunWindowSummary{
+		Hours:          len(window),
+		WorstTime:      window[0].Time,
+		MinTemperature: window[0].Temperature,
+		MaxTemperature: window[0].Temperature,
+		PeakWBGT:       window[0].WBGT,
+	}
+	worst := window[0]
+	for _, data := range window {
+		summary.MinTemperature = math.Min(summary.MinTemperature, data.Temperature)
+		summary.MaxTemperature = math.Max(summary.MaxTemperature, data.Temperature)
+		summary.PeakWBGT = math.Max(summary.PeakWBGT, data.WBGT)
+		summary.MaxWindSpeed = math.Max(summary.MaxWindSpeed, data.WindSpeed)
+		summary.TotalPrecipitation += data.Precipitation
+	}
+	summary.TemperatureRise = window[len(window)-1].Temperature - window[0].Temperature
+	summary.RainChance = 100 - dryProbability(window)
+
+	for _, data := range window[1:] {
+		hourly := AssessDistanceBasedWeather(data, distanceCategory)
+		if hourly.Score < condition.Score {
+			condition = hourly
+			worst = data
+			summary.WorstTime = data.Time
+		}
+		if hourly.HeatStress != nil && (condition.HeatStress == nil || hourly.HeatStress.WBGT > condition.HeatStress.WBGT) {
+			condition.HeatStress = hourly.HeatStress
+		}
+	}
+	condition.Window = summary
+	if len(window) == 1 {
+		return condition
+	}
+
+	score := condition.Score
+	warnings := append([]string{}, condition.Warnings...)
+	clothing := appendUnique(append([]string{}, start.Clothing...), condition.Clothing...)
+
+	// Rain adds up over the run, and so does the chance of meeting some
+	worstPenalty, _ := getRainPenalty(worst.Precipitation)
+	if totalPenalty, _ := getRainPenalty(summary.TotalPrecipitation); totalPenalty > worstPenalty {
+		score -= totalPenalty - worstPenalty
+		warnings = append(warnings, i18n.T("warning.rain_total", summary.TotalPrecipitation))
+	} else if summary.TotalPrecipitation == 0 && worst.PrecipitationProbability < scoring.Thresholds.RainLikely && summary.RainChance >= scoring.Thresholds.RainLikely {
+		score -= scoring.Penalties.RainChance
+		warnings = append(warnings, i18n.T("warning.rain_chance_run", summary.RainChance))
+	}
+
+	if summary.TemperatureRise >= scoring.Thresholds.TemperatureRise {
+		score -= scoring.Penalties.TemperatureRise
+		warnings = append(warnings, i18n.T("warning.temperature_rise", window[0].Temperature, window[len(window)-1].Temperature))
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	condition.Score = score
+	condition.Warnings = warnings
+	condition.Clothing = clothing
+	updateLevel(&condition, distanceCategory)
+
+	return condition
+}
@@ -0,0 +1,109 @@
+package running
+
+import (
+	"testing"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// newWindowHours returns consecutive hours from 06:00 with the given temperatures, mild otherwise
+func newWindowHours(temperatures ...float64) []types.TimeBasedWeather {
+	hours := make([]types.TimeBasedWeather, len(temperatures))
+	for i, temperature := range temperatures {
+		data := newTimeBasedWeather(temperature, temperature, 50, 2, 0, 1)
+		data.Time = []string{"2025-07-05T06:00", "2025-07-05T07:00", "2025-07-05T08:00", "2025-07-05T09:00"}[i]
+		data.DewPoint = weather.CalculateDewPoint(temperature, 50)
+		hours[i] = data
+	}
+	return hours
+}
+
+func TestAssessRunWindowSingleHour(t *testing.T) {
+	hours := newWindowHours(18)
+	distance := GetDistanceCategory("10k")
+
+	condition := AssessRunWindow(hours, distance)
+	expected := AssessDistanceBasedWeather(hours[0], distance)
+	if condition.Score != expected.Score || len(condition.Warnings) != len(expected.Warnings) {
+		t.Errorf("Expected the hourly assessment %d, got %d", expected.Score, condition.Score)
+	}
+	if condition.Window == nil || condition.Window.Hours != 1 || condition.Window.WorstTime != hours[0].Time {
+		t.Errorf("Unexpected window summary: %+v", condition.Window)
+	}
+
+	if empty := AssessRunWindow(nil, distance); empty.Window != nil || empty.Score != 0 {
+		t.Errorf("Expected an empty assessment without hours, got %+v", empty)
+	}
+}
+
+func TestAssessRunWindowWorstHeat(t *testing.T) {
+	hours := newWindowHours(18, 18, 32)
+	hours[2].WBGT = 30
+	distance := GetDistanceCategory("half")
+
+	condition := AssessRunWindow(hours, distance)
+	hottest := AssessDistanceBasedWeather(hours[2], distance)
+	start := AssessDistanceBasedWeather(hours[0], distance)
+
+	// The hot last hour sets the score, which also drops for the rising temperature
+	if condition.Score != max(0, hottest.Score-scoring.Penalties.TemperatureRise) {
+		t.Errorf("Expected score %d, got %d", max(0, hottest.Score-scoring.Penalties.TemperatureRise), condition.Score)
+	}
+	if condition.HeatStress == nil || condition.HeatStress.WBGT != 30 {
+		t.Errorf("Expected the peak WBGT 30, got %+v", condition.HeatStress)
+	}
+	if condition.Window.WorstTime != hours[2].Time || condition.Window.TemperatureRise != 14 || condition.Window.PeakWBGT != 30 {
+		t.Errorf("Unexpected window summary: %+v", condition.Window)
+	}
+	// Dressed for the start, with what the heat calls for
+	for _, item := range start.Clothing {
+		if !containsItem(condition.Clothing, item) {
+			t.Errorf("Expected the start clothing %s in %v", item, condition.Clothing)
+		}
+	}
+	if !containsItem(condition.Warnings, i18nTemperatureRise(hours)) {
+		t.Errorf("Expected a rising temperature warning, got %v", condition.Warnings)
+	}
+}
+
+func TestAssessRunWindowRain(t *testing.T) {
+	tests := []struct {
+		name             string
+		precipitation    []float64
+		probability      []float64
+		expectedPenalty  int
+		expectedRainyRun bool
+	}{
+		{"dry", []float64{0, 0, 0}, []float64{10, 10, 10}, 0, false},
+		// 0.8 mm each hour is light rain, but 2.4 mm over the run is rain
+		{"rain adds up", []float64{0.8, 0.8, 0.8}, []float64{80, 80, 80}, 15, false},
+		// 40% each hour means a 78% chance of meeting rain at some point
+		{"chance adds up", []float64{0, 0, 0}, []float64{40, 40, 40}, scoring.Penalties.RainChance, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours := newWindowHours(18, 18, 18)
+			for i := range hours {
+				hours[i].Precipitation = tt.precipitation[i]
+				hours[i].PrecipitationProbability = tt.probability[i]
+			}
+
+			condition := AssessRunWindow(hours, nil)
+			worst := AssessWeather(hours[0])
+			if condition.Score != worst.Score-tt.expectedPenalty {
+				t.Errorf("Expected score %d, got %d", worst.Score-tt.expectedPenalty, condition.Score)
+			}
+			if tt.expectedRainyRun && condition.Window.RainChance != 78 {
+				t.Errorf("Expected a 78%% chance of rain, got %.0f", condition.Window.RainChance)
+			}
+		})
+	}
+}
+
+// i18nTemperatureRise returns the rising temperature warning for the hours
+func i18nTemperatureRise(hours []types.TimeBasedWeather) string {
+	return i18n.T("warning.temperature_rise", hours[0].Temperature, hours[len(hours)-1].Temperature)
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/display"
+	"runcast/internal/i18n"
+	"runcast/internal/report"
+	"runcast/internal/running"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// Defaults of the HTTP API server
+const (
+	DefaultAddr     = "127.0.0.1:8080"
+	DefaultCacheTTL = 10 * time.Minute
+	shutdownTimeout = 10 * time.Second
+)
+
+// Server serves the running assessments over HTTP as JSON
+type Server struct {
+	Provider weather.Provider
+	Config   *config.Config // custom locations and runner profile, may be nil
+	CacheTTL time.Duration  // how long an assessment is served from memory, zero disables the cache
+	Now      func() time.Time
+	Logger   *log.Logger // receives the failures the API answers without, such as missing air quality data
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+// cachedResponse is an assessment rendered for a request
+type cachedResponse struct {
+	body    []byte
+	expires time.Time
+}
+
+// New creates a server fetching the forecasts from provider
+func New(provider weather.Provider, cfg *config.Config) *Server {
+	return &Server{
+		Provider: provider,
+		Config:   cfg,
+		CacheTTL: DefaultCacheTTL,
+		Now:      time.Now,
+		Logger:   log.Default(),
+		cache:    make(map[string]cachedResponse),
+	}
+}
+
+// Handler returns the routes of the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /v1/cities", s.handleCities)
+	mux.HandleFunc("GET /v1/assessment", s.handleAssessment)
+	return mux
+}
+
+// ListenAndServe serves the API on addr until ctx is done, then lets the requests in flight finish
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// logf logs a message to the server's logger, or the standard one when it has none
+func (s *Server) logf(format string, args ...any) {
+	if s.Logger == nil {
+		log.Printf(format, args...)
+		return
+	}
+	s.Logger.Printf(format, args...)
+}
+
+// handleHealth reports that the server is up
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleCities lists the built-in cities followed by the custom locations
+func (s *Server) handleCities(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, display.NewJSONCities(weather.ListCities(s.Config)))
+}
+
+// handleAssessment assesses a city for the current conditions, a date, a time period or both,
+// answering with the same document as -output json
+func (s *Server) handleAssessment(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	city := strings.TrimSpace(query.Get("city"))
+	dateSpec := strings.TrimSpace(query.Get("date"))
+	timeOfDay := strings.TrimSpace(query.Get("time"))
+	distanceKey := strings.TrimSpace(query.Get("distance"))
+
+	if city == "" {
+		writeError(w, http.StatusBadRequest, i18n.T("error.missing_city"))
+		return
+	}
+	// Malformed coordinates are a bad request rather than an unknown city
+	if config.IsCoordinate(city) {
+		if _, _, err := config.ParseCoordinate(city); err != nil {
+			writeError(w, http.StatusBadRequest, i18n.T("error.invalid_coordinate", city, err))
+			return
+		}
+	}
+	coord, err := weather.ResolveCityCoordinate(city, s.Config)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	now := s.Now().In(weather.ExpectedLocation(*coord))
+	dayOffset := 0
+	if dateSpec != "" {
+		dayOffset, err = weather.ParseDateOffset(dateSpec, now)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if dayOffset+1 > weather.MaxForecastDays {
+		writeError(w, http.StatusBadRequest, i18n.T("error.forecast_horizon", weather.MaxForecastDays-1))
+		return
+	}
+	if timeOfDay != "" && !weather.ValidateTimeSpec(timeOfDay) {
+		writeError(w, http.StatusBadRequest, i18n.T("error.invalid_time", timeOfDay))
+		return
+	}
+
+	if distanceKey == "" && s.Config != nil {
+		distanceKey = s.Config.Profile.Distance
+	}
+	var distanceCategory *types.DistanceCategory
+	if distanceKey != "" {
+		distanceCategory = running.GetDistanceCategory(distanceKey)
+		if distanceCategory == nil {
+			writeError(w, http.StatusBadRequest, i18n.T("error.invalid_distance", distanceKey))
+			return
+		}
+	}
+
+	// Relative dates resolve differently after midnight, so the key holds the resolved date
+	key := strings.Join([]string{city, now.AddDate(0, 0, dayOffset).Format("2006-01-02"), dateSpec, timeOfDay, distanceKey}, "|")
+	if body, ok := s.cached(key, now); ok {
+		writeBody(w, http.StatusOK, body)
+		return
+	}
+
+	days := dayOffset + 1
+	timezone := weather.LocationTimezone(*coord)
+	weatherData, err := s.Provider.Forecast(coord.Lat, coord.Lon, timezone, days)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	// Air quality data is optional, continue without it
+	airQuality, err := s.Provider.AirQuality(coord.Lat, coord.Lon, timezone, days)
+	if err != nil {
+		s.logf("%s", i18n.T("warning.air_quality_fetch", err))
+		airQuality = nil
+	}
+
+	req := report.Request{
+		Location:  *coord,
+		DateSpec:  dateSpec,
+		DayOffset: dayOffset,
+		TimeOfDay: timeOfDay,
+		Days:      days,
+		Distance:  distanceCategory,
+		Now:       now,
+	}
+	if s.Config != nil {
+		req.Pace = s.Config.Profile.PaceDuration()
+		req.Profile = s.Config.Profile.Runner()
+		req.Route = s.Config.Route.Route()
+	}
+	runningReport, err := report.Build(req, weatherData, airQuality)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	var body bytes.Buffer
+	if err := display.RenderJSON(&body, runningReport); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.store(key, body.Bytes(), now)
+	writeBody(w, http.StatusOK, body.Bytes())
+}
+
+// cached returns the body stored for key unless it has expired
+func (s *Server) cached(key string, now time.Time) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response, exists := s.cache[key]
+	if !exists || !now.Before(response.expires) {
+		return nil, false
+	}
+	return response.body, true
+}
+
+// store keeps body for key and drops the expired entries
+func (s *Server) store(key string, body []byte, now time.Time) {
+	if s.CacheTTL <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache == nil {
+		s.cache = make(map[string]cachedResponse)
+	}
+	for existing, response := range s.cache {
+		if !now.Before(response.expires) {
+			delete(s.cache, existing)
+		}
+	}
+	s.cache[key] = cachedResponse{body: body, expires: now.Add(s.CacheTTL)}
+}
+
+// writeJSON writes value as the JSON response
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	body, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeBody(w, status, append(body, '\n'))
+}
+
+// writeError writes an error message as the JSON response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// writeBody writes an encoded JSON response
+func writeBody(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
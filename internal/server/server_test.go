@@ -0,0 +1,220 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/types"
+	"runcast/internal/weather"
+)
+
+// fakeProvider serves fixed data and counts the forecast requests
+type fakeProvider struct {
+	forecasts int
+	err       error
+}
+
+func (f *fakeProvider) Forecast(lat, lon float64, timezone string, days int) (*types.WeatherData, error) {
+	f.forecasts++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return newTestWeather(), nil
+}
+
+func (f *fakeProvider) AirQuality(lat, lon float64, timezone string, days int) (*types.AirQualityData, error) {
+	return nil, errors.New("no air quality")
+}
+
+func newTestWeather() *types.WeatherData {
+	weatherData := &types.WeatherData{}
+	weatherData.Current.Temperature = 20.0
+	weatherData.Current.ApparentTemp = 20.0
+	weatherData.Current.Humidity = 50
+	weatherData.Current.WindSpeed = 2.0
+
+	weatherData.Daily.Time = []string{"2025-07-05", "2025-07-06"}
+	weatherData.Daily.TemperatureMax = []float64{30.0, 24.0}
+	weatherData.Daily.TemperatureMin = []float64{22.0, 16.0}
+	weatherData.Daily.WeatherCode = []int{0, 3}
+	weatherData.Daily.WindSpeedMax = []float64{4.0, 3.0}
+	weatherData.Daily.PrecipitationSum = []float64{0, 0}
+
+	weatherData.Hourly.Time = []string{
+		"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00",
+		"2025-07-06T05:00", "2025-07-06T06:00", "2025-07-06T07:00",
+	}
+	weatherData.Hourly.Temperature = []float64{22.0, 24.0, 27.0, 16.0, 12.0, 18.0}
+	weatherData.Hourly.ApparentTemp = []float64{22.0, 24.0, 29.0, 16.0, 12.0, 18.0}
+	weatherData.Hourly.Humidity = []int{70, 65, 60, 55, 55, 50}
+	weatherData.Hourly.WindSpeed = []float64{2.0, 2.0, 3.0, 1.0, 1.0, 2.0}
+	weatherData.Hourly.WindDirection = []float64{0, 90, 180, 0, 90, 180}
+	weatherData.Hourly.Precipitation = []float64{0, 0, 0, 0, 0, 0}
+	weatherData.Hourly.WeatherCode = []int{0, 0, 1, 1, 0, 0}
+
+	return weatherData
+}
+
+// newTestServer returns a server at 2025-07-05 04:00 in Japan with a custom location
+func newTestServer(provider weather.Provider) *Server {
+	srv := New(provider, &config.Config{
+		Locations: map[string]types.CityCoordinate{
+			"home": {Name: "自宅", Lat: 35.6, Lon: 139.6},
+		},
+	})
+	srv.Now = func() time.Time {
+		return time.Date(2025, 7, 5, 4, 0, 0, 0, weather.DefaultLocation)
+	}
+	srv.Logger = log.New(io.Discard, "", 0)
+	return srv
+}
+
+func get(t *testing.T, handler http.Handler, target string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var body map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON from %s: %v\n%s", target, err, recorder.Body.String())
+	}
+	return recorder, body
+}
+
+func TestHealth(t *testing.T) {
+	recorder, body := get(t, newTestServer(&fakeProvider{}).Handler(), "/healthz")
+	if recorder.Code != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("Unexpected health response %d: %v", recorder.Code, body)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json; charset=utf-8" {
+		t.Errorf("Unexpected content type %s", contentType)
+	}
+}
+
+func TestCities(t *testing.T) {
+	_, body := get(t, newTestServer(&fakeProvider{}).Handler(), "/v1/cities")
+
+	cities, _ := body["cities"].([]any)
+	if len(cities) != len(weather.GetSupportedCities())+1 {
+		t.Fatalf("Expected the built-in cities and one custom location, got %d", len(cities))
+	}
+	last := cities[len(cities)-1].(map[string]any)
+	if last["key"] != "home" || last["name"] != "自宅" || last["custom"] != true {
+		t.Errorf("Expected the custom location last, got %v", last)
+	}
+}
+
+func TestAssessment(t *testing.T) {
+	provider := &fakeProvider{}
+	handler := newTestServer(provider).Handler()
+
+	target := "/v1/assessment?city=tokyo&date=tomorrow&time=morning&distance=10k"
+	recorder, body := get(t, handler, target)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %v", recorder.Code, body)
+	}
+	if body["mode"] != "datetime" || body["date"] != "2025-07-06" {
+		t.Errorf("Expected tomorrow morning, got mode %v on %v", body["mode"], body["date"])
+	}
+	if hours, _ := body["hours"].([]any); len(hours) == 0 {
+		t.Errorf("Expected hourly assessments, got %v", body["hours"])
+	}
+	if distance, _ := body["distance"].(map[string]any); distance["key"] != "10k" {
+		t.Errorf("Expected the 10k distance, got %v", body["distance"])
+	}
+
+	// The same query is answered from memory
+	get(t, handler, target)
+	if provider.forecasts != 1 {
+		t.Errorf("Expected one forecast request, got %d", provider.forecasts)
+	}
+	get(t, handler, "/v1/assessment?city=home")
+	if provider.forecasts != 2 {
+		t.Errorf("Expected another forecast request for a new query, got %d", provider.forecasts)
+	}
+}
+
+func TestAssessmentLogsAirQualityFailure(t *testing.T) {
+	srv := newTestServer(&fakeProvider{})
+	var logs bytes.Buffer
+	srv.Logger = log.New(&logs, "", 0)
+
+	recorder, _ := get(t, srv.Handler(), "/v1/assessment?city=tokyo")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected the assessment without air quality, got %d", recorder.Code)
+	}
+	if !strings.Contains(logs.String(), "no air quality") {
+		t.Errorf("Expected the air quality failure in the log, got %q", logs.String())
+	}
+}
+
+func TestAssessmentErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		err      error
+		expected int
+	}{
+		{"missing city", "/v1/assessment", nil, http.StatusBadRequest},
+		{"unknown city", "/v1/assessment?city=atlantis", nil, http.StatusNotFound},
+		{"malformed coordinates", "/v1/assessment?city=35.6,abc", nil, http.StatusBadRequest},
+		{"coordinates out of range", "/v1/assessment?city=95,139.6", nil, http.StatusBadRequest},
+		{"invalid date", "/v1/assessment?city=tokyo&date=someday", nil, http.StatusBadRequest},
+		{"beyond the forecast", "/v1/assessment?city=tokyo&date=%2B20d", nil, http.StatusBadRequest},
+		{"invalid time", "/v1/assessment?city=tokyo&time=brunch", nil, http.StatusBadRequest},
+		{"invalid distance", "/v1/assessment?city=tokyo&distance=ultra", nil, http.StatusBadRequest},
+		{"provider failure", "/v1/assessment?city=tokyo", errors.New("timeout"), http.StatusBadGateway},
+		{"wrong method", "/healthz", nil, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestServer(&fakeProvider{err: tt.err}).Handler()
+			recorder := httptest.NewRecorder()
+			method := http.MethodGet
+			if tt.expected == http.StatusMethodNotAllowed {
+				method = http.MethodPost
+			}
+			handler.ServeHTTP(recorder, httptest.NewRequest(method, tt.target, nil))
+
+			if recorder.Code != tt.expected {
+				t.Fatalf("Expected %d, got %d: %s", tt.expected, recorder.Code, recorder.Body.String())
+			}
+			if tt.expected == http.StatusMethodNotAllowed {
+				return
+			}
+			var body map[string]string
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body["error"] == "" {
+				t.Errorf("Expected a JSON error message, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestListenAndServeShutsDown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- newTestServer(&fakeProvider{}).ListenAndServe(ctx, "127.0.0.1:0")
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected a clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not shut down")
+	}
+}
@@ -1,50 +1,82 @@
 package types
 
+import "time"
+
 // WeatherData represents weather information from API
 type WeatherData struct {
-	Current struct {
-		Temperature   float64 `json:"temperature_2m"`
-		ApparentTemp  float64 `json:"apparent_temperature"`
-		Humidity      int     `json:"relative_humidity_2m"`
-		WindSpeed     float64 `json:"wind_speed_10m"`
-		WindDirection float64 `json:"wind_direction_10m"`
-		Precipitation float64 `json:"precipitation"`
-		WeatherCode   int     `json:"weather_code"`
-	} `json:"current"`
-	Hourly struct {
-		Time          []string  `json:"time"`
-		Temperature   []float64 `json:"temperature_2m"`
-		ApparentTemp  []float64 `json:"apparent_temperature"`
-		Humidity      []int     `json:"relative_humidity_2m"`
-		WindSpeed     []float64 `json:"wind_speed_10m"`
-		WindDirection []float64 `json:"wind_direction_10m"`
-		Precipitation []float64 `json:"precipitation"`
-		WeatherCode   []int     `json:"weather_code"`
-	} `json:"hourly"`
-	Daily struct {
-		Time                []string  `json:"time"`
-		TemperatureMax      []float64 `json:"temperature_2m_max"`
-		TemperatureMin      []float64 `json:"temperature_2m_min"`
-		WindSpeedMax        []float64 `json:"wind_speed_10m_max"`
-		WindGustMax         []float64 `json:"wind_gusts_10m_max"`
-		PrecipitationSum    []float64 `json:"precipitation_sum"`
-		WeatherCode         []int     `json:"weather_code"`
-		SunriseTime         []string  `json:"sunrise"`
-		SunsetTime          []string  `json:"sunset"`
-		DaylightDuration    []float64 `json:"daylight_duration"`
-		SunshineDuration    []float64 `json:"sunshine_duration"`
-		UvIndexMax          []float64 `json:"uv_index_max"`
-		UvIndexClearSkyMax  []float64 `json:"uv_index_clear_sky_max"`
-		PrecipitationHours  []float64 `json:"precipitation_hours"`
-		PrecipitationProbabilityMax []float64 `json:"precipitation_probability_max"`
-	} `json:"daily"`
+	Current CurrentWeather `json:"current"`
+	Hourly  HourlyWeather  `json:"hourly"`
+	Daily   DailyWeather   `json:"daily"`
+
+	// Timezone and UTCOffsetSeconds describe the local times of the forecast
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+
+	// FetchedAt is when the data was retrieved from the API (set when served from cache)
+	FetchedAt time.Time `json:"-"`
+	// FromCache reports whether the data was served from the on-disk cache
+	FromCache bool `json:"-"`
+}
+
+// CurrentWeather represents the current conditions from API
+type CurrentWeather struct {
+	Temperature        float64 `json:"temperature_2m"`
+	ApparentTemp       float64 `json:"apparent_temperature"`
+	Humidity           int     `json:"relative_humidity_2m"`
+	WindSpeed          float64 `json:"wind_speed_10m"`
+	WindDirection      float64 `json:"wind_direction_10m"`
+	Precipitation      float64 `json:"precipitation"`
+	WeatherCode        int     `json:"weather_code"`
+	DewPoint           float64 `json:"dew_point_2m"`
+	ShortwaveRadiation float64 `json:"shortwave_radiation"`
+	UVIndex            float64 `json:"uv_index"`
+	WindGusts          float64 `json:"wind_gusts_10m"`
+}
+
+// HourlyWeather represents the hourly forecast from API
+type HourlyWeather struct {
+	Time               []string  `json:"time"`
+	Temperature        []float64 `json:"temperature_2m"`
+	ApparentTemp       []float64 `json:"apparent_temperature"`
+	Humidity           []int     `json:"relative_humidity_2m"`
+	WindSpeed          []float64 `json:"wind_speed_10m"`
+	WindDirection      []float64 `json:"wind_direction_10m"`
+	Precipitation      []float64 `json:"precipitation"`
+	WeatherCode        []int     `json:"weather_code"`
+	DewPoint           []float64 `json:"dew_point_2m"`
+	ShortwaveRadiation []float64 `json:"shortwave_radiation"`
+	UVIndex            []float64 `json:"uv_index"`
+	WindGusts          []float64 `json:"wind_gusts_10m"`
+	// PrecipitationProbability is the chance of precipitation in %
+	PrecipitationProbability []float64 `json:"precipitation_probability"`
+}
+
+// DailyWeather represents the daily forecast from API
+type DailyWeather struct {
+	Time                        []string  `json:"time"`
+	TemperatureMax              []float64 `json:"temperature_2m_max"`
+	TemperatureMin              []float64 `json:"temperature_2m_min"`
+	WindSpeedMax                []float64 `json:"wind_speed_10m_max"`
+	WindGustMax                 []float64 `json:"wind_gusts_10m_max"`
+	WindDirectionDominant       []float64 `json:"wind_direction_10m_dominant"`
+	PrecipitationSum            []float64 `json:"precipitation_sum"`
+	WeatherCode                 []int     `json:"weather_code"`
+	SunriseTime                 []string  `json:"sunrise"`
+	SunsetTime                  []string  `json:"sunset"`
+	DaylightDuration            []float64 `json:"daylight_duration"`
+	SunshineDuration            []float64 `json:"sunshine_duration"`
+	UvIndexMax                  []float64 `json:"uv_index_max"`
+	UvIndexClearSkyMax          []float64 `json:"uv_index_clear_sky_max"`
+	PrecipitationHours          []float64 `json:"precipitation_hours"`
+	PrecipitationProbabilityMax []float64 `json:"precipitation_probability_max"`
 }
 
 // CityCoordinate represents city name and coordinates
 type CityCoordinate struct {
-	Name string
-	Lat  float64
-	Lon  float64
+	Name     string
+	Lat      float64
+	Lon      float64
+	Timezone string // IANA timezone such as America/New_York, "auto" for the coordinates' own, empty for Japan
 }
 
 // TimeBasedWeather represents weather data for specific time
@@ -57,6 +89,52 @@ type TimeBasedWeather struct {
 	WindDirection float64
 	Precipitation float64
 	WeatherCode   int
+	// DewPoint is the dew point temperature in °C
+	DewPoint float64
+	// ShortwaveRadiation is the global horizontal irradiance in W/m²
+	ShortwaveRadiation float64
+	// WBGT is the estimated wet bulb globe temperature in °C
+	WBGT float64
+	// UVIndex is the UV index, the daily maximum for whole-day data
+	UVIndex float64
+	// WindGusts is the gust speed in m/s, the daily maximum for whole-day data
+	WindGusts float64
+	// PrecipitationProbability is the chance of precipitation in %, the daily maximum for whole-day data
+	PrecipitationProbability float64
+}
+
+// DryWindow represents consecutive forecast hours without rain
+type DryWindow struct {
+	Start          string // first hour, YYYY-MM-DDTHH:MM
+	End            string // end of the last hour
+	Hours          int
+	DryProbability float64       // chance in % that the whole window stays dry
+	Confidence     string        // language-independent key: high, medium or low
+	RunDuration    time.Duration // expected duration of the run
+	LongEnough     bool          // whether the window fits the run
+}
+
+// Route represents the runner's course
+type Route struct {
+	Bearing float64 // outbound direction in degrees clockwise from north
+}
+
+// RouteWind represents the wind along an out-and-back route
+type RouteWind struct {
+	Bearing      float64 // outbound direction of the route
+	Headwind     float64 // m/s on the outbound leg, negative for a tailwind
+	Crosswind    float64 // m/s, positive from the right on the outbound leg
+	StartBearing float64 // direction to start in so that the tailwind comes on the return leg
+	Advice       string  // language-independent key: into_wind, reverse or either
+}
+
+// Daylight represents the sun times of a day
+type Daylight struct {
+	Sunrise   time.Time
+	Sunset    time.Time
+	CivilDawn time.Time // start of civil twilight, when it becomes light enough to run without a light
+	CivilDusk time.Time // end of civil twilight
+	Duration  time.Duration
 }
 
 // TimePeriod represents time period definition
@@ -65,18 +143,21 @@ type TimePeriod struct {
 	DisplayName string
 	StartHour   int
 	EndHour     int
+	StartMinute int // minutes past StartHour
+	EndMinute   int // minutes past EndHour
 }
 
 // DistanceCategory represents running distance category
 type DistanceCategory struct {
-	Key             string
-	DisplayName     string
-	Description     string
-	MinKm           float64
-	MaxKm           float64
-	TempPenalty     int
-	HumidityPenalty int
-	WindPenalty     int
+	Key              string
+	DisplayName      string
+	Description      string
+	MinKm            float64
+	MaxKm            float64
+	RaceKm           float64 // official race distance used for finish time estimates
+	TempPenalty      int
+	HumidityPenalty  int
+	WindPenalty      int
 	HeatIndexPenalty int
 }
 
@@ -87,6 +168,61 @@ type RunningCondition struct {
 	Recommendation string
 	Warnings       []string
 	Clothing       []string
+	HeatStress     *HeatStress
+	Window         *RunWindowSummary // conditions over the hours the run covers
+}
+
+// RunWindowSummary aggregates the forecast hours a run covers
+type RunWindowSummary struct {
+	Hours              int
+	WorstTime          string  // hour with the lowest score
+	MinTemperature     float64 // °C
+	MaxTemperature     float64
+	TemperatureRise    float64 // °C from the first to the last hour, negative when it cools down
+	PeakWBGT           float64
+	MaxWindSpeed       float64 // m/s
+	TotalPrecipitation float64 // mm
+	RainChance         float64 // % chance of rain at some point of the run
+}
+
+// PaceEstimate represents a target pace adjusted for the weather
+type PaceEstimate struct {
+	TargetPace     time.Duration // per km
+	AdjustedPace   time.Duration // per km
+	HeatAdjustment float64       // slowdown ratio from temperature and dew point, e.g. 0.03 for 3%
+	WindAdjustment float64       // slowdown ratio from wind
+	DistanceKm     float64       // race distance, zero without a distance category
+	FinishTime     time.Duration // zero without a distance category
+}
+
+// RunnerProfile represents optional personal values used to tailor plans; zero means unknown
+type RunnerProfile struct {
+	BodyWeight       float64 // kg
+	SweatRate        float64 // L/h measured in mild conditions
+	HeatTolerance    string  // "low", "normal" or "high"; empty means normal
+	ColdTolerance    string  // "low", "normal" or "high"; empty means normal
+	SensitiveAirways bool    // asthma or a pollen allergy
+}
+
+// HydrationPlan represents how much to drink and eat during a run
+type HydrationPlan struct {
+	Duration           time.Duration // expected run duration
+	SweatRate          float64       // L/h over the run
+	SweatRateEstimated bool          // true when no measured sweat rate is configured
+	FluidPerHour       int           // ml/h, zero when drinking before and after is enough
+	TotalFluid         int           // ml over the whole run
+	SodiumPerHour      int           // mg/h, zero when not needed
+	GelCount           int           // energy gels of about 25 g of carbohydrate
+	FirstGel           time.Duration // when to take the first gel, zero without gels
+	GelInterval        time.Duration
+}
+
+// HeatStress represents the estimated WBGT and its heat stroke prevention band
+type HeatStress struct {
+	WBGT        float64
+	Level       string // language-independent key such as "warning"
+	DisplayName string
+	Guidance    string
 }
 
 // AirQualityData represents air quality information from API
@@ -97,6 +233,10 @@ type AirQualityData struct {
 		PM10  []float64 `json:"pm10"`
 		PM2_5 []float64 `json:"pm2_5"`
 	} `json:"hourly"`
+
+	// Timezone and UTCOffsetSeconds describe the local times of the data
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
 }
 
 // DustLevel represents dust concentration level
@@ -107,4 +247,216 @@ type DustLevel struct {
 	Dust        float64
 	PM10        float64
 	PM2_5       float64
-}
\ No newline at end of file
+}
+
+// Scoring holds the thresholds, penalties and level cutoffs of the running condition score
+type Scoring struct {
+	Thresholds ScoringThresholds
+	Penalties  ScoringPenalties
+	Levels     LevelCutoffs
+	Distances  map[string]DistancePenalties // by distance category key
+}
+
+// ScoringThresholds holds the weather values at which the score starts to drop
+type ScoringThresholds struct {
+	ColdSevere float64 // °C below which it is severely cold
+	Cold       float64 // °C below which it is cold
+	Cool       float64 // °C below which it is cool
+	Hot        float64 // °C above which long distances are penalized again
+	VeryHot    float64 // °C above which long distances are penalized twice more
+	Wind       float64 // m/s above which the wind slows a run
+	WindStrong float64 // m/s above which the wind is strong
+	Rain       float64 // mm above which light rain becomes rain
+	RainHeavy  float64 // mm above which rain is heavy
+
+	// WBGT bands (°C) of the heat stroke prevention guideline for exercise
+	WBGTCaution float64 // 注意: drink actively
+	WBGTWarning float64 // 警戒: take breaks actively
+	WBGTSevere  float64 // 厳重警戒: stop strenuous exercise
+	WBGTDanger  float64 // 危険: exercise should be stopped in principle
+
+	// Dew point comfort bands (°C) commonly used by runners
+	DewPointHumid      float64 // noticeable but fine
+	DewPointMuggy      float64 // sweat evaporates slowly
+	DewPointOppressive float64 // uncomfortable, expect to slow down
+	DewPointDangerous  float64 // evaporative cooling barely works
+
+	// PM2.5 bands (μg/m³) based on Japan's environmental standard
+	PM25Standard float64 // above which the environmental standard is exceeded
+	PM25High     float64 // above which the level is high
+	PM25Alert    float64 // above which the alert level is reached
+
+	// Clothing bands (°C) above Cool
+	Mild   float64 // below which thin long sleeves are worn
+	Warm   float64 // from which a hat is recommended
+	Summer float64 // from which a hat and sunglasses are needed
+
+	// Warnings and gear for half and full marathons
+	LongRunFuel float64 // °C above which fluids and energy are carried
+	LongRunHot  float64 // °C above which long runs warn about the heat
+	MarathonHot float64 // °C above which full marathons warn about the heat
+
+	// UV index bands of the WHO Global Solar UV Index
+	UVModerate float64 // sun protection needed around midday
+	UVHigh     float64 // sun protection essential
+	UVVeryHigh float64 // avoid the midday sun
+	UVExtreme  float64 // stay out of the midday sun
+
+	// Gust bands (m/s)
+	Gust          float64 // noticeable pushes, loose items fly
+	GustStrong    float64 // hard to hold a line, branches fall
+	GustDangerous float64 // can knock a runner over
+
+	// Precipitation probability (%) and temperature rise (°C) over a run
+	DryProbability  float64 // below which an hour without precipitation counts as dry
+	RainLikely      float64 // from which rain gear is taken even when none is forecast
+	TemperatureRise float64 // from which runners are told to dress for the warmer end
+}
+
+// ScoringPenalties holds the points taken off the score for each weather band
+type ScoringPenalties struct {
+	ColdSevere         int
+	Cold               int
+	Cool               int
+	WBGTWarning        int
+	WBGTSevere         int
+	WBGTDanger         int
+	HumidityMuggy      int
+	HumidityOppressive int
+	HumidityDangerous  int
+	Wind               int
+	WindStrong         int
+	RainLight          int
+	Rain               int
+	RainHeavy          int
+	Showers            int
+	Thunderstorm       int
+	DustLow            int // dust level 1
+	DustModerate       int // dust level 2
+	DustHigh           int // dust level 3
+	DustVeryHigh       int // dust level 4
+	PM25Elevated       int // above PM25Standard
+	PM25High           int
+	PM25Alert          int
+	UVHigh             int
+	UVVeryHigh         int
+	UVExtreme          int
+	Gust               int
+	GustStrong         int
+	GustDangerous      int
+	RainChance         int // rain likely without forecast precipitation
+	TemperatureRise    int
+}
+
+// LevelCutoffs holds the lowest score of each level
+type LevelCutoffs struct {
+	Excellent int
+	Good      int
+	Fair      int
+	Caution   int
+}
+
+// DistancePenalties holds the extra penalties of a distance category
+type DistancePenalties struct {
+	Temperature int
+	Humidity    int
+	Wind        int
+	HeatIndex   int
+}
+
+// DefaultScoring returns the built-in scoring
+func DefaultScoring() Scoring {
+	return Scoring{
+		Thresholds: ScoringThresholds{
+			ColdSevere: 5,
+			Cold:       10,
+			Cool:       15,
+			Hot:        28,
+			VeryHot:    32,
+			Wind:       7,
+			WindStrong: 10,
+			Rain:       1,
+			RainHeavy:  5,
+
+			WBGTCaution: 21,
+			WBGTWarning: 25,
+			WBGTSevere:  28,
+			WBGTDanger:  31,
+
+			DewPointHumid:      13,
+			DewPointMuggy:      16,
+			DewPointOppressive: 18,
+			DewPointDangerous:  24,
+
+			PM25Standard: 35,
+			PM25High:     50,
+			PM25Alert:    70,
+
+			Mild:   20,
+			Warm:   25,
+			Summer: 30,
+
+			LongRunFuel: 20,
+			LongRunHot:  25,
+			MarathonHot: 22,
+
+			UVModerate: 3,
+			UVHigh:     6,
+			UVVeryHigh: 8,
+			UVExtreme:  11,
+
+			Gust:          12,
+			GustStrong:    15,
+			GustDangerous: 20,
+
+			DryProbability:  30,
+			RainLikely:      60,
+			TemperatureRise: 3,
+		},
+		Penalties: ScoringPenalties{
+			ColdSevere:         30,
+			Cold:               15,
+			Cool:               5,
+			WBGTWarning:        15,
+			WBGTSevere:         45,
+			WBGTDanger:         60,
+			HumidityMuggy:      10,
+			HumidityOppressive: 20,
+			HumidityDangerous:  30,
+			Wind:               10,
+			WindStrong:         25,
+			RainLight:          10,
+			Rain:               25,
+			RainHeavy:          40,
+			Showers:            30,
+			Thunderstorm:       50,
+			DustLow:            5,
+			DustModerate:       15,
+			DustHigh:           30,
+			DustVeryHigh:       50,
+			PM25Elevated:       5,
+			PM25High:           15,
+			PM25Alert:          30,
+			UVHigh:             5,
+			UVVeryHigh:         10,
+			UVExtreme:          20,
+			Gust:               5,
+			GustStrong:         15,
+			GustDangerous:      30,
+			RainChance:         5,
+			TemperatureRise:    5,
+		},
+		Levels: LevelCutoffs{
+			Excellent: 80,
+			Good:      60,
+			Fair:      40,
+			Caution:   20,
+		},
+		Distances: map[string]DistancePenalties{
+			"5k":   {},
+			"10k":  {Temperature: 3, Humidity: 2, Wind: 1, HeatIndex: 5},
+			"half": {Temperature: 7, Humidity: 5, Wind: 3, HeatIndex: 10},
+			"full": {Temperature: 15, Humidity: 10, Wind: 5, HeatIndex: 20},
+		},
+	}
+}
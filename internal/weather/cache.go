@@ -0,0 +1,248 @@
+package weather
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+// DefaultCacheTTL is how long cached responses are considered fresh
+const DefaultCacheTTL = 30 * time.Minute
+
+// ErrNoCachedData is returned in offline mode when nothing has been cached for a location
+var ErrNoCachedData error = noCachedDataError{}
+
+// noCachedDataError formats its message in the language selected when it is printed
+type noCachedDataError struct{}
+
+func (noCachedDataError) Error() string {
+	return i18n.T("error.no_cached_data")
+}
+
+// CachedProvider wraps a Provider and stores its responses on disk.
+// Fresh entries are served without contacting the upstream provider, and stale
+// entries are used as a fallback when the upstream request fails.
+// In offline mode the upstream provider is never contacted.
+type CachedProvider struct {
+	Provider Provider
+	Dir      string
+	TTL      time.Duration
+	Offline  bool
+
+	now func() time.Time
+}
+
+// cacheEntry is the on-disk representation of a cached response
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewCachedProvider creates a caching provider storing responses under dir
+func NewCachedProvider(provider Provider, dir string, ttl time.Duration, offline bool) *CachedProvider {
+	return &CachedProvider{
+		Provider: provider,
+		Dir:      dir,
+		TTL:      ttl,
+		Offline:  offline,
+		now:      time.Now,
+	}
+}
+
+// DefaultCacheDir returns the runcast directory under the user's cache dir ($XDG_CACHE_HOME or ~/.cache)
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "runcast"), nil
+}
+
+// Forecast returns cached forecast data when fresh, otherwise fetches and caches it
+func (c *CachedProvider) Forecast(lat, lon float64, timezone string, days int) (*types.WeatherData, error) {
+	forecastURL, _ := c.endpoints()
+	key := cacheKey("forecast", lat, lon, days, forecastURL+forecastCurrentParams+forecastDailyParams+forecastHourlyParams+forecastWindSpeedUnit+requestTimezone(timezone))
+
+	var weather types.WeatherData
+	fetchedAt, fromCache, err := c.load(key, &weather, func() (any, error) {
+		return c.Provider.Forecast(lat, lon, timezone, days)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	weather.FetchedAt = fetchedAt
+	weather.FromCache = fromCache
+	return &weather, nil
+}
+
+// AirQuality returns cached air quality data when fresh, otherwise fetches and caches it
+func (c *CachedProvider) AirQuality(lat, lon float64, timezone string, days int) (*types.AirQualityData, error) {
+	_, airQualityURL := c.endpoints()
+	key := cacheKey("airquality", lat, lon, days, airQualityURL+airQualityHourlyParams+requestTimezone(timezone))
+
+	var airQuality types.AirQualityData
+	if _, _, err := c.load(key, &airQuality, func() (any, error) {
+		return c.Provider.AirQuality(lat, lon, timezone, days)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &airQuality, nil
+}
+
+// endpoints returns the URLs the wrapped provider fetches from, empty when it has no configurable server
+func (c *CachedProvider) endpoints() (forecastURL, airQualityURL string) {
+	if provider, ok := c.Provider.(endpointProvider); ok {
+		return provider.Endpoints()
+	}
+	return "", ""
+}
+
+// load fills out from the cache or from fetch, returning when the data was fetched
+// and whether it came from the cache
+func (c *CachedProvider) load(key cacheFileKey, out any, fetch func() (any, error)) (time.Time, bool, error) {
+	entry, cacheErr := c.readEntry(key)
+
+	if c.Offline {
+		if cacheErr != nil {
+			// Another cached horizon of the same request is better than nothing offline
+			entry, cacheErr = c.readLatestEntry(key)
+		}
+		if cacheErr != nil {
+			return time.Time{}, false, ErrNoCachedData
+		}
+		if err := json.Unmarshal(entry.Data, out); err != nil {
+			return time.Time{}, false, fmt.Errorf("failed to decode cached data: %w", err)
+		}
+		return entry.FetchedAt, true, nil
+	}
+
+	if cacheErr == nil && c.now().Sub(entry.FetchedAt) < c.TTL {
+		if err := json.Unmarshal(entry.Data, out); err == nil {
+			return entry.FetchedAt, true, nil
+		}
+	}
+
+	data, err := fetch()
+	if err != nil {
+		// Fall back to stale data rather than failing outright
+		if cacheErr == nil {
+			if jsonErr := json.Unmarshal(entry.Data, out); jsonErr == nil {
+				return entry.FetchedAt, true, nil
+			}
+		}
+		return time.Time{}, false, err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to encode response: %w", err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	fetchedAt := c.now()
+	// Caching is best effort; a read-only cache dir must not break the CLI
+	_ = c.writeEntry(key, cacheEntry{FetchedAt: fetchedAt, Data: raw})
+
+	return fetchedAt, false, nil
+}
+
+// cacheFileKey identifies a cached response on disk
+type cacheFileKey struct {
+	prefix string // kind and rounded coordinates, shared by all horizons of a location
+	params string // hash of the parameter set, shared by all horizons of the same request
+	name   string // full file name including horizon and parameter set
+}
+
+// cacheKey builds a key from the rounded coordinates and requested parameter set, which includes the endpoint.
+// Coordinates are rounded to 0.01° (about 1km), well below the forecast grid resolution.
+func cacheKey(kind string, lat, lon float64, days int, params string) cacheFileKey {
+	sum := sha256.Sum256([]byte(params))
+	prefix := fmt.Sprintf("%s_%.2f_%.2f_", kind, lat, lon)
+	hash := hex.EncodeToString(sum[:4])
+	return cacheFileKey{
+		prefix: prefix,
+		params: hash,
+		name:   fmt.Sprintf("%sd%d_%s.json", prefix, days, hash),
+	}
+}
+
+// readEntry reads the cache entry for the exact key
+func (c *CachedProvider) readEntry(key cacheFileKey) (*cacheEntry, error) {
+	return readCacheFile(filepath.Join(c.Dir, key.name))
+}
+
+// readLatestEntry reads the most recently fetched entry for the same kind, location and parameter set
+// with any horizon. Entries of another endpoint, timezone or set of variables do not match.
+func (c *CachedProvider) readLatestEntry(key cacheFileKey) (*cacheEntry, error) {
+	matches, err := filepath.Glob(filepath.Join(c.Dir, key.prefix+"d*_"+key.params+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *cacheEntry
+	for _, path := range matches {
+		entry, err := readCacheFile(path)
+		if err != nil {
+			continue
+		}
+		if latest == nil || entry.FetchedAt.After(latest.FetchedAt) {
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return nil, ErrNoCachedData
+	}
+	return latest, nil
+}
+
+// writeEntry atomically writes a cache entry
+func (c *CachedProvider) writeEntry(key cacheFileKey, entry cacheEntry) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, key.name+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(c.Dir, key.name))
+}
+
+// readCacheFile reads and decodes a cache file
+func readCacheFile(path string) (*cacheEntry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
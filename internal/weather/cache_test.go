@@ -0,0 +1,282 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+// fakeProvider counts upstream calls and returns canned data
+type fakeProvider struct {
+	forecastCalls   int
+	airQualityCalls int
+	temperature     float64
+	endpoint        string
+	err             error
+}
+
+func (f *fakeProvider) Endpoints() (string, string) {
+	return f.endpoint, f.endpoint
+}
+
+func (f *fakeProvider) Forecast(lat, lon float64, timezone string, days int) (*types.WeatherData, error) {
+	f.forecastCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	data := &types.WeatherData{}
+	data.Current.Temperature = f.temperature
+	for i := 0; i < days; i++ {
+		data.Daily.Time = append(data.Daily.Time, time.Date(2025, 7, 5+i, 0, 0, 0, 0, time.UTC).Format("2006-01-02"))
+	}
+	return data, nil
+}
+
+func (f *fakeProvider) AirQuality(lat, lon float64, timezone string, days int) (*types.AirQualityData, error) {
+	f.airQualityCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	data := &types.AirQualityData{}
+	data.Hourly.Time = []string{"2025-07-05T00:00"}
+	data.Hourly.Dust = []float64{120}
+	return data, nil
+}
+
+func TestCachedProviderServesFreshEntries(t *testing.T) {
+	upstream := &fakeProvider{temperature: 21.5}
+	now := time.Date(2025, 7, 5, 7, 0, 0, 0, time.UTC)
+	cache := NewCachedProvider(upstream, t.TempDir(), 30*time.Minute, false)
+	cache.now = func() time.Time { return now }
+
+	first, err := cache.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	if first.FromCache {
+		t.Error("First response should come from the upstream provider")
+	}
+
+	// Nearby coordinates round to the same key
+	now = now.Add(10 * time.Minute)
+	second, err := cache.Forecast(35.6801, 139.6511, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	if upstream.forecastCalls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", upstream.forecastCalls)
+	}
+	if !second.FromCache {
+		t.Error("Second response should be served from cache")
+	}
+	if second.Current.Temperature != 21.5 {
+		t.Errorf("Expected cached temperature 21.5, got %f", second.Current.Temperature)
+	}
+	if !second.FetchedAt.Equal(now.Add(-10 * time.Minute)) {
+		t.Errorf("Expected fetch time %v, got %v", now.Add(-10*time.Minute), second.FetchedAt)
+	}
+
+	// A different horizon is a different parameter set
+	if _, err := cache.Forecast(35.6762, 139.6503, DefaultTimezone, 3); err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	if upstream.forecastCalls != 2 {
+		t.Errorf("Expected 2 upstream calls, got %d", upstream.forecastCalls)
+	}
+
+	// So is another timezone, whose local times differ
+	if _, err := cache.Forecast(35.6762, 139.6503, TimezoneAuto, 3); err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	if upstream.forecastCalls != 3 {
+		t.Errorf("Expected 3 upstream calls, got %d", upstream.forecastCalls)
+	}
+
+	// Air quality is cached separately
+	cache.AirQuality(35.6762, 139.6503, DefaultTimezone, 1)
+	cache.AirQuality(35.6762, 139.6503, DefaultTimezone, 1)
+	if upstream.airQualityCalls != 1 {
+		t.Errorf("Expected 1 air quality upstream call, got %d", upstream.airQualityCalls)
+	}
+}
+
+func TestCachedProviderRefreshesExpiredEntries(t *testing.T) {
+	upstream := &fakeProvider{temperature: 20.0}
+	now := time.Date(2025, 7, 5, 7, 0, 0, 0, time.UTC)
+	cache := NewCachedProvider(upstream, t.TempDir(), 30*time.Minute, false)
+	cache.now = func() time.Time { return now }
+
+	cache.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+
+	now = now.Add(time.Hour)
+	upstream.temperature = 25.0
+	data, err := cache.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	if upstream.forecastCalls != 2 {
+		t.Errorf("Expected expired entry to be refetched, got %d calls", upstream.forecastCalls)
+	}
+	if data.FromCache || data.Current.Temperature != 25.0 {
+		t.Errorf("Expected fresh data, got FromCache=%v temperature=%f", data.FromCache, data.Current.Temperature)
+	}
+
+	// Upstream failures fall back to stale data
+	now = now.Add(time.Hour)
+	upstream.err = errors.New("network unreachable")
+	data, err = cache.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Expected stale fallback, got error: %v", err)
+	}
+	if !data.FromCache || data.Current.Temperature != 25.0 {
+		t.Errorf("Expected stale cached data, got FromCache=%v temperature=%f", data.FromCache, data.Current.Temperature)
+	}
+}
+
+func TestCachedProviderOffline(t *testing.T) {
+	dir := t.TempDir()
+	upstream := &fakeProvider{temperature: 18.0}
+	now := time.Date(2025, 7, 5, 7, 0, 0, 0, time.UTC)
+
+	online := NewCachedProvider(upstream, dir, 30*time.Minute, false)
+	online.now = func() time.Time { return now }
+	online.Forecast(35.6762, 139.6503, DefaultTimezone, 3)
+
+	offline := NewCachedProvider(upstream, dir, 30*time.Minute, true)
+	offline.now = func() time.Time { return now.Add(24 * time.Hour) }
+
+	// Offline mode serves the last cached forecast regardless of TTL and horizon
+	data, err := offline.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Offline forecast failed: %v", err)
+	}
+	if upstream.forecastCalls != 1 {
+		t.Errorf("Offline mode must not call upstream, got %d calls", upstream.forecastCalls)
+	}
+	if !data.FromCache || !data.FetchedAt.Equal(now) {
+		t.Errorf("Expected cached data as of %v, got FromCache=%v FetchedAt=%v", now, data.FromCache, data.FetchedAt)
+	}
+	if len(data.Daily.Time) != 3 {
+		t.Errorf("Expected the cached 3-day forecast, got %d days", len(data.Daily.Time))
+	}
+
+	// Unknown locations have nothing to serve
+	if _, err := offline.Forecast(34.6937, 135.5023, DefaultTimezone, 1); !errors.Is(err, ErrNoCachedData) {
+		t.Errorf("Expected ErrNoCachedData, got %v", err)
+	}
+}
+
+func TestCachedProviderOfflineMatchesParameters(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 7, 5, 7, 0, 0, 0, time.UTC)
+	upstream := &fakeProvider{temperature: 20.0, endpoint: DefaultForecastURL}
+	mirror := &fakeProvider{temperature: 25.0, endpoint: "http://localhost:8080/v1/jma"}
+
+	online := NewCachedProvider(upstream, dir, 30*time.Minute, false)
+	online.now = func() time.Time { return now }
+	online.Forecast(35.6762, 139.6503, DefaultTimezone, 3)
+
+	// A newer entry of another endpoint and of another timezone must not be served instead
+	later := now.Add(time.Hour)
+	online.now = func() time.Time { return later }
+	online.Forecast(35.6762, 139.6503, "America/New_York", 1)
+	other := NewCachedProvider(mirror, dir, 30*time.Minute, false)
+	other.now = func() time.Time { return later }
+	other.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+
+	offline := NewCachedProvider(upstream, dir, 30*time.Minute, true)
+	offline.now = func() time.Time { return now.Add(24 * time.Hour) }
+	data, err := offline.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Offline forecast failed: %v", err)
+	}
+	if data.Current.Temperature != upstream.temperature || !data.FetchedAt.Equal(now) || len(data.Daily.Time) != 3 {
+		t.Errorf("Expected the 3-day forecast of the same request, got temperature %f fetched at %v", data.Current.Temperature, data.FetchedAt)
+	}
+
+	// Only other parameter sets are cached for this one
+	if _, err := offline.Forecast(35.6762, 139.6503, "Europe/London", 1); !errors.Is(err, ErrNoCachedData) {
+		t.Errorf("Expected ErrNoCachedData for an uncached timezone, got %v", err)
+	}
+}
+
+func TestCachedProviderStaleForecastDates(t *testing.T) {
+	dir := t.TempDir()
+	upstream := &fakeProvider{temperature: 18.0}
+	fetchedAt := time.Date(2025, 7, 5, 7, 0, 0, 0, time.UTC) // 16:00 on the 5th in Japan
+
+	online := NewCachedProvider(upstream, dir, 30*time.Minute, false)
+	online.now = func() time.Time { return fetchedAt }
+	online.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	online.Forecast(35.6762, 139.6503, DefaultTimezone, 3)
+
+	// A day later the cached days are looked up by date rather than counted from the first one
+	nextDay := fetchedAt.Add(24 * time.Hour)
+	offline := NewCachedProvider(upstream, dir, 30*time.Minute, true)
+	offline.now = func() time.Time { return nextDay }
+
+	data, err := offline.Forecast(35.6762, 139.6503, DefaultTimezone, 3)
+	if err != nil {
+		t.Fatalf("Offline forecast failed: %v", err)
+	}
+	tomorrow, err := ExtractForecastDays(data, ForecastDate(data, nextDay, 1), 1)
+	if err != nil {
+		t.Fatalf("Expected tomorrow in the cached 3-day forecast: %v", err)
+	}
+	if tomorrow.Daily.Time[0] != "2025-07-07" {
+		t.Errorf("Expected 2025-07-07, got %s", tomorrow.Daily.Time[0])
+	}
+	if _, err := ExtractForecastDays(data, ForecastDate(data, nextDay, 2), 1); err == nil {
+		t.Error("Expected an error for a day after the cached forecast")
+	}
+
+	// The 1-day forecast only covers the day it was fetched on, so it has no data for today
+	data, err = offline.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Offline forecast failed: %v", err)
+	}
+	if _, err := ExtractForecastDays(data, ForecastDate(data, nextDay, 0), 1); err == nil {
+		t.Errorf("Expected an error for today in a forecast of %v", data.Daily.Time)
+	}
+
+	// The stale fallback online is held to the same dates
+	upstream.err = errors.New("network unreachable")
+	online.now = func() time.Time { return nextDay }
+	data, err = online.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+	if err != nil {
+		t.Fatalf("Expected stale fallback, got error: %v", err)
+	}
+	if _, err := ExtractForecastDays(data, ForecastDate(data, nextDay, 0), 1); err == nil {
+		t.Errorf("Expected an error for today in the stale forecast of %v", data.Daily.Time)
+	}
+}
+
+func TestCachedProviderSeparatesEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 7, 5, 7, 0, 0, 0, time.UTC)
+	upstream := &fakeProvider{temperature: 20.0, endpoint: DefaultForecastURL}
+	mirror := &fakeProvider{temperature: 25.0, endpoint: "http://localhost:8080/v1/jma"}
+
+	for _, provider := range []*fakeProvider{upstream, mirror, upstream, mirror} {
+		cache := NewCachedProvider(provider, dir, 30*time.Minute, false)
+		cache.now = func() time.Time { return now }
+		data, err := cache.Forecast(35.6762, 139.6503, DefaultTimezone, 1)
+		if err != nil {
+			t.Fatalf("Forecast failed: %v", err)
+		}
+		if data.Current.Temperature != provider.temperature {
+			t.Errorf("Expected the data of %s, got temperature %f", provider.endpoint, data.Current.Temperature)
+		}
+		if _, err := cache.AirQuality(35.6762, 139.6503, DefaultTimezone, 1); err != nil {
+			t.Fatalf("AirQuality failed: %v", err)
+		}
+	}
+	if upstream.forecastCalls != 1 || mirror.forecastCalls != 1 {
+		t.Errorf("Expected one forecast call per endpoint, got %d and %d", upstream.forecastCalls, mirror.forecastCalls)
+	}
+	if upstream.airQualityCalls != 1 || mirror.airQualityCalls != 1 {
+		t.Errorf("Expected one air quality call per endpoint, got %d and %d", upstream.airQualityCalls, mirror.airQualityCalls)
+	}
+}
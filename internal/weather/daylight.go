@@ -0,0 +1,84 @@
+package weather
+
+import (
+	"math"
+	"time"
+
+	"runcast/internal/types"
+)
+
+// Solar altitudes (degrees) bounding civil twilight
+const (
+	sunriseAltitude   = -0.833 // upper limb on the horizon, including refraction
+	civilDawnAltitude = -6.0
+)
+
+// defaultTwilight is used where the sun never reaches these altitudes
+const defaultTwilight = 30 * time.Minute
+
+// ForecastTimeLayout is the layout of the local times in the forecast such as 2025-07-05T05:00
+const ForecastTimeLayout = "2006-01-02T15:04"
+
+// GetDaylight returns the sunrise, sunset and civil twilight of a date (YYYY-MM-DD) at the latitude.
+// It reports false when the forecast has no sun times for the date.
+func GetDaylight(weather *types.WeatherData, date string, lat float64) (*types.Daylight, bool) {
+	for i, day := range weather.Daily.Time {
+		if day != date {
+			continue
+		}
+
+		sunrise, err := time.ParseInLocation(ForecastTimeLayout, valueAt(weather.Daily.SunriseTime, i), ForecastLocation(weather))
+		if err != nil {
+			return nil, false
+		}
+		sunset, err := time.ParseInLocation(ForecastTimeLayout, valueAt(weather.Daily.SunsetTime, i), ForecastLocation(weather))
+		if err != nil {
+			return nil, false
+		}
+
+		twilight := CivilTwilightDuration(lat, sunrise)
+		daylight := &types.Daylight{
+			Sunrise:   sunrise,
+			Sunset:    sunset,
+			CivilDawn: sunrise.Add(-twilight),
+			CivilDusk: sunset.Add(twilight),
+			Duration:  sunset.Sub(sunrise),
+		}
+		if seconds := valueAt(weather.Daily.DaylightDuration, i); seconds > 0 {
+			daylight.Duration = time.Duration(seconds * float64(time.Second)).Round(time.Minute)
+		}
+		return daylight, true
+	}
+	return nil, false
+}
+
+// CivilTwilightDuration returns how long civil twilight lasts between sunrise and civil dawn on the date at the latitude
+func CivilTwilightDuration(lat float64, date time.Time) time.Duration {
+	// Approximate solar declination for the day of the year
+	declination := -23.44 * math.Cos(2*math.Pi/365*float64(date.YearDay()+10))
+
+	sunriseAngle, ok := solarHourAngle(lat, declination, sunriseAltitude)
+	if !ok {
+		return defaultTwilight
+	}
+	dawnAngle, ok := solarHourAngle(lat, declination, civilDawnAltitude)
+	if !ok {
+		return defaultTwilight
+	}
+
+	// The hour angle advances 15 degrees per hour
+	return time.Duration((dawnAngle - sunriseAngle) / 15 * float64(time.Hour)).Round(time.Minute)
+}
+
+// solarHourAngle returns the hour angle (degrees) at which the sun reaches the altitude,
+// or false when it stays above or below it all day
+func solarHourAngle(lat, declination, altitude float64) (float64, bool) {
+	latRad := lat * math.Pi / 180
+	declinationRad := declination * math.Pi / 180
+	cosAngle := (math.Sin(altitude*math.Pi/180) - math.Sin(latRad)*math.Sin(declinationRad)) /
+		(math.Cos(latRad) * math.Cos(declinationRad))
+	if cosAngle < -1 || cosAngle > 1 {
+		return 0, false
+	}
+	return math.Acos(cosAngle) * 180 / math.Pi, true
+}
@@ -0,0 +1,67 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"runcast/internal/types"
+)
+
+func TestCivilTwilightDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat      float64
+		date     string
+		expected time.Duration
+	}{
+		{name: "Tokyo at the equinox", lat: 35.6762, date: "2025-03-20", expected: 25 * time.Minute},
+		{name: "Equator at the equinox", lat: 0, date: "2025-03-20", expected: 21 * time.Minute},
+		{name: "Sapporo at midsummer", lat: 43.0642, date: "2025-06-21", expected: 35 * time.Minute},
+		{name: "Midnight sun", lat: 80, date: "2025-06-21", expected: defaultTwilight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse("2006-01-02", tt.date)
+			if got := CivilTwilightDuration(tt.lat, date); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetDaylight(t *testing.T) {
+	weather := &types.WeatherData{}
+	weather.Daily.Time = []string{"2025-07-05", "2025-07-06"}
+	weather.Daily.SunriseTime = []string{"2025-07-05T04:30", "2025-07-06T04:31"}
+	weather.Daily.SunsetTime = []string{"2025-07-05T19:01", "2025-07-06T19:01"}
+	weather.Daily.DaylightDuration = []float64{52200}
+
+	daylight, exists := GetDaylight(weather, "2025-07-05", 35.6762)
+	if !exists {
+		t.Fatal("Expected sun times for 2025-07-05")
+	}
+	if got := daylight.CivilDawn.Format("15:04"); got != "04:00" {
+		t.Errorf("Expected civil dawn at 04:00, got %s", got)
+	}
+	if got := daylight.CivilDusk.Format("15:04"); got != "19:31" {
+		t.Errorf("Expected civil dusk at 19:31, got %s", got)
+	}
+	if daylight.Duration != 14*time.Hour+30*time.Minute {
+		t.Errorf("Expected the daylight duration from the forecast, got %v", daylight.Duration)
+	}
+
+	// Without daylight_duration the sunset minus sunrise is used
+	daylight, exists = GetDaylight(weather, "2025-07-06", 35.6762)
+	if !exists || daylight.Duration != 14*time.Hour+30*time.Minute {
+		t.Errorf("Expected 14h30m of daylight on 2025-07-06, got %+v", daylight)
+	}
+
+	if _, exists := GetDaylight(weather, "2025-07-07", 35.6762); exists {
+		t.Error("Expected no sun times outside the forecast")
+	}
+	weather.Daily.SunriseTime = nil
+	if _, exists := GetDaylight(weather, "2025-07-05", 35.6762); exists {
+		t.Error("Expected no sun times when the forecast has none")
+	}
+}
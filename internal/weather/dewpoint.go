@@ -0,0 +1,30 @@
+package weather
+
+import (
+	"math"
+
+	"runcast/internal/types"
+)
+
+// Magnus formula coefficients over water (Alduchov & Eskridge, 1996)
+const (
+	magnusA = 17.625
+	magnusB = 243.04
+)
+
+// CalculateDewPoint calculates the dew point (°C) from air temperature (°C) and relative humidity (%)
+func CalculateDewPoint(temp, humidity float64) float64 {
+	humidity = math.Min(math.Max(humidity, 1), 100)
+	gamma := math.Log(humidity/100) + magnusA*temp/(magnusB+temp)
+	dewPoint := magnusB * gamma / (magnusA - gamma)
+	return math.Round(dewPoint*10) / 10
+}
+
+// hourlyDewPoint returns the dew point of an hourly entry,
+// calculating it from temperature and humidity when the response does not include it
+func hourlyDewPoint(weather *types.WeatherData, index int) float64 {
+	if index < len(weather.Hourly.DewPoint) {
+		return weather.Hourly.DewPoint[index]
+	}
+	return CalculateDewPoint(valueAt(weather.Hourly.Temperature, index), float64(valueAt(weather.Hourly.Humidity, index)))
+}
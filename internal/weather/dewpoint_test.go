@@ -0,0 +1,54 @@
+package weather
+
+import (
+	"testing"
+
+	"runcast/internal/types"
+)
+
+func TestCalculateDewPoint(t *testing.T) {
+	tests := []struct {
+		temp     float64
+		humidity float64
+		expected float64
+	}{
+		{temp: 20.0, humidity: 100, expected: 20.0},
+		{temp: 25.0, humidity: 60, expected: 16.7},
+		{temp: 30.0, humidity: 70, expected: 23.9},
+		{temp: 5.0, humidity: 40, expected: -7.5},
+	}
+
+	for _, tt := range tests {
+		if got := CalculateDewPoint(tt.temp, tt.humidity); got != tt.expected {
+			t.Errorf("CalculateDewPoint(%.1f, %.0f) = %.1f, expected %.1f", tt.temp, tt.humidity, got, tt.expected)
+		}
+	}
+}
+
+func TestExtractTimeBasedWeatherDewPoint(t *testing.T) {
+	weather := &types.WeatherData{
+		Hourly: types.HourlyWeather{
+			Time:          []string{"2025-07-05T05:00", "2025-07-05T06:00"},
+			Temperature:   []float64{25.0, 25.0},
+			ApparentTemp:  []float64{26.0, 26.0},
+			Humidity:      []int{60, 60},
+			WindSpeed:     []float64{2.0, 2.0},
+			WindDirection: []float64{0, 0},
+			Precipitation: []float64{0, 0},
+			WeatherCode:   []int{0, 0},
+			DewPoint:      []float64{18.2},
+		},
+	}
+
+	timeData := ExtractTimeBasedWeather(weather, "morning", 1)
+	if len(timeData) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(timeData))
+	}
+	if timeData[0].DewPoint != 18.2 {
+		t.Errorf("Expected the dew point from the response, got %.1f", timeData[0].DewPoint)
+	}
+	// Missing values are calculated from temperature and humidity
+	if timeData[1].DewPoint != 16.7 {
+		t.Errorf("Expected a calculated dew point of 16.7, got %.1f", timeData[1].DewPoint)
+	}
+}
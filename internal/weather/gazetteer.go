@@ -0,0 +1,249 @@
+package weather
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"runcast/internal/i18n"
+	"runcast/internal/types"
+)
+
+//go:embed gazetteer.tsv
+var gazetteerData string
+
+// Municipality is a Japanese municipality (市区町村) of the embedded gazetteer
+type Municipality struct {
+	Key           string // romaji, e.g. setagaya
+	Name          string // e.g. 世田谷区
+	PrefectureKey string // romaji, e.g. tokyo
+	Prefecture    string // e.g. 東京都
+	Lat           float64
+	Lon           float64
+}
+
+var (
+	gazetteerOnce sync.Once
+	gazetteer     []Municipality
+)
+
+// romajiSuffixes are the municipality types that may follow a romaji name, e.g. setagaya-ku
+var romajiSuffixes = []string{"-shi", "-ku", "-cho", "-machi", "-mura", "-son", "-city", "-ward", "-town", "-village"}
+
+// kanjiSuffixes are the municipality types ending a kanji name, e.g. 世田谷区
+var kanjiSuffixes = []string{"市", "区", "町", "村"}
+
+// Gazetteer returns the municipalities of the embedded gazetteer
+func Gazetteer() []Municipality {
+	gazetteerOnce.Do(func() {
+		gazetteer = parseGazetteer(gazetteerData)
+	})
+	return gazetteer
+}
+
+// parseGazetteer parses the tab-separated gazetteer, skipping comment lines
+func parseGazetteer(data string) []Municipality {
+	var municipalities []Municipality
+	for number, line := range strings.Split(data, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 6 {
+			panic(fmt.Sprintf("gazetteer line %d: expected 6 fields, got %d", number+1, len(fields)))
+		}
+		lat, latErr := strconv.ParseFloat(fields[4], 64)
+		lon, lonErr := strconv.ParseFloat(fields[5], 64)
+		if err := errors.Join(latErr, lonErr); err != nil {
+			panic(fmt.Sprintf("gazetteer line %d: %v", number+1, err))
+		}
+		municipalities = append(municipalities, Municipality{
+			Key:           fields[0],
+			Name:          fields[1],
+			PrefectureKey: fields[2],
+			Prefecture:    fields[3],
+			Lat:           lat,
+			Lon:           lon,
+		})
+	}
+	return municipalities
+}
+
+// QualifiedKey returns the key followed by the prefecture, e.g. fuchu-hiroshima,
+// which tells apart the municipalities sharing a name
+func (m Municipality) QualifiedKey() string {
+	return m.Key + "-" + m.PrefectureKey
+}
+
+// DisplayName returns the kanji name in Japanese and the capitalized romaji otherwise
+func (m Municipality) DisplayName() string {
+	if i18n.Language() == "ja" {
+		return m.Name
+	}
+	return strings.ToUpper(m.Key[:1]) + m.Key[1:]
+}
+
+// Coordinate returns the coordinates of the municipal office with the display name
+func (m Municipality) Coordinate() *types.CityCoordinate {
+	return &types.CityCoordinate{Name: m.DisplayName(), Lat: m.Lat, Lon: m.Lon}
+}
+
+// FindMunicipality looks a place name up in the gazetteer by romaji or kanji, optionally with the
+// municipality type (setagaya-ku, 世田谷区) or the prefecture (fuchu-hiroshima, 広島県府中市).
+// An exact match is tried first, then a prefix of the name and finally, for romaji, a name
+// with a typo or two. It returns nil when nothing matches and an error listing the
+// candidates when the name matches several municipalities.
+func FindMunicipality(query string) (*Municipality, error) {
+	name := normalizePlaceName(query)
+	if name == "" {
+		return nil, nil
+	}
+	municipalities := Gazetteer()
+	// A town or village sharing its reading with another municipality of the prefecture keeps
+	// the type in its key, so fuchu-cho finds fuchucho rather than the cities named fuchu
+	joined := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(query)))
+
+	var typed, exact, prefix []int
+	for i, m := range municipalities {
+		if joined != name && m.Key == joined {
+			typed = append(typed, i)
+		}
+		if matchesMunicipality(m, name) {
+			exact = append(exact, i)
+		} else if hasNamePrefix(m, name) {
+			prefix = append(prefix, i)
+		}
+	}
+	if len(typed) > 0 {
+		return pickMunicipality(query, typed)
+	}
+	if len(exact) > 0 {
+		return pickMunicipality(query, exact)
+	}
+	if len(prefix) > 0 {
+		return pickMunicipality(query, prefix)
+	}
+	return pickMunicipality(query, closestMunicipalities(name))
+}
+
+// normalizePlaceName lowercases a romaji name, joins its words with hyphens and drops the municipality type
+func normalizePlaceName(query string) string {
+	name := strings.ToLower(strings.TrimSpace(query))
+	name = strings.NewReplacer(" ", "-", "_", "-").Replace(name)
+	for _, suffix := range romajiSuffixes {
+		if trimmed, found := strings.CutSuffix(name, suffix); found && trimmed != "" {
+			return trimmed
+		}
+	}
+	return name
+}
+
+// matchesMunicipality reports whether the normalized name is one of the names of m
+func matchesMunicipality(m Municipality, name string) bool {
+	base := trimKanjiSuffix(m.Name)
+	switch name {
+	case m.Key, m.QualifiedKey(), m.Name, base, m.Prefecture + m.Name, m.Prefecture + base:
+		return true
+	}
+	return false
+}
+
+// hasNamePrefix reports whether the normalized name starts the romaji or kanji name of m.
+// At least three letters of romaji or two characters of kanji are needed.
+func hasNamePrefix(m Municipality, name string) bool {
+	if isASCII(name) {
+		return len(name) >= 3 && strings.HasPrefix(m.Key, name)
+	}
+	return utf8.RuneCountInString(name) >= 2 && strings.HasPrefix(m.Name, name)
+}
+
+// closestMunicipalities returns the municipalities whose romaji is closest to the name,
+// within one edit for names under eight letters and two for longer ones
+func closestMunicipalities(name string) []int {
+	if !isASCII(name) || len(name) < 4 {
+		return nil
+	}
+	best := 1
+	if len(name) >= 8 {
+		best = 2
+	}
+
+	var closest []int
+	for i, m := range Gazetteer() {
+		distance := editDistance(name, m.Key)
+		if distance < best {
+			best = distance
+			closest = nil
+		}
+		if distance == best {
+			closest = append(closest, i)
+		}
+	}
+	return closest
+}
+
+// pickMunicipality returns the only matched municipality, or an error listing the candidates
+func pickMunicipality(query string, matches []int) (*Municipality, error) {
+	municipalities := Gazetteer()
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		m := municipalities[matches[0]]
+		return &m, nil
+	}
+
+	candidates := make([]string, len(matches))
+	for i, index := range matches {
+		m := municipalities[index]
+		candidates[i] = fmt.Sprintf("%s (%s%s)", m.QualifiedKey(), m.Prefecture, m.Name)
+	}
+	sort.Strings(candidates)
+	return nil, errors.New(i18n.T("error.city_ambiguous", query, strings.Join(candidates, ", ")))
+}
+
+// trimKanjiSuffix removes the municipality type from a kanji name
+func trimKanjiSuffix(name string) string {
+	for _, suffix := range kanjiSuffixes {
+		if trimmed, found := strings.CutSuffix(name, suffix); found && trimmed != "" {
+			return trimmed
+		}
+	}
+	return name
+}
+
+// isASCII reports whether s holds only ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// editDistance returns the Levenshtein distance between two ASCII strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,390 @@
+//go:build ignore
+
+// gazetteer_gen regenerates gazetteer.tsv from official data:
+//
+//   - the list of local government codes (全国地方公共団体コード) of the Ministry of Internal Affairs
+//     and Communications, saved as UTF-8 CSV with the columns 団体コード, 都道府県名（漢字）,
+//     市区町村名（漢字）, 都道府県名（カナ）, 市区町村名（カナ）, which gives the names and readings
+//   - the municipal offices (市町村役場等及び公的集会施設, P34) of 国土数値情報 as GeoJSON,
+//     which gives the coordinates of each main office
+//
+// Usage:
+//
+//	go run gazetteer_gen.go -codes codes.csv -offices P34-14_01.geojson,P34-14_02.geojson,... > gazetteer.tsv
+//
+// The romaji is derived from the katakana reading in the simplified Hepburn style used across
+// runcast: long vowels are dropped (とうきょう → tokyo) and ん is always n. The municipality type
+// (市, 区, 町, 村) is left out of the key; a town or village that would share its key with another
+// municipality of the prefecture keeps it (利島村 → toshimamura beside 豊島区 toshima).
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// keyOverrides holds the keys of municipalities whose reading and type are both shared within
+// a prefecture, and of those whose vowels meet across a word boundary (勝+浦 is not a long vowel)
+var keyOverrides = map[string]string{
+	"01514": "esashi-soya", // 枝幸町, which reads like 江差町 of the same prefecture
+	"12218": "katsuura",
+	"30421": "nachikatsuura",
+	"36321": "katsuura",
+	"42208": "matsuura",
+}
+
+// prefectureSuffixes are the readings of the prefecture types dropped from the prefecture key
+var prefectureSuffixes = []string{"ト", "フ", "ケン"}
+
+// typeReadings are the readings of the municipality types, tried in order
+var typeReadings = map[string][]string{
+	"市": {"シ"},
+	"区": {"ク"},
+	"町": {"マチ", "チョウ"},
+	"村": {"ムラ", "ソン"},
+}
+
+type municipality struct {
+	code       string // five-digit code without the check digit
+	key        string
+	typeKey    string // romaji of the municipality type
+	name       string
+	prefKey    string
+	prefecture string
+	lat, lon   float64
+}
+
+type office struct {
+	code     string
+	name     string
+	lat, lon float64
+}
+
+func main() {
+	codesPath := flag.String("codes", "", "CSV of the local government codes")
+	officesPaths := flag.String("offices", "", "comma-separated GeoJSON files of the P34 municipal offices")
+	officeClass := flag.String("office-class", "1", "P34_002 facility class of the main municipal offices")
+	flag.Parse()
+	if *codesPath == "" || *officesPaths == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	municipalities, err := readCodes(*codesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var offices []office
+	for _, path := range strings.Split(*officesPaths, ",") {
+		found, err := readOffices(path, *officeClass)
+		if err != nil {
+			log.Fatal(err)
+		}
+		offices = append(offices, found...)
+	}
+
+	for i := range municipalities {
+		m := &municipalities[i]
+		o, ok := findOffice(offices, m)
+		if !ok {
+			log.Fatalf("no office found for %s %s%s", m.code, m.prefecture, m.name)
+		}
+		m.lat, m.lon = o.lat, o.lon
+	}
+	disambiguate(municipalities)
+
+	fmt.Println("# Japanese municipalities (市区町村) searched by -city: romaji, name, prefecture (romaji), prefecture, latitude, longitude of the municipal office.")
+	fmt.Printf("# All %d municipalities, the 23 special wards included. Regenerate with gazetteer_gen.go from the local government codes of MIC and the municipal offices (P34) of 国土数値情報.\n", len(municipalities))
+	for _, m := range municipalities {
+		fmt.Printf("%s\t%s\t%s\t%s\t%.4f\t%.4f\n", m.key, m.name, m.prefKey, m.prefecture, m.lat, m.lon)
+	}
+}
+
+// readCodes reads the municipalities of the code list, skipping the prefectures and the wards of
+// designated cities
+func readCodes(path string) ([]municipality, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	var municipalities []municipality
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 || len(record) < 5 || strings.TrimSpace(record[2]) == "" {
+			continue
+		}
+		name := strings.TrimSpace(record[2])
+		if strings.Contains(name, "市") && strings.HasSuffix(name, "区") {
+			continue // a ward of a designated city, searched through the city
+		}
+
+		code := strings.TrimSpace(record[0])
+		if len(code) == 6 {
+			code = code[:5] // drop the check digit
+		}
+		reading := toFullWidth(strings.TrimSpace(record[4]))
+		kind := lastRune(name)
+		typeKey := ""
+		for _, typeReading := range typeReadings[kind] {
+			if trimmed, found := strings.CutSuffix(reading, typeReading); found {
+				reading = trimmed
+				typeKey = romanize(typeReading)
+				break
+			}
+		}
+
+		prefReading := toFullWidth(strings.TrimSpace(record[3]))
+		for _, suffix := range prefectureSuffixes {
+			if trimmed, found := strings.CutSuffix(prefReading, suffix); found && trimmed != "" {
+				prefReading = trimmed
+				break
+			}
+		}
+
+		municipalities = append(municipalities, municipality{
+			code:       code,
+			key:        romanize(reading),
+			typeKey:    typeKey,
+			name:       name,
+			prefKey:    romanize(prefReading),
+			prefecture: strings.TrimSpace(record[1]),
+		})
+	}
+	sort.Slice(municipalities, func(i, j int) bool { return municipalities[i].code < municipalities[j].code })
+	return municipalities, nil
+}
+
+// readOffices reads the main municipal offices of a P34 GeoJSON file
+func readOffices(path, class string) ([]office, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var collection struct {
+		Features []struct {
+			Properties map[string]any `json:"properties"`
+			Geometry   struct {
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(raw, &collection); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var offices []office
+	for _, feature := range collection.Features {
+		if fmt.Sprint(feature.Properties["P34_002"]) != class || len(feature.Geometry.Coordinates) < 2 {
+			continue
+		}
+		offices = append(offices, office{
+			code: fmt.Sprint(feature.Properties["P34_001"]),
+			name: fmt.Sprint(feature.Properties["P34_003"]),
+			lon:  feature.Geometry.Coordinates[0],
+			lat:  feature.Geometry.Coordinates[1],
+		})
+	}
+	return offices, nil
+}
+
+// findOffice returns the main office of a municipality by its code, or for designated cities,
+// whose offices are listed under their wards, by the name of the city hall
+func findOffice(offices []office, m *municipality) (office, bool) {
+	for _, o := range offices {
+		if o.code == m.code {
+			return o, true
+		}
+	}
+	for _, o := range offices {
+		if o.code[:2] == m.code[:2] && strings.HasPrefix(o.name, m.name+"役所") {
+			return o, true
+		}
+	}
+	return office{}, false
+}
+
+// disambiguate appends the type to the keys of the towns and villages that share a key with
+// another municipality of the same prefecture, then applies keyOverrides
+func disambiguate(municipalities []municipality) {
+	count := make(map[string]int)
+	for _, m := range municipalities {
+		count[m.prefKey+"/"+m.key]++
+	}
+	for i := range municipalities {
+		m := &municipalities[i]
+		if count[m.prefKey+"/"+m.key] > 1 && m.typeKey != "shi" && m.typeKey != "ku" {
+			m.key += m.typeKey
+		}
+		if key, exists := keyOverrides[m.code]; exists {
+			m.key = key
+		}
+	}
+}
+
+// lastRune returns the last character of s
+func lastRune(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return ""
+	}
+	return string(runes[len(runes)-1])
+}
+
+// halfWidth maps the half-width katakana of the code list to full-width katakana
+var halfWidth = map[rune]rune{
+	'ｦ': 'ヲ', 'ｧ': 'ァ', 'ｨ': 'ィ', 'ｩ': 'ゥ', 'ｪ': 'ェ', 'ｫ': 'ォ', 'ｬ': 'ャ', 'ｭ': 'ュ', 'ｮ': 'ョ', 'ｯ': 'ッ',
+	'ｰ': 'ー', 'ｱ': 'ア', 'ｲ': 'イ', 'ｳ': 'ウ', 'ｴ': 'エ', 'ｵ': 'オ', 'ｶ': 'カ', 'ｷ': 'キ', 'ｸ': 'ク', 'ｹ': 'ケ',
+	'ｺ': 'コ', 'ｻ': 'サ', 'ｼ': 'シ', 'ｽ': 'ス', 'ｾ': 'セ', 'ｿ': 'ソ', 'ﾀ': 'タ', 'ﾁ': 'チ', 'ﾂ': 'ツ', 'ﾃ': 'テ',
+	'ﾄ': 'ト', 'ﾅ': 'ナ', 'ﾆ': 'ニ', 'ﾇ': 'ヌ', 'ﾈ': 'ネ', 'ﾉ': 'ノ', 'ﾊ': 'ハ', 'ﾋ': 'ヒ', 'ﾌ': 'フ', 'ﾍ': 'ヘ',
+	'ﾎ': 'ホ', 'ﾏ': 'マ', 'ﾐ': 'ミ', 'ﾑ': 'ム', 'ﾒ': 'メ', 'ﾓ': 'モ', 'ﾔ': 'ヤ', 'ﾕ': 'ユ', 'ﾖ': 'ヨ', 'ﾗ': 'ラ',
+	'ﾘ': 'リ', 'ﾙ': 'ル', 'ﾚ': 'レ', 'ﾛ': 'ロ', 'ﾜ': 'ワ', 'ﾝ': 'ン',
+}
+
+// voiced and semiVoiced combine a katakana with a following dakuten or handakuten
+var (
+	voiced = map[rune]rune{
+		'カ': 'ガ', 'キ': 'ギ', 'ク': 'グ', 'ケ': 'ゲ', 'コ': 'ゴ', 'サ': 'ザ', 'シ': 'ジ', 'ス': 'ズ', 'セ': 'ゼ', 'ソ': 'ゾ',
+		'タ': 'ダ', 'チ': 'ヂ', 'ツ': 'ヅ', 'テ': 'デ', 'ト': 'ド', 'ハ': 'バ', 'ヒ': 'ビ', 'フ': 'ブ', 'ヘ': 'ベ', 'ホ': 'ボ',
+		'ウ': 'ヴ',
+	}
+	semiVoiced = map[rune]rune{'ハ': 'パ', 'ヒ': 'ピ', 'フ': 'プ', 'ヘ': 'ペ', 'ホ': 'ポ'}
+)
+
+// toFullWidth converts half-width katakana to full-width, combining the voicing marks
+func toFullWidth(s string) string {
+	var out []rune
+	for _, r := range s {
+		switch r {
+		case 'ﾞ', '゛':
+			if n := len(out); n > 0 {
+				if v, ok := voiced[out[n-1]]; ok {
+					out[n-1] = v
+				}
+			}
+			continue
+		case 'ﾟ', '゜':
+			if n := len(out); n > 0 {
+				if v, ok := semiVoiced[out[n-1]]; ok {
+					out[n-1] = v
+				}
+			}
+			continue
+		case ' ', '　':
+			continue
+		}
+		if full, ok := halfWidth[r]; ok {
+			r = full
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// digraphs are the romaji of katakana followed by a small ya, yu or yo
+var digraphs = map[string]string{
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo", "シャ": "sha", "シュ": "shu", "ショ": "sho",
+	"チャ": "cha", "チュ": "chu", "チョ": "cho", "ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo", "ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo", "ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジョ": "jo", "ヂャ": "ja", "ヂュ": "ju", "ヂョ": "jo",
+	"ビャ": "bya", "ビュ": "byu", "ビョ": "byo", "ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo",
+}
+
+// monographs are the romaji of single katakana
+var monographs = map[rune]string{
+	'ア': "a", 'イ': "i", 'ウ': "u", 'エ': "e", 'オ': "o",
+	'カ': "ka", 'キ': "ki", 'ク': "ku", 'ケ': "ke", 'コ': "ko",
+	'サ': "sa", 'シ': "shi", 'ス': "su", 'セ': "se", 'ソ': "so",
+	'タ': "ta", 'チ': "chi", 'ツ': "tsu", 'テ': "te", 'ト': "to",
+	'ナ': "na", 'ニ': "ni", 'ヌ': "nu", 'ネ': "ne", 'ノ': "no",
+	'ハ': "ha", 'ヒ': "hi", 'フ': "fu", 'ヘ': "he", 'ホ': "ho",
+	'マ': "ma", 'ミ': "mi", 'ム': "mu", 'メ': "me", 'モ': "mo",
+	'ヤ': "ya", 'ユ': "yu", 'ヨ': "yo",
+	'ラ': "ra", 'リ': "ri", 'ル': "ru", 'レ': "re", 'ロ': "ro",
+	'ワ': "wa", 'ヲ': "o", 'ン': "n",
+	'ガ': "ga", 'ギ': "gi", 'グ': "gu", 'ゲ': "ge", 'ゴ': "go",
+	'ザ': "za", 'ジ': "ji", 'ズ': "zu", 'ゼ': "ze", 'ゾ': "zo",
+	'ダ': "da", 'ヂ': "ji", 'ヅ': "zu", 'デ': "de", 'ド': "do",
+	'バ': "ba", 'ビ': "bi", 'ブ': "bu", 'ベ': "be", 'ボ': "bo",
+	'パ': "pa", 'ピ': "pi", 'プ': "pu", 'ペ': "pe", 'ポ': "po",
+	'ヴ': "vu", 'ァ': "a", 'ィ': "i", 'ゥ': "u", 'ェ': "e", 'ォ': "o",
+}
+
+// romanize converts full-width katakana to simplified Hepburn, dropping long vowels
+func romanize(kana string) string {
+	runes := []rune(kana)
+	var syllables []string
+	for i := 0; i < len(runes); i++ {
+		if i+1 < len(runes) {
+			if romaji, ok := digraphs[string(runes[i:i+2])]; ok {
+				syllables = append(syllables, romaji)
+				i++
+				continue
+			}
+		}
+		switch runes[i] {
+		case 'ッ':
+			syllables = append(syllables, "ッ")
+			continue
+		case 'ー':
+			continue
+		}
+		syllables = append(syllables, monographs[runes[i]])
+	}
+
+	var b strings.Builder
+	previous := ""
+	for i, syllable := range syllables {
+		if syllable == "ッ" {
+			// Double the consonant of the next syllable, tch for ch
+			if i+1 < len(syllables) && syllables[i+1] != "" {
+				next := syllables[i+1]
+				if strings.HasPrefix(next, "ch") {
+					b.WriteString("t")
+				} else {
+					b.WriteByte(next[0])
+				}
+			}
+			continue
+		}
+		// Long vowels: ou, oo and uu are written with a single vowel, once per syllable so
+		// that すおうおおしま is suooshima
+		vowel := lastVowel(previous)
+		if (syllable == "u" && (vowel == 'o' || vowel == 'u')) || (syllable == "o" && vowel == 'o') {
+			previous = ""
+			continue
+		}
+		b.WriteString(syllable)
+		previous = syllable
+	}
+	return b.String()
+}
+
+// lastVowel returns the vowel ending a romaji syllable, or 0
+func lastVowel(syllable string) byte {
+	if syllable == "" {
+		return 0
+	}
+	last := syllable[len(syllable)-1]
+	if strings.IndexByte("aiueo", last) >= 0 {
+		return last
+	}
+	return 0
+}
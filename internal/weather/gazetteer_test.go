@@ -0,0 +1,169 @@
+package weather
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGazetteer(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, m := range Gazetteer() {
+		if seen[m.QualifiedKey()] {
+			t.Errorf("Duplicate municipality %s", m.QualifiedKey())
+		}
+		seen[m.QualifiedKey()] = true
+		// Japan lies within these bounds
+		if m.Lat < 20 || m.Lat > 46 || m.Lon < 122 || m.Lon > 154 {
+			t.Errorf("%s lies outside Japan: %f, %f", m.QualifiedKey(), m.Lat, m.Lon)
+		}
+		if m.Key != strings.ToLower(m.Key) || trimKanjiSuffix(m.Name) == m.Name {
+			t.Errorf("Unexpected names for %s: %s", m.QualifiedKey(), m.Name)
+		}
+	}
+	// The 1,718 cities, towns and villages and the 23 special wards of Tokyo
+	if len(seen) != 1741 {
+		t.Errorf("Expected 1741 municipalities, got %d", len(seen))
+	}
+}
+
+func TestGazetteerSmallMunicipalities(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		lat, lon float64 // rough position of the office
+	}{
+		{"音威子府村", "otoineppu-hokkaido", 44.7, 142.3},
+		{"hinoemata", "hinoemata-fukushima", 37.0, 139.4},
+		{"青ヶ島村", "aogashima-tokyo", 32.5, 139.8},
+		{"小笠原村", "ogasawara-tokyo", 27.1, 142.2},
+		{"toshima-mura", "toshimamura-tokyo", 34.5, 139.3},
+		{"野沢温泉村", "nozawaonsen-nagano", 36.9, 138.4},
+		{"totsukawa", "totsukawa-nara", 34.0, 135.8},
+		{"kitadaito", "kitadaito-okinawa", 25.9, 131.3},
+		{"与那国町", "yonaguni-okinawa", 24.5, 123.0},
+	}
+
+	for _, tt := range tests {
+		m, err := FindMunicipality(tt.query)
+		if err != nil || m == nil {
+			t.Errorf("Expected %s for %s, got %v, %v", tt.expected, tt.query, m, err)
+			continue
+		}
+		if m.QualifiedKey() != tt.expected {
+			t.Errorf("Expected %s for %s, got %s", tt.expected, tt.query, m.QualifiedKey())
+		}
+		if math.Abs(m.Lat-tt.lat) > 0.2 || math.Abs(m.Lon-tt.lon) > 0.2 {
+			t.Errorf("Unexpected position of %s: %f, %f", tt.expected, m.Lat, m.Lon)
+		}
+	}
+}
+
+func TestGazetteerPrefectures(t *testing.T) {
+	counts := make(map[string]int)
+	for _, m := range Gazetteer() {
+		counts[m.Prefecture]++
+	}
+	expected := map[string]int{"北海道": 179, "東京都": 62, "長野県": 77, "大阪府": 43, "鳥取県": 19, "沖縄県": 41}
+	for prefecture, count := range expected {
+		if counts[prefecture] != count {
+			t.Errorf("Expected %d municipalities in %s, got %d", count, prefecture, counts[prefecture])
+		}
+	}
+	if len(counts) != 47 {
+		t.Errorf("Expected 47 prefectures, got %d", len(counts))
+	}
+}
+
+func TestFindMunicipality(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expected    string // qualified key, empty when nothing matches
+		expectError bool
+	}{
+		{"romaji", "setagaya", "setagaya-tokyo", false},
+		{"romaji with type", "Setagaya-ku", "setagaya-tokyo", false},
+		{"romaji with space", "setagaya ku", "setagaya-tokyo", false},
+		{"kanji", "世田谷区", "setagaya-tokyo", false},
+		{"kanji without type", "世田谷", "setagaya-tokyo", false},
+		{"kanji with prefecture", "広島県府中市", "fuchu-hiroshima", false},
+		{"romaji with prefecture", "fuchu-hiroshima", "fuchu-hiroshima", false},
+		{"single kanji", "津", "tsu-mie", false},
+		{"unknown prefix", "kichij", "", false},
+		{"romaji prefix unique", "musashimura", "musashimurayama-tokyo", false},
+		{"romaji with a shared reading", "fuchu-cho", "fuchucho-hiroshima", false},
+		{"kanji prefix", "八王", "hachioji-tokyo", false},
+		{"typo", "setagya", "setagaya-tokyo", false},
+		{"no close name", "kichijyoji", "", false},
+		{"ambiguous name", "fuchu", "", true},
+		{"ambiguous kanji", "草津", "", true},
+		{"ambiguous prefix", "chi", "", true},
+		{"unknown", "atlantis", "", false},
+		{"empty", " ", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := FindMunicipality(tt.query)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error for %s, got %+v", tt.query, m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %s: %v", tt.query, err)
+			}
+			key := ""
+			if m != nil {
+				key = m.QualifiedKey()
+			}
+			if key != tt.expected {
+				t.Errorf("Expected %q for %s, got %q", tt.expected, tt.query, key)
+			}
+		})
+	}
+}
+
+func TestFindMunicipalityAmbiguousCandidates(t *testing.T) {
+	_, err := FindMunicipality("fuchu")
+	if err == nil || !strings.Contains(err.Error(), "fuchu-tokyo") || !strings.Contains(err.Error(), "fuchu-hiroshima") {
+		t.Errorf("Expected both candidates in the error, got %v", err)
+	}
+}
+
+func TestResolveCityCoordinateMunicipality(t *testing.T) {
+	coord, err := ResolveCityCoordinate("setagaya", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if coord.Name != "世田谷区" || coord.Lat != 35.6464 || coord.Lon != 139.6532 {
+		t.Errorf("Unexpected coordinate %+v", coord)
+	}
+
+	// Built-in cities take precedence over the municipalities of the same name
+	coord, _ = ResolveCityCoordinate("tokyo", nil)
+	if coord.Name != "東京" {
+		t.Errorf("Expected the built-in city, got %+v", coord)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"setagaya", "setagaya", 0},
+		{"setagya", "setagaya", 1},
+		{"setagaya", "setagawa", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if distance := editDistance(tt.a, tt.b); distance != tt.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", tt.a, tt.b, distance, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/types"
+)
+
+// DefaultForecastURL is the Open-Meteo JMA forecast endpoint
+const DefaultForecastURL = "https://api.open-meteo.com/v1/jma"
+
+// DefaultAirQualityURL is the Open-Meteo Air Quality endpoint
+const DefaultAirQualityURL = "https://air-quality-api.open-meteo.com/v1/air-quality"
+
+// DefaultTimeout is the HTTP timeout used when none is configured
+const DefaultTimeout = 10 * time.Second
+
+// MaxForecastDays is the longest forecast horizon offered by the Open-Meteo JMA API
+const MaxForecastDays = 11
+
+// MaxAirQualityForecastDays is the longest forecast horizon offered by the Open-Meteo Air Quality API
+const MaxAirQualityForecastDays = 7
+
+// Variables requested from the Open-Meteo APIs
+const (
+	forecastCurrentParams  = "temperature_2m,apparent_temperature,relative_humidity_2m,wind_speed_10m,wind_direction_10m,weather_code,precipitation,dew_point_2m,shortwave_radiation,uv_index,wind_gusts_10m"
+	forecastDailyParams    = "temperature_2m_max,temperature_2m_min,weather_code,wind_speed_10m_max,wind_gusts_10m_max,wind_direction_10m_dominant,precipitation_sum,precipitation_probability_max,precipitation_hours,sunrise,sunset,daylight_duration,uv_index_max,uv_index_clear_sky_max"
+	forecastHourlyParams   = "temperature_2m,apparent_temperature,relative_humidity_2m,wind_speed_10m,wind_direction_10m,weather_code,precipitation,dew_point_2m,shortwave_radiation,uv_index,wind_gusts_10m,precipitation_probability"
+	airQualityHourlyParams = "dust,pm10,pm2_5"
+)
+
+// forecastWindSpeedUnit is the wind speed unit requested from the forecast API.
+// Thresholds and the WBGT estimate expect m/s rather than the API default km/h.
+const forecastWindSpeedUnit = "ms"
+
+// Provider fetches forecast and air quality data for a location
+type Provider interface {
+	// Forecast returns current, hourly and daily weather for the given number of days in local times of the timezone
+	Forecast(lat, lon float64, timezone string, days int) (*types.WeatherData, error)
+	// AirQuality returns hourly dust and particulate data for the given number of days in local times of the timezone
+	AirQuality(lat, lon float64, timezone string, days int) (*types.AirQualityData, error)
+}
+
+// endpointProvider is implemented by providers whose data depends on a configurable server,
+// so that the responses of a mirror and of the default endpoint are cached apart
+type endpointProvider interface {
+	Endpoints() (forecastURL, airQualityURL string)
+}
+
+// OpenMeteoProvider fetches data from the Open-Meteo JMA and Air Quality APIs
+type OpenMeteoProvider struct {
+	ForecastURL   string
+	AirQualityURL string
+	Client        *http.Client
+}
+
+// NewOpenMeteoProvider creates a provider using the public Open-Meteo endpoints
+func NewOpenMeteoProvider() *OpenMeteoProvider {
+	return &OpenMeteoProvider{
+		ForecastURL:   DefaultForecastURL,
+		AirQualityURL: DefaultAirQualityURL,
+		Client: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
+}
+
+// NewOpenMeteoProviderFromConfig creates a provider honoring the [provider] section of the config.
+// Empty values fall back to the defaults.
+func NewOpenMeteoProviderFromConfig(cfg config.ProviderConfig) *OpenMeteoProvider {
+	provider := NewOpenMeteoProvider()
+	if cfg.ForecastURL != "" {
+		provider.ForecastURL = cfg.ForecastURL
+	}
+	if cfg.AirQualityURL != "" {
+		provider.AirQualityURL = cfg.AirQualityURL
+	}
+	if cfg.Timeout > 0 {
+		provider.Client.Timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	return provider
+}
+
+// Endpoints returns the forecast and air quality URLs the provider fetches from
+func (p *OpenMeteoProvider) Endpoints() (forecastURL, airQualityURL string) {
+	return p.ForecastURL, p.AirQualityURL
+}
+
+// Forecast fetches weather data from the JMA forecast API.
+// The timezone is an IANA name or auto, and defaults to DefaultTimezone when empty.
+func (p *OpenMeteoProvider) Forecast(lat, lon float64, timezone string, forecastDays int) (*types.WeatherData, error) {
+	if forecastDays < 1 {
+		forecastDays = 1
+	}
+	if forecastDays > MaxForecastDays {
+		return nil, fmt.Errorf("forecast days must be %d or less: %d", MaxForecastDays, forecastDays)
+	}
+
+	// 予報データ
+	requestURL := fmt.Sprintf("%s?latitude=%s&longitude=%s&current=%s&daily=%s&hourly=%s&wind_speed_unit=%s&timezone=%s&forecast_days=%d",
+		p.ForecastURL,
+		strconv.FormatFloat(lat, 'f', 4, 64),
+		strconv.FormatFloat(lon, 'f', 4, 64),
+		forecastCurrentParams,
+		forecastDailyParams,
+		forecastHourlyParams,
+		forecastWindSpeedUnit,
+		requestTimezone(timezone),
+		forecastDays)
+
+	resp, err := p.client().Get(requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+	}
+
+	var weather types.WeatherData
+	if err := json.NewDecoder(resp.Body).Decode(&weather); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &weather, nil
+}
+
+// AirQuality fetches air quality data from the Air Quality API.
+// The horizon is capped at MaxAirQualityForecastDays since air quality data is optional.
+func (p *OpenMeteoProvider) AirQuality(lat, lon float64, timezone string, forecastDays int) (*types.AirQualityData, error) {
+	if forecastDays < 1 {
+		forecastDays = 1
+	}
+	if forecastDays > MaxAirQualityForecastDays {
+		forecastDays = MaxAirQualityForecastDays
+	}
+
+	requestURL := fmt.Sprintf("%s?latitude=%s&longitude=%s&hourly=%s&timezone=%s&forecast_days=%d",
+		p.AirQualityURL,
+		strconv.FormatFloat(lat, 'f', 4, 64),
+		strconv.FormatFloat(lon, 'f', 4, 64),
+		airQualityHourlyParams,
+		requestTimezone(timezone),
+		forecastDays)
+
+	resp, err := p.client().Get(requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("Air Quality API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Air Quality API request failed with status: %d", resp.StatusCode)
+	}
+
+	var airQuality types.AirQualityData
+	if err := json.NewDecoder(resp.Body).Decode(&airQuality); err != nil {
+		return nil, fmt.Errorf("failed to decode air quality response: %w", err)
+	}
+
+	return &airQuality, nil
+}
+
+// requestTimezone returns the timezone query value, DefaultTimezone when none is given
+func requestTimezone(timezone string) string {
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	return url.QueryEscape(timezone)
+}
+
+// client returns the configured HTTP client or a default one
+func (p *OpenMeteoProvider) client() *http.Client {
+	if p.Client != nil {
+		return p.Client
+	}
+	return &http.Client{
+		Timeout: DefaultTimeout,
+	}
+}
@@ -0,0 +1,180 @@
+package weather
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/types"
+)
+
+func TestOpenMeteoProviderForecast(t *testing.T) {
+	var gotQuery map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = map[string]string{
+			"latitude":        r.URL.Query().Get("latitude"),
+			"longitude":       r.URL.Query().Get("longitude"),
+			"forecast_days":   r.URL.Query().Get("forecast_days"),
+			"wind_speed_unit": r.URL.Query().Get("wind_speed_unit"),
+			"hourly":          r.URL.Query().Get("hourly"),
+			"daily":           r.URL.Query().Get("daily"),
+			"timezone":        r.URL.Query().Get("timezone"),
+		}
+		w.Write([]byte(`{"current":{"temperature_2m":18.5,"relative_humidity_2m":55,"shortwave_radiation":640,"dew_point_2m":9.4,"uv_index":7.2},"daily":{"time":["2025-07-05","2025-07-06","2025-07-07"]}}`))
+	}))
+	defer server.Close()
+
+	provider := &OpenMeteoProvider{ForecastURL: server.URL, Client: server.Client()}
+
+	data, err := provider.Forecast(35.6762, 139.6503, "", 3)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+
+	if gotQuery["latitude"] != "35.6762" || gotQuery["longitude"] != "139.6503" {
+		t.Errorf("Unexpected coordinates in request: %v", gotQuery)
+	}
+	if gotQuery["timezone"] != DefaultTimezone {
+		t.Errorf("Expected timezone=%s by default, got %s", DefaultTimezone, gotQuery["timezone"])
+	}
+	if gotQuery["forecast_days"] != "3" {
+		t.Errorf("Expected forecast_days=3, got %s", gotQuery["forecast_days"])
+	}
+	if gotQuery["wind_speed_unit"] != "ms" {
+		t.Errorf("Expected wind_speed_unit=ms, got %s", gotQuery["wind_speed_unit"])
+	}
+	if !strings.Contains(gotQuery["hourly"], "uv_index") {
+		t.Errorf("Expected the hourly UV index to be requested, got %s", gotQuery["hourly"])
+	}
+	if !strings.Contains(gotQuery["daily"], "sunrise,sunset,daylight_duration") {
+		t.Errorf("Expected sun times to be requested, got %s", gotQuery["daily"])
+	}
+	if data.Current.Temperature != 18.5 || data.Current.Humidity != 55 || data.Current.ShortwaveRadiation != 640 || data.Current.DewPoint != 9.4 || data.Current.UVIndex != 7.2 {
+		t.Errorf("Unexpected current data: %+v", data.Current)
+	}
+	if len(data.Daily.Time) != 3 {
+		t.Errorf("Expected 3 daily entries, got %d", len(data.Daily.Time))
+	}
+}
+
+func TestOpenMeteoProviderWindSpeedUnit(t *testing.T) {
+	// The API answers in km/h unless asked for m/s, the unit of the wind and gust thresholds.
+	// A 43.2 km/h wind must reach the scoring as 12 m/s, a strong wind but not a dangerous gust.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("wind_speed_unit") == "ms" {
+			w.Write([]byte(`{"current":{"wind_speed_10m":12.0,"wind_gusts_10m":12.0}}`))
+			return
+		}
+		w.Write([]byte(`{"current":{"wind_speed_10m":43.2,"wind_gusts_10m":43.2}}`))
+	}))
+	defer server.Close()
+
+	provider := &OpenMeteoProvider{ForecastURL: server.URL, Client: server.Client()}
+	data, err := provider.Forecast(35.6762, 139.6503, "", 1)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+
+	thresholds := types.DefaultScoring().Thresholds
+	if data.Current.WindSpeed != 12.0 || data.Current.WindSpeed <= thresholds.WindStrong || data.Current.WindGusts >= thresholds.GustDangerous {
+		t.Errorf("Expected the wind in m/s between wind_strong %g and gust_dangerous %g, got %.1f", thresholds.WindStrong, thresholds.GustDangerous, data.Current.WindSpeed)
+	}
+}
+
+func TestOpenMeteoProviderForecastErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider := &OpenMeteoProvider{ForecastURL: server.URL, Client: server.Client()}
+
+	if _, err := provider.Forecast(35.6762, 139.6503, "", 1); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+	if _, err := provider.Forecast(35.6762, 139.6503, "", MaxForecastDays+1); err == nil {
+		t.Error("Expected error for forecast days beyond the maximum")
+	}
+}
+
+func TestOpenMeteoProviderAirQuality(t *testing.T) {
+	var gotDays string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDays = r.URL.Query().Get("forecast_days")
+		w.Write([]byte(`{"hourly":{"time":["2025-07-05T00:00"],"dust":[120],"pm10":[80],"pm2_5":[40]}}`))
+	}))
+	defer server.Close()
+
+	provider := &OpenMeteoProvider{AirQualityURL: server.URL, Client: server.Client()}
+
+	data, err := provider.AirQuality(35.6762, 139.6503, "", MaxForecastDays)
+	if err != nil {
+		t.Fatalf("AirQuality failed: %v", err)
+	}
+
+	// Air quality horizon is capped instead of failing
+	if gotDays != "7" {
+		t.Errorf("Expected forecast_days capped to 7, got %s", gotDays)
+	}
+	if len(data.Hourly.Dust) != 1 || data.Hourly.Dust[0] != 120 {
+		t.Errorf("Unexpected dust data: %v", data.Hourly.Dust)
+	}
+}
+
+func TestNewOpenMeteoProviderFromConfig(t *testing.T) {
+	provider := NewOpenMeteoProviderFromConfig(config.ProviderConfig{})
+	if provider.ForecastURL != DefaultForecastURL || provider.AirQualityURL != DefaultAirQualityURL {
+		t.Errorf("Expected default URLs, got %s and %s", provider.ForecastURL, provider.AirQualityURL)
+	}
+	if provider.Client.Timeout != DefaultTimeout {
+		t.Errorf("Expected default timeout, got %v", provider.Client.Timeout)
+	}
+
+	provider = NewOpenMeteoProviderFromConfig(config.ProviderConfig{
+		ForecastURL:   "http://localhost:8080/v1/jma",
+		AirQualityURL: "http://localhost:8080/v1/air-quality",
+		Timeout:       30,
+	})
+	if provider.ForecastURL != "http://localhost:8080/v1/jma" {
+		t.Errorf("Expected custom forecast URL, got %s", provider.ForecastURL)
+	}
+	if provider.AirQualityURL != "http://localhost:8080/v1/air-quality" {
+		t.Errorf("Expected custom air quality URL, got %s", provider.AirQualityURL)
+	}
+	if provider.Client.Timeout != 30*time.Second {
+		t.Errorf("Expected 30s timeout, got %v", provider.Client.Timeout)
+	}
+}
+
+func TestOpenMeteoProviderTimezone(t *testing.T) {
+	var gotTimezones []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTimezones = append(gotTimezones, r.URL.Query().Get("timezone"))
+		w.Write([]byte(`{"timezone":"America/New_York","utc_offset_seconds":-14400,"hourly":{"time":["2025-07-05T00:00"]}}`))
+	}))
+	defer server.Close()
+
+	provider := &OpenMeteoProvider{ForecastURL: server.URL, AirQualityURL: server.URL, Client: server.Client()}
+
+	weatherData, err := provider.Forecast(40.7128, -74.006, TimezoneAuto, 1)
+	if err != nil {
+		t.Fatalf("Forecast failed: %v", err)
+	}
+	airQuality, err := provider.AirQuality(40.7128, -74.006, "America/New_York", 1)
+	if err != nil {
+		t.Fatalf("AirQuality failed: %v", err)
+	}
+
+	if len(gotTimezones) != 2 || gotTimezones[0] != TimezoneAuto || gotTimezones[1] != "America/New_York" {
+		t.Errorf("Unexpected timezones in the requests: %v", gotTimezones)
+	}
+	if weatherData.Timezone != "America/New_York" || weatherData.UTCOffsetSeconds != -14400 {
+		t.Errorf("Expected the forecast's timezone, got %s %d", weatherData.Timezone, weatherData.UTCOffsetSeconds)
+	}
+	if airQuality.Timezone != "America/New_York" || airQuality.UTCOffsetSeconds != -14400 {
+		t.Errorf("Expected the air quality timezone, got %s %d", airQuality.Timezone, airQuality.UTCOffsetSeconds)
+	}
+}
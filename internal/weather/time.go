@@ -1,65 +1,177 @@
 package weather
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"runcast/internal/config"
+	"runcast/internal/i18n"
 	"runcast/internal/types"
 )
 
+// customPeriods holds the user-defined time periods, which take precedence over built-in ones of the same key
+var customPeriods []types.TimePeriod
+
+// SetCustomTimePeriods registers user-defined time periods such as those of the [periods] config section
+func SetCustomTimePeriods(periods []types.TimePeriod) {
+	customPeriods = periods
+}
+
 // GetTimePeriods returns all available time periods
 func GetTimePeriods() map[string]types.TimePeriod {
-	return map[string]types.TimePeriod{
-		"morning": {"morning", "早朝", 5, 9},
-		"noon":    {"noon", "昼", 11, 15},
-		"evening": {"evening", "夕方", 17, 19},
-		"night":   {"night", "夜", 21, 23},
+	periods := map[string]types.TimePeriod{
+		"morning": {Key: "morning", DisplayName: i18n.T("period.morning"), StartHour: 5, EndHour: 9},
+		"noon":    {Key: "noon", DisplayName: i18n.T("period.noon"), StartHour: 11, EndHour: 15},
+		"evening": {Key: "evening", DisplayName: i18n.T("period.evening"), StartHour: 17, EndHour: 19},
+		"night":   {Key: "night", DisplayName: i18n.T("period.night"), StartHour: 21, EndHour: 23},
+	}
+	for _, period := range customPeriods {
+		periods[period.Key] = period
+	}
+	return periods
+}
+
+// GetTimePeriodKeys returns the keys of all available time periods in alphabetical order
+func GetTimePeriodKeys() []string {
+	periods := GetTimePeriods()
+	keys := make([]string, 0, len(periods))
+	for key := range periods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FormatPeriodRange formats the start and end of a time period such as "5:00-9:00" or "12:00-13:30"
+func FormatPeriodRange(period types.TimePeriod) string {
+	return fmt.Sprintf("%d:%02d-%d:%02d", period.StartHour, period.StartMinute, period.EndHour, period.EndMinute)
+}
+
+// periodCoversHour reports whether the forecast hour starting at hour overlaps the time period.
+// The end is inclusive, so the hour starting at the end of the period still counts.
+func periodCoversHour(period types.TimePeriod, hour int) bool {
+	start := period.StartHour*60 + period.StartMinute
+	end := period.EndHour*60 + period.EndMinute
+	return hour*60 <= end && (hour+1)*60 > start
+}
+
+// IsMiddayTime reports whether a forecast hour such as "2025-07-05T12:00" falls in the noon period,
+// when the UV index peaks. A date without a time of day does not.
+func IsMiddayTime(t string) bool {
+	if len(t) < 13 {
+		return false
 	}
+	return periodCoversHour(GetTimePeriods()["noon"], ExtractHourInt(t))
 }
 
-// ExtractTimeBasedWeather extracts weather data for specific time period
+// ExtractTimeBasedWeather extracts weather data for specific time period over the first days of the forecast
 func ExtractTimeBasedWeather(weather *types.WeatherData, timeOfDay string, days int) []types.TimeBasedWeather {
 	periods := GetTimePeriods()
 	period, exists := periods[timeOfDay]
 	if !exists {
 		return nil
 	}
-	
+
 	var timeData []types.TimeBasedWeather
-	
-	for i := 0; i < len(weather.Hourly.Time) && len(timeData) < days*5; i++ {
-		hour := ExtractHour(weather.Hourly.Time[i])
-		if hour == "" {
+	date, dates := "", 0
+	for i, t := range weather.Hourly.Time {
+		if len(t) < 13 || !periodCoversHour(period, ExtractHourInt(t)) {
 			continue
 		}
-		
-		hourInt := 0
-		if len(hour) >= 2 {
-			if hour[0] == '0' || hour[0] == '1' || hour[0] == '2' {
-				if hour[0] == '0' {
-					hourInt = int(hour[1] - '0')
-				} else if hour[0] == '1' {
-					hourInt = 10 + int(hour[1] - '0')
-				} else if hour[0] == '2' {
-					hourInt = 20 + int(hour[1] - '0')
-				}
+		if t[:10] != date {
+			if dates == days {
+				break
 			}
+			date = t[:10]
+			dates++
 		}
-		
-		if hourInt >= period.StartHour && hourInt <= period.EndHour {
-			timeData = append(timeData, types.TimeBasedWeather{
-				Time:          weather.Hourly.Time[i],
-				Temperature:   weather.Hourly.Temperature[i],
-				ApparentTemp:  weather.Hourly.ApparentTemp[i],
-				Humidity:      weather.Hourly.Humidity[i],
-				WindSpeed:     weather.Hourly.WindSpeed[i],
-				WindDirection: weather.Hourly.WindDirection[i],
-				Precipitation: weather.Hourly.Precipitation[i],
-				WeatherCode:   weather.Hourly.WeatherCode[i],
-			})
-		}
+		timeData = append(timeData, hourlyWeatherAt(weather, i))
 	}
-	
+
 	return timeData
 }
 
+// ExtractRunWindow returns the hourly weather of a run starting at startTime (YYYY-MM-DDTHH:MM) and lasting the given hours.
+// The window is cut short at the end of the forecast and is empty when the start hour is not in the forecast.
+func ExtractRunWindow(weather *types.WeatherData, startTime string, hours int) []types.TimeBasedWeather {
+	if len(startTime) < 13 {
+		return nil
+	}
+	startHour := startTime[:13]
+
+	var window []types.TimeBasedWeather
+	for i, t := range weather.Hourly.Time {
+		if len(window) == 0 && !strings.HasPrefix(t, startHour) {
+			continue
+		}
+		if len(window) >= hours {
+			break
+		}
+		window = append(window, hourlyWeatherAt(weather, i))
+	}
+	return window
+}
+
+// GetRunnablePeriod returns the span from the start of the earliest time period to the end of the latest one,
+// which leaves out the middle of the night
+func GetRunnablePeriod() types.TimePeriod {
+	runnable := types.TimePeriod{StartHour: 23, EndHour: 0}
+	for _, period := range GetTimePeriods() {
+		if period.StartHour*60+period.StartMinute < runnable.StartHour*60+runnable.StartMinute {
+			runnable.StartHour, runnable.StartMinute = period.StartHour, period.StartMinute
+		}
+		if period.EndHour*60+period.EndMinute > runnable.EndHour*60+runnable.EndMinute {
+			runnable.EndHour, runnable.EndMinute = period.EndHour, period.EndMinute
+		}
+	}
+	return runnable
+}
+
+// ExtractRunnableHours returns the hourly weather of a date (YYYY-MM-DD) within the runnable period
+func ExtractRunnableHours(weather *types.WeatherData, date string) []types.TimeBasedWeather {
+	runnable := GetRunnablePeriod()
+	var hours []types.TimeBasedWeather
+	start, end := hourlyRangeForDate(weather.Hourly.Time, date)
+	for i := start; i < end; i++ {
+		if periodCoversHour(runnable, ExtractHourInt(weather.Hourly.Time[i])) {
+			hours = append(hours, hourlyWeatherAt(weather, i))
+		}
+	}
+	return hours
+}
+
+// hourlyWeatherAt converts the hourly forecast at index i
+func hourlyWeatherAt(weather *types.WeatherData, i int) types.TimeBasedWeather {
+	return types.TimeBasedWeather{
+		Time:          weather.Hourly.Time[i],
+		Temperature:   weather.Hourly.Temperature[i],
+		ApparentTemp:  weather.Hourly.ApparentTemp[i],
+		Humidity:      weather.Hourly.Humidity[i],
+		WindSpeed:     weather.Hourly.WindSpeed[i],
+		WindDirection: weather.Hourly.WindDirection[i],
+		Precipitation: weather.Hourly.Precipitation[i],
+		WeatherCode:   weather.Hourly.WeatherCode[i],
+		DewPoint:      hourlyDewPoint(weather, i),
+		// Optional variables default to zero when missing from the response
+		ShortwaveRadiation: valueAt(weather.Hourly.ShortwaveRadiation, i),
+		UVIndex:            valueAt(weather.Hourly.UVIndex, i),
+		WindGusts:          valueAt(weather.Hourly.WindGusts, i),
+		PrecipitationProbability: valueAt(weather.Hourly.PrecipitationProbability, i),
+		WBGT: EstimateWBGT(
+			weather.Hourly.Temperature[i],
+			float64(weather.Hourly.Humidity[i]),
+			weather.Hourly.WindSpeed[i],
+			valueAt(weather.Hourly.ShortwaveRadiation, i),
+		),
+	}
+}
+
 // ExtractHour extracts hour from ISO time string
 func ExtractHour(timeStr string) string {
 	// Extract hour from ISO time string (YYYY-MM-DDTHH:MM)
@@ -84,104 +196,310 @@ func ExtractHourInt(timeStr string) int {
 	return 0
 }
 
-// ExtractDateBasedWeather extracts weather data for specific date
-func ExtractDateBasedWeather(weather *types.WeatherData, dayOffset int) *types.WeatherData {
-	if dayOffset == 0 || len(weather.Daily.Time) == 0 {
-		return weather
-	}
-	
-	if dayOffset >= len(weather.Daily.Time) {
-		return weather
-	}
-	
-	// Check if all required arrays have sufficient length
-	if len(weather.Daily.TemperatureMax) <= dayOffset ||
-		len(weather.Daily.TemperatureMin) <= dayOffset ||
-		len(weather.Daily.WindSpeedMax) <= dayOffset ||
-		len(weather.Daily.PrecipitationSum) <= dayOffset ||
-		len(weather.Daily.WeatherCode) <= dayOffset {
-		return weather
-	}
-	
-	// Create new weather data with selected day
-	dateSpecificWeather := &types.WeatherData{
-		Current: weather.Current,
-		Daily: struct {
-			Time                        []string  `json:"time"`
-			TemperatureMax              []float64 `json:"temperature_2m_max"`
-			TemperatureMin              []float64 `json:"temperature_2m_min"`
-			WindSpeedMax                []float64 `json:"wind_speed_10m_max"`
-			WindGustMax                 []float64 `json:"wind_gusts_10m_max"`
-			PrecipitationSum            []float64 `json:"precipitation_sum"`
-			WeatherCode                 []int     `json:"weather_code"`
-			SunriseTime                 []string  `json:"sunrise"`
-			SunsetTime                  []string  `json:"sunset"`
-			DaylightDuration            []float64 `json:"daylight_duration"`
-			SunshineDuration            []float64 `json:"sunshine_duration"`
-			UvIndexMax                  []float64 `json:"uv_index_max"`
-			UvIndexClearSkyMax          []float64 `json:"uv_index_clear_sky_max"`
-			PrecipitationHours          []float64 `json:"precipitation_hours"`
-			PrecipitationProbabilityMax []float64 `json:"precipitation_probability_max"`
-		}{
-			Time:                        []string{weather.Daily.Time[dayOffset]},
-			TemperatureMax:              []float64{weather.Daily.TemperatureMax[dayOffset]},
-			TemperatureMin:              []float64{weather.Daily.TemperatureMin[dayOffset]},
-			WindSpeedMax:                []float64{weather.Daily.WindSpeedMax[dayOffset]},
-			WindGustMax:                 safeFloat64Slice(weather.Daily.WindGustMax, dayOffset),
-			PrecipitationSum:            []float64{weather.Daily.PrecipitationSum[dayOffset]},
-			WeatherCode:                 []int{weather.Daily.WeatherCode[dayOffset]},
-			SunriseTime:                 safeStringSlice(weather.Daily.SunriseTime, dayOffset),
-			SunsetTime:                  safeStringSlice(weather.Daily.SunsetTime, dayOffset),
-			DaylightDuration:            safeFloat64Slice(weather.Daily.DaylightDuration, dayOffset),
-			SunshineDuration:            safeFloat64Slice(weather.Daily.SunshineDuration, dayOffset),
-			UvIndexMax:                  safeFloat64Slice(weather.Daily.UvIndexMax, dayOffset),
-			UvIndexClearSkyMax:          safeFloat64Slice(weather.Daily.UvIndexClearSkyMax, dayOffset),
-			PrecipitationHours:          safeFloat64Slice(weather.Daily.PrecipitationHours, dayOffset),
-			PrecipitationProbabilityMax: safeFloat64Slice(weather.Daily.PrecipitationProbabilityMax, dayOffset),
-		},
-		Hourly: weather.Hourly,
-	}
-	
-	return dateSpecificWeather
-}
-
-// GetDateDisplayName returns Japanese display name for date specification
+// ForecastDate returns the date (YYYY-MM-DD) dayOffset days after now in the forecast's timezone
+func ForecastDate(weather *types.WeatherData, now time.Time, dayOffset int) string {
+	return now.In(ForecastLocation(weather)).AddDate(0, 0, dayOffset).Format("2006-01-02")
+}
+
+// ExtractDateBasedWeather extracts weather data for a specific date (YYYY-MM-DD).
+// It returns an error when the date is outside the fetched forecast range.
+func ExtractDateBasedWeather(weather *types.WeatherData, date string) (*types.WeatherData, error) {
+	dateSpecificWeather, err := ExtractForecastDays(weather, date, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if all required values of the day are present
+	daily := dateSpecificWeather.Daily
+	if len(daily.TemperatureMax) == 0 ||
+		len(daily.TemperatureMin) == 0 ||
+		len(daily.WindSpeedMax) == 0 ||
+		len(daily.PrecipitationSum) == 0 ||
+		len(daily.WeatherCode) == 0 {
+		return nil, errors.New(i18n.T("error.date_incomplete", date))
+	}
+	return dateSpecificWeather, nil
+}
+
+// ExtractForecastDays narrows the forecast down to the given number of days starting at date (YYYY-MM-DD),
+// fewer at the end of the forecast. Days are looked up by date rather than counted from the first one
+// because a forecast served from the cache may have been fetched on an earlier day.
+// It returns an error when the forecast does not cover date.
+func ExtractForecastDays(weather *types.WeatherData, date string, days int) (*types.WeatherData, error) {
+	if len(weather.Daily.Time) == 0 {
+		return nil, errors.New(i18n.T("error.no_date_data"))
+	}
+	first := slices.Index(weather.Daily.Time, date)
+	if first < 0 {
+		dates := weather.Daily.Time
+		return nil, errors.New(i18n.T("error.date_out_of_range", date, dates[0], dates[len(dates)-1]))
+	}
+	end := min(first+max(days, 1), len(weather.Daily.Time))
+
+	// Keep the location, current conditions and cache state of the forecast
+	selected := *weather
+	daily := &selected.Daily
+	daily.Time = weather.Daily.Time[first:end]
+	daily.TemperatureMax = safeRange(weather.Daily.TemperatureMax, first, end)
+	daily.TemperatureMin = safeRange(weather.Daily.TemperatureMin, first, end)
+	daily.WindSpeedMax = safeRange(weather.Daily.WindSpeedMax, first, end)
+	daily.WindGustMax = safeRange(weather.Daily.WindGustMax, first, end)
+	daily.WindDirectionDominant = safeRange(weather.Daily.WindDirectionDominant, first, end)
+	daily.PrecipitationSum = safeRange(weather.Daily.PrecipitationSum, first, end)
+	daily.WeatherCode = safeRange(weather.Daily.WeatherCode, first, end)
+	daily.SunriseTime = safeRange(weather.Daily.SunriseTime, first, end)
+	daily.SunsetTime = safeRange(weather.Daily.SunsetTime, first, end)
+	daily.DaylightDuration = safeRange(weather.Daily.DaylightDuration, first, end)
+	daily.SunshineDuration = safeRange(weather.Daily.SunshineDuration, first, end)
+	daily.UvIndexMax = safeRange(weather.Daily.UvIndexMax, first, end)
+	daily.UvIndexClearSkyMax = safeRange(weather.Daily.UvIndexClearSkyMax, first, end)
+	daily.PrecipitationHours = safeRange(weather.Daily.PrecipitationHours, first, end)
+	daily.PrecipitationProbabilityMax = safeRange(weather.Daily.PrecipitationProbabilityMax, first, end)
+
+	// Keep only the hourly entries of the selected days
+	start, stop := hourlyRangeForDates(weather.Hourly.Time, date, daily.Time[len(daily.Time)-1])
+	hourly := &selected.Hourly
+	hourly.Time = safeRange(weather.Hourly.Time, start, stop)
+	hourly.Temperature = safeRange(weather.Hourly.Temperature, start, stop)
+	hourly.ApparentTemp = safeRange(weather.Hourly.ApparentTemp, start, stop)
+	hourly.Humidity = safeRange(weather.Hourly.Humidity, start, stop)
+	hourly.WindSpeed = safeRange(weather.Hourly.WindSpeed, start, stop)
+	hourly.WindDirection = safeRange(weather.Hourly.WindDirection, start, stop)
+	hourly.Precipitation = safeRange(weather.Hourly.Precipitation, start, stop)
+	hourly.WeatherCode = safeRange(weather.Hourly.WeatherCode, start, stop)
+	hourly.DewPoint = safeRange(weather.Hourly.DewPoint, start, stop)
+	hourly.ShortwaveRadiation = safeRange(weather.Hourly.ShortwaveRadiation, start, stop)
+	hourly.UVIndex = safeRange(weather.Hourly.UVIndex, start, stop)
+	hourly.WindGusts = safeRange(weather.Hourly.WindGusts, start, stop)
+	hourly.PrecipitationProbability = safeRange(weather.Hourly.PrecipitationProbability, start, stop)
+
+	return &selected, nil
+}
+
+// hourlyRangeForDates returns the [start, end) index range of hourly entries from the first to the last date (YYYY-MM-DD)
+func hourlyRangeForDates(times []string, first, last string) (int, int) {
+	start, end := len(times), len(times)
+	for i, t := range times {
+		date := t[:min(len(t), 10)]
+		if date > last {
+			end = i
+			break
+		}
+		if start == len(times) && date >= first {
+			start = i
+		}
+	}
+	return start, end
+}
+
+// hourlyRangeForDate returns the [start, end) index range of hourly entries on the given date (YYYY-MM-DD)
+func hourlyRangeForDate(times []string, date string) (int, int) {
+	start, end := -1, -1
+	for i, t := range times {
+		if strings.HasPrefix(t, date) {
+			if start < 0 {
+				start = i
+			}
+			end = i + 1
+		}
+	}
+	if start < 0 {
+		return 0, 0
+	}
+	return start, end
+}
+
+// DefaultLocation is the timezone of locations and forecasts without one of their own
+var DefaultLocation = time.FixedZone(DefaultTimezone, 9*60*60)
+
+// Timezones of a location
+const (
+	DefaultTimezone = "Asia/Tokyo"        // built-in cities, municipalities and custom locations without a timezone
+	TimezoneAuto    = config.TimezoneAuto // the timezone of the coordinates, resolved by the forecast API
+)
+
+// LocationTimezone returns the timezone requested from the forecast API for the location
+func LocationTimezone(coord types.CityCoordinate) string {
+	if coord.Timezone == "" {
+		return DefaultTimezone
+	}
+	return coord.Timezone
+}
+
+// ExpectedLocation returns the timezone of the location before its forecast is known, to resolve the date to fetch.
+// A timezone left to the forecast API is estimated from the longitude, which tells the local date but for an hour or two around midnight;
+// the dates are resolved again against the forecast's own offset.
+func ExpectedLocation(coord types.CityCoordinate) *time.Location {
+	switch coord.Timezone {
+	case "", DefaultTimezone:
+		return DefaultLocation
+	case TimezoneAuto:
+		return time.FixedZone(TimezoneAuto, int(math.Round(coord.Lon/15))*60*60)
+	}
+	location, err := time.LoadLocation(coord.Timezone)
+	if err != nil {
+		return DefaultLocation
+	}
+	return location
+}
+
+// ForecastLocation returns the timezone of the local times in the forecast.
+// Forecasts without a timezone, such as those cached before it was recorded, are in DefaultLocation.
+func ForecastLocation(weather *types.WeatherData) *time.Location {
+	if weather == nil {
+		return DefaultLocation
+	}
+	return dataLocation(weather.Timezone, weather.UTCOffsetSeconds)
+}
+
+// ForecastUTCOffset returns the UTC offset of the forecast's local times as the API reported it,
+// which unlike the offset of ForecastLocation at an arbitrary time is known to apply to the forecast
+func ForecastUTCOffset(weather *types.WeatherData) int {
+	if weather == nil || weather.Timezone == "" {
+		_, offset := time.Time{}.In(DefaultLocation).Zone()
+		return offset
+	}
+	return weather.UTCOffsetSeconds
+}
+
+// AirQualityLocation returns the timezone of the local times in the air quality data
+func AirQualityLocation(airQuality *types.AirQualityData) *time.Location {
+	if airQuality == nil {
+		return DefaultLocation
+	}
+	return dataLocation(airQuality.Timezone, airQuality.UTCOffsetSeconds)
+}
+
+// dataLocation returns the timezone of an API response, DefaultLocation when it has none.
+// The UTC offset of the response holds only at the time it was fetched, so it is used only
+// for timezones that fail to load; the IANA zone keeps the dates right across a DST change.
+func dataLocation(timezone string, utcOffsetSeconds int) *time.Location {
+	if timezone == "" {
+		return DefaultLocation
+	}
+	if location, err := time.LoadLocation(timezone); err == nil {
+		return location
+	}
+	return time.FixedZone(timezone, utcOffsetSeconds)
+}
+
+// weekdayNames maps English weekday names and abbreviations to time.Weekday
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// GetDateDisplayName returns the localized display name for date specification
 func GetDateDisplayName(dateSpec string) string {
-	switch dateSpec {
+	spec := strings.ToLower(dateSpec)
+	switch spec {
 	case "today":
-		return "今日の"
+		return i18n.T("date.today")
 	case "tomorrow":
-		return "明日の"
+		return i18n.T("date.tomorrow")
 	case "day-after-tomorrow":
-		return "明後日の"
-	default:
-		return dateSpec + "の"
+		return i18n.T("date.day_after_tomorrow")
+	}
+
+	if days, ok := parseRelativeDays(spec); ok {
+		return i18n.T("date.days_later", days)
 	}
+	if weekday, next, ok := parseWeekday(spec); ok {
+		name := i18n.T(fmt.Sprintf("weekday.%d", weekday))
+		if next {
+			return i18n.T("date.next_weekday", name)
+		}
+		return i18n.T("date.weekday", name)
+	}
+	if date, err := time.Parse("2006-01-02", spec); err == nil {
+		weekday := i18n.T(fmt.Sprintf("weekday.short.%d", date.Weekday()))
+		return i18n.T("date.iso", int(date.Month()), date.Day(), weekday, date.Format("Jan"))
+	}
+
+	return i18n.T("date.other", dateSpec)
 }
 
-// GetDateOffset returns day offset for date specification
+// GetDateOffset returns day offset for date specification resolved against the current date in DefaultLocation
 func GetDateOffset(dateSpec string) int {
-	switch dateSpec {
-	case "today":
+	offset, err := ParseDateOffset(dateSpec, time.Now().In(DefaultLocation))
+	if err != nil {
 		return 0
+	}
+	return offset
+}
+
+// ParseDateOffset resolves a date specification to a day offset from now.
+// Supported forms are today, tomorrow, day-after-tomorrow, ISO dates (2026-10-20),
+// weekday names (sat, next-sun) and relative offsets (+5d).
+// A weekday name refers to the nearest such day including today, and the next- prefix adds one week.
+// now must already be in the location's timezone.
+func ParseDateOffset(dateSpec string, now time.Time) (int, error) {
+	spec := strings.ToLower(dateSpec)
+	switch spec {
+	case "today":
+		return 0, nil
 	case "tomorrow":
-		return 1
+		return 1, nil
 	case "day-after-tomorrow":
-		return 2
-	default:
-		return 0
+		return 2, nil
+	}
+
+	if days, ok := parseRelativeDays(spec); ok {
+		return days, nil
+	}
+
+	if weekday, next, ok := parseWeekday(spec); ok {
+		offset := (int(weekday) - int(now.Weekday()) + 7) % 7
+		if next {
+			offset += 7
+		}
+		return offset, nil
 	}
+
+	if date, err := time.ParseInLocation("2006-01-02", spec, now.Location()); err == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		offset := int(date.Sub(today).Hours()+12) / 24
+		if date.Before(today) {
+			return 0, errors.New(i18n.T("error.past_date", dateSpec))
+		}
+		return offset, nil
+	}
+
+	return 0, errors.New(i18n.T("error.invalid_date", dateSpec))
+}
+
+// parseRelativeDays parses relative offsets such as +5d
+func parseRelativeDays(spec string) (int, bool) {
+	if !strings.HasPrefix(spec, "+") || !strings.HasSuffix(spec, "d") {
+		return 0, false
+	}
+	days, err := strconv.Atoi(spec[1 : len(spec)-1])
+	if err != nil || days < 0 {
+		return 0, false
+	}
+	return days, true
+}
+
+// parseWeekday parses weekday names with an optional next- prefix
+func parseWeekday(spec string) (time.Weekday, bool, bool) {
+	next := false
+	if strings.HasPrefix(spec, "next-") {
+		next = true
+		spec = strings.TrimPrefix(spec, "next-")
+	}
+	weekday, exists := weekdayNames[spec]
+	return weekday, next, exists
 }
 
 // ValidateDateSpec validates if the date specification is valid
 func ValidateDateSpec(dateSpec string) bool {
-	validDates := []string{"today", "tomorrow", "day-after-tomorrow"}
-	for _, valid := range validDates {
-		if dateSpec == valid {
-			return true
-		}
-	}
-	return false
+	_, err := ParseDateOffset(dateSpec, time.Now().In(DefaultLocation))
+	return err == nil
 }
 
 // ValidateTimeSpec validates if the time specification is valid
@@ -191,18 +509,22 @@ func ValidateTimeSpec(timeSpec string) bool {
 	return exists
 }
 
-// safeStringSlice safely accesses string slice and returns slice with single element or empty slice
-func safeStringSlice(slice []string, index int) []string {
-	if len(slice) > index {
-		return []string{slice[index]}
+// valueAt safely returns slice[index] or the zero value when the index is out of range
+func valueAt[T any](slice []T, index int) T {
+	var zero T
+	if index < 0 || index >= len(slice) {
+		return zero
 	}
-	return []string{}
+	return slice[index]
 }
 
-// safeFloat64Slice safely accesses float64 slice and returns slice with single element or empty slice
-func safeFloat64Slice(slice []float64, index int) []float64 {
-	if len(slice) > index {
-		return []float64{slice[index]}
+// safeRange safely returns slice[start:end] clamped to the slice length
+func safeRange[T any](slice []T, start, end int) []T {
+	if end > len(slice) {
+		end = len(slice)
+	}
+	if start >= end {
+		return []T{}
 	}
-	return []float64{}
-}
\ No newline at end of file
+	return slice[start:end]
+}
@@ -1,7 +1,11 @@
 package weather
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
 	"runcast/internal/types"
 )
 
@@ -96,16 +100,7 @@ func TestExtractHour(t *testing.T) {
 func TestExtractTimeBasedWeather(t *testing.T) {
 	// Create mock weather data
 	weather := &types.WeatherData{
-		Hourly: struct {
-			Time          []string  `json:"time"`
-			Temperature   []float64 `json:"temperature_2m"`
-			ApparentTemp  []float64 `json:"apparent_temperature"`
-			Humidity      []int     `json:"relative_humidity_2m"`
-			WindSpeed     []float64 `json:"wind_speed_10m"`
-			WindDirection []float64 `json:"wind_direction_10m"`
-			Precipitation []float64 `json:"precipitation"`
-			WeatherCode   []int     `json:"weather_code"`
-		}{
+		Hourly: types.HourlyWeather{
 			Time:          []string{"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00", "2025-07-05T08:00", "2025-07-05T09:00", "2025-07-05T10:00", "2025-07-05T11:00"},
 			Temperature:   []float64{20.0, 21.0, 22.0, 23.0, 24.0, 25.0, 26.0},
 			ApparentTemp:  []float64{19.0, 20.0, 21.0, 22.0, 23.0, 24.0, 25.0},
@@ -135,6 +130,135 @@ func TestExtractTimeBasedWeather(t *testing.T) {
 	}
 }
 
+func TestExtractTimeBasedWeatherCustomPeriods(t *testing.T) {
+	weather := &types.WeatherData{}
+	for day := 5; day <= 7; day++ {
+		for hour := 0; hour < 24; hour++ {
+			weather.Hourly.Time = append(weather.Hourly.Time, fmt.Sprintf("2025-07-%02dT%02d:00", day, hour))
+			weather.Hourly.Temperature = append(weather.Hourly.Temperature, 20.0)
+			weather.Hourly.ApparentTemp = append(weather.Hourly.ApparentTemp, 20.0)
+			weather.Hourly.Humidity = append(weather.Hourly.Humidity, 60)
+			weather.Hourly.WindSpeed = append(weather.Hourly.WindSpeed, 2.0)
+			weather.Hourly.WindDirection = append(weather.Hourly.WindDirection, 180)
+			weather.Hourly.Precipitation = append(weather.Hourly.Precipitation, 0)
+			weather.Hourly.WeatherCode = append(weather.Hourly.WeatherCode, 0)
+		}
+	}
+
+	SetCustomTimePeriods([]types.TimePeriod{
+		{Key: "lunch", DisplayName: "lunch", StartHour: 12, EndHour: 13, EndMinute: 30},
+		{Key: "commute", DisplayName: "commute", StartHour: 6, StartMinute: 30, EndHour: 7, EndMinute: 15},
+		{Key: "long", DisplayName: "long", StartHour: 5, EndHour: 12},
+		{Key: "morning", DisplayName: "morning", StartHour: 6, EndHour: 7},
+	})
+	defer SetCustomTimePeriods(nil)
+
+	tests := []struct {
+		timeOfDay string
+		days      int
+		expected  []string
+	}{
+		{timeOfDay: "lunch", days: 1, expected: []string{"2025-07-05T12:00", "2025-07-05T13:00"}},
+		{timeOfDay: "commute", days: 2, expected: []string{"2025-07-05T06:00", "2025-07-05T07:00", "2025-07-06T06:00", "2025-07-06T07:00"}},
+		{timeOfDay: "morning", days: 1, expected: []string{"2025-07-05T06:00", "2025-07-05T07:00"}},
+		{timeOfDay: "noon", days: 1, expected: []string{"2025-07-05T11:00", "2025-07-05T12:00", "2025-07-05T13:00", "2025-07-05T14:00", "2025-07-05T15:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeOfDay, func(t *testing.T) {
+			timeData := ExtractTimeBasedWeather(weather, tt.timeOfDay, tt.days)
+			if len(timeData) != len(tt.expected) {
+				t.Fatalf("Expected %d hours, got %d", len(tt.expected), len(timeData))
+			}
+			for i, data := range timeData {
+				if data.Time != tt.expected[i] {
+					t.Errorf("Expected %s at %d, got %s", tt.expected[i], i, data.Time)
+				}
+			}
+		})
+	}
+
+	// Periods longer than five hours are no longer cut short
+	if timeData := ExtractTimeBasedWeather(weather, "long", 3); len(timeData) != 24 {
+		t.Errorf("Expected 8 hours on each of 3 days, got %d", len(timeData))
+	}
+	if formatted := FormatPeriodRange(GetTimePeriods()["commute"]); formatted != "6:30-7:15" {
+		t.Errorf("Expected 6:30-7:15, got %s", formatted)
+	}
+}
+
+func TestExtractRunWindow(t *testing.T) {
+	weather := &types.WeatherData{
+		Hourly: types.HourlyWeather{
+			Time:          []string{"2025-07-05T05:00", "2025-07-05T06:00", "2025-07-05T07:00"},
+			Temperature:   []float64{20.0, 21.0, 22.0},
+			ApparentTemp:  []float64{19.0, 20.0, 21.0},
+			Humidity:      []int{80, 75, 70},
+			WindSpeed:     []float64{2.0, 2.5, 3.0},
+			WindDirection: []float64{180, 185, 190},
+			Precipitation: []float64{0, 0, 0},
+			WeatherCode:   []int{0, 0, 1},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		startTime string
+		hours     int
+		expected  []string
+	}{
+		{name: "From the start hour", startTime: "2025-07-05T06:00", hours: 2, expected: []string{"2025-07-05T06:00", "2025-07-05T07:00"}},
+		{name: "Minutes within the hour", startTime: "2025-07-05T05:42", hours: 1, expected: []string{"2025-07-05T05:00"}},
+		{name: "Cut at the end of the forecast", startTime: "2025-07-05T07:00", hours: 3, expected: []string{"2025-07-05T07:00"}},
+		{name: "Outside the forecast", startTime: "2025-07-06T05:00", hours: 1, expected: nil},
+		{name: "Date only", startTime: "2025-07-05", hours: 1, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := ExtractRunWindow(weather, tt.startTime, tt.hours)
+			if len(window) != len(tt.expected) {
+				t.Fatalf("Expected %d hours, got %d", len(tt.expected), len(window))
+			}
+			for i, data := range window {
+				if data.Time != tt.expected[i] {
+					t.Errorf("Expected %s at %d, got %s", tt.expected[i], i, data.Time)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractRunnableHours(t *testing.T) {
+	weather := &types.WeatherData{
+		Hourly: types.HourlyWeather{
+			Time: []string{
+				"2025-07-05T02:00", "2025-07-05T05:00", "2025-07-05T23:00",
+				"2025-07-06T05:00",
+			},
+			Temperature:              []float64{18.0, 19.0, 22.0, 20.0},
+			ApparentTemp:             []float64{18.0, 19.0, 22.0, 20.0},
+			Humidity:                 []int{80, 75, 70, 70},
+			WindSpeed:                []float64{1.0, 2.0, 2.0, 1.0},
+			WindDirection:            []float64{0, 90, 180, 270},
+			Precipitation:            []float64{0, 0, 0, 0},
+			WeatherCode:              []int{0, 0, 1, 1},
+			PrecipitationProbability: []float64{0, 10, 20, 30},
+		},
+	}
+
+	hours := ExtractRunnableHours(weather, "2025-07-05")
+	if len(hours) != 2 {
+		t.Fatalf("Expected 2 hours between the periods, got %d", len(hours))
+	}
+	if hours[0].Time != "2025-07-05T05:00" || hours[1].Time != "2025-07-05T23:00" {
+		t.Errorf("Unexpected hours %s and %s", hours[0].Time, hours[1].Time)
+	}
+	if hours[1].PrecipitationProbability != 20 {
+		t.Errorf("Expected a 20%% chance of rain at 23:00, got %.0f", hours[1].PrecipitationProbability)
+	}
+}
+
 func TestGetDateDisplayName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -156,6 +280,26 @@ func TestGetDateDisplayName(t *testing.T) {
 			dateSpec: "day-after-tomorrow",
 			expected: "明後日の",
 		},
+		{
+			name:     "iso_date",
+			dateSpec: "2026-10-20",
+			expected: "10月20日(火)の",
+		},
+		{
+			name:     "weekday",
+			dateSpec: "sat",
+			expected: "土曜日の",
+		},
+		{
+			name:     "next_weekday",
+			dateSpec: "next-sun",
+			expected: "来週日曜日の",
+		},
+		{
+			name:     "relative_days",
+			dateSpec: "+5d",
+			expected: "5日後の",
+		},
 		{
 			name:     "invalid",
 			dateSpec: "invalid",
@@ -211,37 +355,65 @@ func TestGetDateOffset(t *testing.T) {
 	}
 }
 
+func TestParseDateOffset(t *testing.T) {
+	// Friday 2026-10-16 07:30 JST
+	now := time.Date(2026, 10, 16, 7, 30, 0, 0, DefaultLocation)
+
+	tests := []struct {
+		name        string
+		dateSpec    string
+		expected    int
+		expectError bool
+	}{
+		{name: "today", dateSpec: "today", expected: 0},
+		{name: "tomorrow", dateSpec: "tomorrow", expected: 1},
+		{name: "day-after-tomorrow", dateSpec: "day-after-tomorrow", expected: 2},
+		{name: "iso_today", dateSpec: "2026-10-16", expected: 0},
+		{name: "iso_future", dateSpec: "2026-10-20", expected: 4},
+		{name: "iso_next_month", dateSpec: "2026-11-01", expected: 16},
+		{name: "iso_past", dateSpec: "2026-10-15", expectError: true},
+		{name: "iso_invalid", dateSpec: "2026-13-01", expectError: true},
+		{name: "weekday_same_day", dateSpec: "fri", expected: 0},
+		{name: "weekday_upcoming", dateSpec: "sat", expected: 1},
+		{name: "weekday_wraps", dateSpec: "thu", expected: 6},
+		{name: "weekday_full_name", dateSpec: "Sunday", expected: 2},
+		{name: "next_weekday", dateSpec: "next-sun", expected: 9},
+		{name: "next_same_weekday", dateSpec: "next-fri", expected: 7},
+		{name: "relative", dateSpec: "+5d", expected: 5},
+		{name: "relative_zero", dateSpec: "+0d", expected: 0},
+		{name: "relative_negative", dateSpec: "+-1d", expectError: true},
+		{name: "relative_without_unit", dateSpec: "+5", expectError: true},
+		{name: "next_week", dateSpec: "next-week", expectError: true},
+		{name: "empty", dateSpec: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDateOffset(tt.dateSpec, now)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for dateSpec '%s', got offset %d", tt.dateSpec, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for dateSpec '%s': %v", tt.dateSpec, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestExtractDateBasedWeather(t *testing.T) {
 	// Create mock weather data with daily data
 	weather := &types.WeatherData{
-		Current: struct {
-			Temperature   float64 `json:"temperature_2m"`
-			ApparentTemp  float64 `json:"apparent_temperature"`
-			Humidity      int     `json:"relative_humidity_2m"`
-			WindSpeed     float64 `json:"wind_speed_10m"`
-			WindDirection float64 `json:"wind_direction_10m"`
-			Precipitation float64 `json:"precipitation"`
-			WeatherCode   int     `json:"weather_code"`
-		}{
+		Timezone: "Asia/Tokyo",
+		Current: types.CurrentWeather{
 			Temperature: 25.0,
 		},
-		Daily: struct {
-			Time                        []string  `json:"time"`
-			TemperatureMax              []float64 `json:"temperature_2m_max"`
-			TemperatureMin              []float64 `json:"temperature_2m_min"`
-			WindSpeedMax                []float64 `json:"wind_speed_10m_max"`
-			WindGustMax                 []float64 `json:"wind_gusts_10m_max"`
-			PrecipitationSum            []float64 `json:"precipitation_sum"`
-			WeatherCode                 []int     `json:"weather_code"`
-			SunriseTime                 []string  `json:"sunrise"`
-			SunsetTime                  []string  `json:"sunset"`
-			DaylightDuration            []float64 `json:"daylight_duration"`
-			SunshineDuration            []float64 `json:"sunshine_duration"`
-			UvIndexMax                  []float64 `json:"uv_index_max"`
-			UvIndexClearSkyMax          []float64 `json:"uv_index_clear_sky_max"`
-			PrecipitationHours          []float64 `json:"precipitation_hours"`
-			PrecipitationProbabilityMax []float64 `json:"precipitation_probability_max"`
-		}{
+		Daily: types.DailyWeather{
 			Time:           []string{"2025-07-05", "2025-07-06", "2025-07-07"},
 			TemperatureMax: []float64{30.0, 28.0, 26.0},
 			TemperatureMin: []float64{20.0, 18.0, 16.0},
@@ -249,34 +421,116 @@ func TestExtractDateBasedWeather(t *testing.T) {
 			PrecipitationSum: []float64{0.0, 1.0, 2.0},
 			WeatherCode:    []int{0, 1, 2},
 		},
-		Hourly: struct {
-			Time          []string  `json:"time"`
-			Temperature   []float64 `json:"temperature_2m"`
-			ApparentTemp  []float64 `json:"apparent_temperature"`
-			Humidity      []int     `json:"relative_humidity_2m"`
-			WindSpeed     []float64 `json:"wind_speed_10m"`
-			WindDirection []float64 `json:"wind_direction_10m"`
-			Precipitation []float64 `json:"precipitation"`
-			WeatherCode   []int     `json:"weather_code"`
-		}{
-			Time: []string{"2025-07-05T00:00", "2025-07-05T01:00", "2025-07-06T00:00"},
+		Hourly: types.HourlyWeather{
+			Time:        []string{"2025-07-05T00:00", "2025-07-05T01:00", "2025-07-06T00:00"},
+			Temperature: []float64{20.0, 19.0, 18.0},
 		},
 	}
 
-	// Test dayOffset 0 (today)
-	result := ExtractDateBasedWeather(weather, 0)
-	if result != weather {
-		t.Error("Expected same weather data for dayOffset 0")
+	// The first day keeps only its own data
+	result, err := ExtractDateBasedWeather(weather, "2025-07-05")
+	if err != nil {
+		t.Fatalf("Unexpected error for 2025-07-05: %v", err)
+	}
+	if len(result.Daily.Time) != 1 || result.Daily.Time[0] != "2025-07-05" || result.Current.Temperature != 25.0 {
+		t.Errorf("Expected 2025-07-05 with the current conditions, got %v", result.Daily.Time)
+	}
+	if len(result.Hourly.Time) != 2 {
+		t.Errorf("Expected the hourly data of 2025-07-05, got %v", result.Hourly.Time)
 	}
 
-	// Test dayOffset 1 (tomorrow)
-	result = ExtractDateBasedWeather(weather, 1)
+	// A later day
+	result, err = ExtractDateBasedWeather(weather, "2025-07-06")
+	if err != nil {
+		t.Fatalf("Unexpected error for 2025-07-06: %v", err)
+	}
 	if len(result.Daily.Time) != 1 {
 		t.Errorf("Expected 1 daily entry for tomorrow, got %d", len(result.Daily.Time))
 	}
 	if len(result.Daily.Time) > 0 && result.Daily.Time[0] != "2025-07-06" {
 		t.Errorf("Expected date 2025-07-06, got %s", result.Daily.Time[0])
 	}
+	if result.Timezone != "Asia/Tokyo" {
+		t.Errorf("Expected the timezone to be kept, got %q", result.Timezone)
+	}
+
+	// Hourly data should be narrowed down to the selected day
+	if len(result.Hourly.Time) != 1 || result.Hourly.Time[0] != "2025-07-06T00:00" {
+		t.Errorf("Expected hourly data for 2025-07-06 only, got %v", result.Hourly.Time)
+	}
+	if len(result.Hourly.Temperature) != 1 || result.Hourly.Temperature[0] != 18.0 {
+		t.Errorf("Expected hourly temperature [18.0], got %v", result.Hourly.Temperature)
+	}
+
+	// Dates outside the fetched range
+	for _, date := range []string{"2025-07-08", "2025-07-04", ""} {
+		if _, err := ExtractDateBasedWeather(weather, date); err == nil {
+			t.Errorf("Expected error for %q outside the forecast range", date)
+		}
+	}
+}
+
+func TestExtractForecastDays(t *testing.T) {
+	weather := &types.WeatherData{
+		Daily: types.DailyWeather{
+			Time:           []string{"2025-07-05", "2025-07-06", "2025-07-07"},
+			TemperatureMax: []float64{30.0, 28.0, 26.0},
+		},
+		Hourly: types.HourlyWeather{
+			Time:        []string{"2025-07-05T23:00", "2025-07-06T00:00", "2025-07-06T01:00", "2025-07-07T00:00"},
+			Temperature: []float64{20.0, 19.0, 18.0, 17.0},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		date          string
+		days          int
+		expectedDaily []string
+		expectedHours int
+	}{
+		{"from a later day", "2025-07-06", 2, []string{"2025-07-06", "2025-07-07"}, 3},
+		{"cut at the end of the forecast", "2025-07-07", 3, []string{"2025-07-07"}, 1},
+		{"at least one day", "2025-07-05", 0, []string{"2025-07-05"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractForecastDays(weather, tt.date, tt.days)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if strings.Join(result.Daily.Time, ",") != strings.Join(tt.expectedDaily, ",") {
+				t.Errorf("Expected days %v, got %v", tt.expectedDaily, result.Daily.Time)
+			}
+			if len(result.Daily.TemperatureMax) != len(tt.expectedDaily) {
+				t.Errorf("Expected %d daily temperatures, got %v", len(tt.expectedDaily), result.Daily.TemperatureMax)
+			}
+			if len(result.Hourly.Time) != tt.expectedHours || len(result.Hourly.Temperature) != tt.expectedHours {
+				t.Errorf("Expected %d hours, got %v", tt.expectedHours, result.Hourly.Time)
+			}
+		})
+	}
+
+	// Data cached on an earlier day does not cover today
+	if _, err := ExtractForecastDays(weather, "2025-07-08", 1); err == nil {
+		t.Error("Expected an error for a day after the forecast")
+	}
+}
+
+func TestForecastDate(t *testing.T) {
+	newYork := &types.WeatherData{Timezone: "America/New_York", UTCOffsetSeconds: -4 * 3600}
+	now := time.Date(2025, 7, 5, 10, 0, 0, 0, DefaultLocation) // 2025-07-04 21:00 in New York
+
+	if date := ForecastDate(&types.WeatherData{}, now, 0); date != "2025-07-05" {
+		t.Errorf("Expected 2025-07-05 in Japan, got %s", date)
+	}
+	if date := ForecastDate(&types.WeatherData{}, now, 2); date != "2025-07-07" {
+		t.Errorf("Expected 2025-07-07 in Japan, got %s", date)
+	}
+	if date := ForecastDate(newYork, now, 1); date != "2025-07-05" {
+		t.Errorf("Expected 2025-07-05 in New York, got %s", date)
+	}
 }
 
 func TestValidateDateSpec(t *testing.T) {
@@ -300,6 +554,26 @@ func TestValidateDateSpec(t *testing.T) {
 			dateSpec: "day-after-tomorrow",
 			expected: true,
 		},
+		{
+			name:     "valid_weekday",
+			dateSpec: "sat",
+			expected: true,
+		},
+		{
+			name:     "valid_next_weekday",
+			dateSpec: "next-sun",
+			expected: true,
+		},
+		{
+			name:     "valid_relative",
+			dateSpec: "+5d",
+			expected: true,
+		},
+		{
+			name:     "invalid_past_date",
+			dateSpec: "2020-01-01",
+			expected: false,
+		},
 		{
 			name:     "invalid_yesterday",
 			dateSpec: "yesterday",
@@ -393,4 +667,108 @@ func TestValidateTimeSpec(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestExpectedLocation(t *testing.T) {
+	tests := []struct {
+		name           string
+		coord          types.CityCoordinate
+		expectedName   string
+		expectedOffset int
+	}{
+		{"Japan by default", types.CityCoordinate{Lat: 35.68, Lon: 139.76}, "Asia/Tokyo", 9 * 60 * 60},
+		{"IANA timezone", types.CityCoordinate{Lat: 40.71, Lon: -74.01, Timezone: "America/New_York"}, "EST", -5 * 60 * 60},
+		{"auto from the longitude", types.CityCoordinate{Lat: 51.51, Lon: -0.13, Timezone: TimezoneAuto}, TimezoneAuto, 0},
+		{"auto in the west", types.CityCoordinate{Lat: 21.31, Lon: -157.86, Timezone: TimezoneAuto}, TimezoneAuto, -11 * 60 * 60},
+		{"unknown timezone", types.CityCoordinate{Timezone: "Mars/Olympus"}, "Asia/Tokyo", 9 * 60 * 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winter := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+			name, offset := winter.In(ExpectedLocation(tt.coord)).Zone()
+			if name != tt.expectedName || offset != tt.expectedOffset {
+				t.Errorf("Expected %s %d, got %s %d", tt.expectedName, tt.expectedOffset, name, offset)
+			}
+		})
+	}
+}
+
+func TestForecastLocationDST(t *testing.T) {
+	// Fetched on Saturday in EDT; New York falls back to EST on Sunday, 2 November 2025
+	weatherData := &types.WeatherData{
+		Timezone:         "America/New_York",
+		UTCOffsetSeconds: -4 * 60 * 60,
+		Daily: types.DailyWeather{
+			Time:        []string{"2025-11-01", "2025-11-02", "2025-11-03"},
+			SunriseTime: []string{"2025-11-01T07:26", "2025-11-02T06:27", "2025-11-03T06:28"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected string
+	}{
+		{"before the change", time.Date(2025, 11, 2, 3, 30, 0, 0, time.UTC), "2025-11-01"},
+		{"after the change", time.Date(2025, 11, 3, 4, 30, 0, 0, time.UTC), "2025-11-02"},
+		{"after midnight EST", time.Date(2025, 11, 3, 5, 30, 0, 0, time.UTC), "2025-11-03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if date := ForecastDate(weatherData, tt.now, 0); date != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, date)
+			}
+		})
+	}
+
+	sunrise, err := time.ParseInLocation(ForecastTimeLayout, weatherData.Daily.SunriseTime[2], ForecastLocation(weatherData))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := time.Date(2025, 11, 3, 11, 28, 0, 0, time.UTC); !sunrise.Equal(expected) {
+		t.Errorf("Expected the sunrise at %v, got %v", expected, sunrise.UTC())
+	}
+}
+
+func TestForecastLocation(t *testing.T) {
+	if location := ForecastLocation(&types.WeatherData{}); location != DefaultLocation {
+		t.Errorf("Expected DefaultLocation for a forecast without a timezone, got %v", location)
+	}
+
+	weatherData := &types.WeatherData{Timezone: "Europe/London", UTCOffsetSeconds: 3600}
+	name, offset := time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC).In(ForecastLocation(weatherData)).Zone()
+	if name != "BST" || offset != 3600 {
+		t.Errorf("Expected the forecast's timezone, got %s %d", name, offset)
+	}
+
+	// A timezone the host cannot load falls back to the offset of the response
+	weatherData = &types.WeatherData{Timezone: "Mars/Olympus", UTCOffsetSeconds: 3600}
+	name, offset = time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC).In(ForecastLocation(weatherData)).Zone()
+	if name != "Mars/Olympus" || offset != 3600 {
+		t.Errorf("Expected the forecast's offset, got %s %d", name, offset)
+	}
+	if LocationTimezone(types.CityCoordinate{}) != DefaultTimezone || LocationTimezone(types.CityCoordinate{Timezone: TimezoneAuto}) != TimezoneAuto {
+		t.Error("Expected the default timezone only for locations without one")
+	}
+}
+
+func TestIsMiddayTime(t *testing.T) {
+	tests := []struct {
+		time     string
+		expected bool
+	}{
+		{"2025-07-05T06:00", false},
+		{"2025-07-05T11:00", true},
+		{"2025-07-05T15:00", true},
+		{"2025-07-05T16:00", false},
+		{"2025-07-05", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMiddayTime(tt.time); got != tt.expected {
+			t.Errorf("IsMiddayTime(%q) = %v, expected %v", tt.time, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package weather
+
+import (
+	"math"
+
+	"runcast/internal/types"
+)
+
+// EstimateWBGT estimates the outdoor WBGT (°C) from air temperature (°C), relative humidity (%),
+// wind speed (m/s) and shortwave radiation (W/m²).
+// It uses the regression published by the Ministry of the Environment (Ono & Tonouchi, 2014).
+func EstimateWBGT(temp, humidity, windSpeed, shortwaveRadiation float64) float64 {
+	radiation := math.Max(shortwaveRadiation, 0) / 1000 // kW/m²
+	wbgt := 0.735*temp +
+		0.0374*humidity +
+		0.00292*temp*humidity +
+		7.619*radiation -
+		4.557*radiation*radiation -
+		0.0572*math.Max(windSpeed, 0) -
+		4.064
+	return math.Round(wbgt*10) / 10
+}
+
+// GetPeakWBGT returns the highest estimated WBGT among the hourly entries on the given date (YYYY-MM-DD).
+// The second return value is false when there is no hourly data for the date.
+func GetPeakWBGT(weather *types.WeatherData, date string) (float64, bool) {
+	start, end := hourlyRangeForDate(weather.Hourly.Time, date)
+	if start >= end {
+		return 0, false
+	}
+
+	peak := math.Inf(-1)
+	for i := start; i < end; i++ {
+		wbgt := EstimateWBGT(
+			valueAt(weather.Hourly.Temperature, i),
+			float64(valueAt(weather.Hourly.Humidity, i)),
+			valueAt(weather.Hourly.WindSpeed, i),
+			valueAt(weather.Hourly.ShortwaveRadiation, i),
+		)
+		peak = math.Max(peak, wbgt)
+	}
+	return peak, true
+}
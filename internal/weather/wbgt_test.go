@@ -0,0 +1,56 @@
+package weather
+
+import (
+	"testing"
+
+	"runcast/internal/types"
+)
+
+func TestEstimateWBGT(t *testing.T) {
+	tests := []struct {
+		name      string
+		temp      float64
+		humidity  float64
+		windSpeed float64
+		radiation float64
+		expected  float64
+	}{
+		{name: "Mild shade", temp: 20.0, humidity: 50, windSpeed: 2.0, radiation: 0, expected: 15.3},
+		{name: "Hot and humid shade", temp: 30.0, humidity: 70, windSpeed: 1.0, radiation: 0, expected: 26.7},
+		{name: "Hot and humid in the sun", temp: 30.0, humidity: 70, windSpeed: 1.0, radiation: 800, expected: 29.9},
+		{name: "Negative radiation is ignored", temp: 20.0, humidity: 50, windSpeed: 2.0, radiation: -10, expected: 15.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateWBGT(tt.temp, tt.humidity, tt.windSpeed, tt.radiation); got != tt.expected {
+				t.Errorf("Expected WBGT %.1f, got %.1f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetPeakWBGT(t *testing.T) {
+	weather := &types.WeatherData{
+		Hourly: types.HourlyWeather{
+			Time:               []string{"2025-07-05T12:00", "2025-07-06T09:00", "2025-07-06T13:00", "2025-07-06T18:00"},
+			Temperature:        []float64{35.0, 26.0, 31.0, 28.0},
+			Humidity:           []int{60, 70, 60, 70},
+			WindSpeed:          []float64{1.0, 2.0, 2.0, 2.0},
+			ShortwaveRadiation: []float64{900, 400, 850, 50},
+		},
+	}
+
+	peak, exists := GetPeakWBGT(weather, "2025-07-06")
+	if !exists {
+		t.Fatal("Expected hourly data for 2025-07-06")
+	}
+	expected := EstimateWBGT(31.0, 60, 2.0, 850)
+	if peak != expected {
+		t.Errorf("Expected peak WBGT %.1f, got %.1f", expected, peak)
+	}
+
+	if _, exists := GetPeakWBGT(weather, "2025-07-07"); exists {
+		t.Error("Expected no hourly data for 2025-07-07")
+	}
+}
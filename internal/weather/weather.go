@@ -1,33 +1,33 @@
 package weather
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
+	"math"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 	"runcast/internal/config"
+	"runcast/internal/i18n"
 	"runcast/internal/types"
 )
 
-const apiURL = "https://api.open-meteo.com/v1/jma"
-const airQualityAPIURL = "https://air-quality-api.open-meteo.com/v1/air-quality"
-
 // Cities holds all supported cities
 var Cities = map[string]types.CityCoordinate{
-	"tokyo":    {"東京", 35.6762, 139.6503},
-	"osaka":    {"大阪", 34.6937, 135.5023},
-	"kyoto":    {"京都", 35.0116, 135.7681},
-	"yokohama": {"横浜", 35.4437, 139.6380},
-	"nagoya":   {"名古屋", 35.1815, 136.9066},
-	"sapporo":  {"札幌", 43.0642, 141.3469},
-	"fukuoka":  {"福岡", 33.5904, 130.4017},
-	"sendai":   {"仙台", 38.2682, 140.8694},
-	"hiroshima":{"広島", 34.3853, 132.4553},
-	"naha":     {"那覇", 26.2124, 127.6792},
-	"kobe":     {"神戸", 34.6901, 135.1956},
-	"shiga":    {"滋賀", 35.0044, 135.8686},
+	"tokyo":    {Name: "東京", Lat: 35.6762, Lon: 139.6503},
+	"osaka":    {Name: "大阪", Lat: 34.6937, Lon: 135.5023},
+	"kyoto":    {Name: "京都", Lat: 35.0116, Lon: 135.7681},
+	"yokohama": {Name: "横浜", Lat: 35.4437, Lon: 139.6380},
+	"nagoya":   {Name: "名古屋", Lat: 35.1815, Lon: 136.9066},
+	"sapporo":  {Name: "札幌", Lat: 43.0642, Lon: 141.3469},
+	"fukuoka":  {Name: "福岡", Lat: 33.5904, Lon: 130.4017},
+	"sendai":   {Name: "仙台", Lat: 38.2682, Lon: 140.8694},
+	"hiroshima":{Name: "広島", Lat: 34.3853, Lon: 132.4553},
+	"naha":     {Name: "那覇", Lat: 26.2124, Lon: 127.6792},
+	"kobe":     {Name: "神戸", Lat: 34.6901, Lon: 135.1956},
+	"shiga":    {Name: "滋賀", Lat: 35.0044, Lon: 135.8686},
 }
 
 // GetSupportedCities returns a list of all supported city names
@@ -42,21 +42,51 @@ func GetSupportedCities() []string {
 
 // GetCityCoordinate returns city coordinates by city name
 func GetCityCoordinate(city string) (*types.CityCoordinate, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		// If config loading fails, continue with built-in cities only
+		fmt.Fprintln(os.Stderr, i18n.T("warning.config_load", err))
+		cfg = nil
+	}
+
+	return ResolveCityCoordinate(city, cfg)
+}
+
+// ResolveCityCoordinate returns city coordinates by city name using an already loaded config.
+// Coordinates such as 35.68,139.76 or geo:35.68,139.76 are used as they are.
+// Otherwise built-in cities come first, then the custom locations and finally the gazetteer of municipalities.
+// cfg may be nil, in which case the custom locations are skipped.
+func ResolveCityCoordinate(city string, cfg *config.Config) (*types.CityCoordinate, error) {
+	if config.IsCoordinate(city) {
+		lat, lon, err := config.ParseCoordinate(city)
+		if err != nil {
+			return nil, errors.New(i18n.T("error.invalid_coordinate", city, err))
+		}
+		// Coordinates may lie anywhere, so their timezone is left to the forecast API
+		return &types.CityCoordinate{Name: CoordinateName(lat, lon), Lat: lat, Lon: lon, Timezone: TimezoneAuto}, nil
+	}
+
 	// Check built-in cities first
 	if coord, exists := Cities[city]; exists {
+		coord.Name = GetCityDisplayName(city, coord.Name)
 		return &coord, nil
 	}
 	
 	// Check custom locations from config
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		// If config loading fails, continue with built-in cities only
-		fmt.Printf("警告: 設定ファイルの読み込みに失敗しました: %v\n", err)
-	} else {
+	if cfg != nil {
 		if coord, exists := cfg.GetCustomLocation(city); exists {
 			return coord, nil
 		}
 	}
+
+	// Look the name up in the gazetteer of municipalities
+	municipality, err := FindMunicipality(city)
+	if err != nil {
+		return nil, err
+	}
+	if municipality != nil {
+		return municipality.Coordinate(), nil
+	}
 	
 	// Generate error message with all available locations
 	supportedCities := GetSupportedCities()
@@ -72,108 +102,85 @@ func GetCityCoordinate(city string) (*types.CityCoordinate, error) {
 		}
 	}
 	
-	return nil, fmt.Errorf("都市が見つかりません: %s\n対応都市: %v", city, allLocations)
+	return nil, errors.New(i18n.T("error.city_not_found", city, allLocations))
 }
 
-// GetWeather fetches weather data from API
-func GetWeather(lat, lon float64) (*types.WeatherData, error) {
-	forecastDays := 1
-	
-	var url string
-	currentParams := "temperature_2m,apparent_temperature,relative_humidity_2m,wind_speed_10m,wind_direction_10m,weather_code,precipitation,dewpoint_2m"
-	dailyParams := "temperature_2m_max,temperature_2m_min,weather_code,wind_speed_10m_max,precipitation_sum"
-	hourlyParams := "temperature_2m,apparent_temperature,relative_humidity_2m,wind_speed_10m,wind_direction_10m,weather_code,precipitation"
-	
+// City is a location that can be given as the city, built in or from the config file
+type City struct {
+	Key        string
+	Coordinate types.CityCoordinate // with the localized name for built-in cities
+	Custom     bool
+}
 
-	// 予報データ
-	url = fmt.Sprintf("%s?latitude=%s&longitude=%s&current=%s&daily=%s&hourly=%s&timezone=Asia/Tokyo&forecast_days=%d", 
-		apiURL, 
-		strconv.FormatFloat(lat, 'f', 4, 64), 
-		strconv.FormatFloat(lon, 'f', 4, 64),
-		currentParams,
-		dailyParams,
-		hourlyParams,
-		forecastDays)
-	
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("API request failed: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+// ListCities returns the built-in cities followed by the custom locations of cfg, each sorted by key.
+// cfg may be nil, in which case only built-in cities are listed.
+func ListCities(cfg *config.Config) []City {
+	var cities []City
+	for _, key := range GetSupportedCities() {
+		coord := Cities[key]
+		coord.Name = GetCityDisplayName(key, coord.Name)
+		cities = append(cities, City{Key: key, Coordinate: coord})
 	}
-	
-	var weather types.WeatherData
-	if err := json.NewDecoder(resp.Body).Decode(&weather); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if cfg != nil {
+		keys := cfg.GetCustomLocationNames()
+		sort.Strings(keys)
+		for _, key := range keys {
+			cities = append(cities, City{Key: key, Coordinate: cfg.Locations[key], Custom: true})
+		}
 	}
-	
-	return &weather, nil
+	return cities
 }
 
-// GetAirQuality fetches air quality data from API
-func GetAirQuality(lat, lon float64) (*types.AirQualityData, error) {
-	url := fmt.Sprintf("%s?latitude=%s&longitude=%s&hourly=dust,pm10,pm2_5&timezone=Asia/Tokyo&forecast_days=1",
-		airQualityAPIURL,
-		strconv.FormatFloat(lat, 'f', 4, 64),
-		strconv.FormatFloat(lon, 'f', 4, 64))
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// CoordinateName returns a display name for coordinates given directly, e.g. 35.68°N 139.76°E
+func CoordinateName(lat, lon float64) string {
+	latHemisphere, lonHemisphere := "N", "E"
+	if lat < 0 {
+		latHemisphere = "S"
 	}
-
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("Air Quality API request failed: %w", err)
+	if lon < 0 {
+		lonHemisphere = "W"
 	}
-	defer resp.Body.Close()
+	return fmt.Sprintf("%s°%s %s°%s", formatDegrees(lat), latHemisphere, formatDegrees(lon), lonHemisphere)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Air Quality API request failed with status: %d", resp.StatusCode)
-	}
+// formatDegrees formats the magnitude of an angle with up to four decimals
+func formatDegrees(degrees float64) string {
+	return strconv.FormatFloat(math.Round(math.Abs(degrees)*10000)/10000, 'f', -1, 64)
+}
 
-	var airQuality types.AirQualityData
-	if err := json.NewDecoder(resp.Body).Decode(&airQuality); err != nil {
-		return nil, fmt.Errorf("failed to decode air quality response: %w", err)
+// GetCityDisplayName returns the localized name of a built-in city, or name if there is none
+func GetCityDisplayName(key, name string) string {
+	if localized, exists := i18n.Lookup("city." + key); exists {
+		return localized
 	}
+	return name
+}
 
-	return &airQuality, nil
+// GetWeather fetches weather data in local times of the timezone for the given number of forecast days using the default provider
+func GetWeather(lat, lon float64, timezone string, forecastDays int) (*types.WeatherData, error) {
+	return NewOpenMeteoProvider().Forecast(lat, lon, timezone, forecastDays)
+}
+
+// GetAirQuality fetches air quality data in local times of the timezone for the given number of forecast days using the default provider
+func GetAirQuality(lat, lon float64, timezone string, forecastDays int) (*types.AirQualityData, error) {
+	return NewOpenMeteoProvider().AirQuality(lat, lon, timezone, forecastDays)
 }
 
 // GetCurrentDustLevel returns current dust level based on air quality data
 func GetCurrentDustLevel(airQuality *types.AirQualityData) *types.DustLevel {
+	return GetCurrentDustLevelAt(airQuality, time.Now())
+}
+
+// GetCurrentDustLevelAt returns the dust level for the hour containing now in the data's timezone,
+// falling back to the first available entry
+func GetCurrentDustLevelAt(airQuality *types.AirQualityData, now time.Time) *types.DustLevel {
 	if airQuality == nil || len(airQuality.Hourly.Time) == 0 {
 		return nil
 	}
 
 	// Find current hour data
-	now := time.Now()
-	currentHour := now.Format("2006-01-02T15:00")
-
-	for i, t := range airQuality.Hourly.Time {
-		if t == currentHour {
-			dust := 0.0
-			pm10 := 0.0
-			pm2_5 := 0.0
-
-			if i < len(airQuality.Hourly.Dust) {
-				dust = airQuality.Hourly.Dust[i]
-			}
-			if i < len(airQuality.Hourly.PM10) {
-				pm10 = airQuality.Hourly.PM10[i]
-			}
-			if i < len(airQuality.Hourly.PM2_5) {
-				pm2_5 = airQuality.Hourly.PM2_5[i]
-			}
-
-			return createDustLevel(dust, pm10, pm2_5)
-		}
+	if dustLevel := GetDustLevelAt(airQuality, now.In(AirQualityLocation(airQuality)).Format("2006-01-02T15:00")); dustLevel != nil {
+		return dustLevel
 	}
 
 	// If current hour not found, use first available data
@@ -194,17 +201,46 @@ func GetCurrentDustLevel(airQuality *types.AirQualityData) *types.DustLevel {
 	return createDustLevel(dust, pm10, pm2_5)
 }
 
-// GetHourlyDustLevel returns dust level for a specific hour
+// GetHourlyDustLevel returns dust level for a specific hour of the day days from today in the data's timezone
 func GetHourlyDustLevel(airQuality *types.AirQualityData, hour int, days int) *types.DustLevel {
-	if airQuality == nil || len(airQuality.Hourly.Time) == 0 {
+	targetDate := time.Now().In(AirQualityLocation(airQuality)).AddDate(0, 0, days)
+	targetTime := fmt.Sprintf("%sT%02d:00", targetDate.Format("2006-01-02"), hour)
+
+	return GetDustLevelAt(airQuality, targetTime)
+}
+
+// GetDailyDustLevel returns the dust level of the worst hours of a date (YYYY-MM-DD),
+// or nil when the air quality forecast does not cover the date
+func GetDailyDustLevel(airQuality *types.AirQualityData, date string) *types.DustLevel {
+	if airQuality == nil {
 		return nil
 	}
 
-	targetDate := time.Now().AddDate(0, 0, days)
-	targetTime := fmt.Sprintf("%sT%02d:00", targetDate.Format("2006-01-02"), hour)
+	found := false
+	dust, pm10, pm2_5 := 0.0, 0.0, 0.0
+	for i, t := range airQuality.Hourly.Time {
+		if !strings.HasPrefix(t, date+"T") {
+			continue
+		}
+		found = true
+		dust = math.Max(dust, valueAt(airQuality.Hourly.Dust, i))
+		pm10 = math.Max(pm10, valueAt(airQuality.Hourly.PM10, i))
+		pm2_5 = math.Max(pm2_5, valueAt(airQuality.Hourly.PM2_5, i))
+	}
+	if !found {
+		return nil
+	}
+	return createDustLevel(dust, pm10, pm2_5)
+}
+
+// GetDustLevelAt returns dust level for the hourly entry matching the given ISO time string (YYYY-MM-DDTHH:MM)
+func GetDustLevelAt(airQuality *types.AirQualityData, timeStr string) *types.DustLevel {
+	if airQuality == nil || len(airQuality.Hourly.Time) == 0 {
+		return nil
+	}
 
 	for i, t := range airQuality.Hourly.Time {
-		if t == targetTime {
+		if t == timeStr {
 			dust := 0.0
 			pm10 := 0.0
 			pm2_5 := 0.0
@@ -229,77 +265,35 @@ func GetHourlyDustLevel(airQuality *types.AirQualityData, hour int, days int) *t
 // createDustLevel creates DustLevel from raw values
 func createDustLevel(dust, pm10, pm2_5 float64) *types.DustLevel {
 	level := 0
-	displayName := "なし"
-	description := "黄砂の影響なし"
-
 	if dust > 500 {
 		level = 4
-		displayName = "非常に多い"
-		description = "屋外活動は控えるべき"
 	} else if dust > 200 {
 		level = 3
-		displayName = "多い"
-		description = "外出時に注意が必要"
 	} else if dust > 100 {
 		level = 2
-		displayName = "やや多い"
-		description = "視程に影響の可能性"
 	} else if dust > 50 {
 		level = 1
-		displayName = "少ない"
-		description = "わずかに飛来"
 	}
 
 	return &types.DustLevel{
 		Level:       level,
-		DisplayName: displayName,
-		Description: description,
+		DisplayName: i18n.T(fmt.Sprintf("dust.%d.name", level)),
+		Description: i18n.T(fmt.Sprintf("dust.%d.desc", level)),
 		Dust:        dust,
 		PM10:        pm10,
 		PM2_5:       pm2_5,
 	}
 }
 
-// GetWeatherDescription returns Japanese weather description
+// GetWeatherDescription returns the localized weather description for a WMO weather code
 func GetWeatherDescription(code int) string {
-	weatherCodes := map[int]string{
-		0:  "快晴",
-		1:  "晴れ",
-		2:  "一部曇り",
-		3:  "曇り",
-		45: "霧",
-		48: "着氷霧",
-		51: "弱い霧雨",
-		53: "霧雨",
-		55: "強い霧雨",
-		56: "軽い着氷霧雨",
-		57: "着氷霧雨",
-		61: "弱い雨",
-		63: "雨",
-		65: "強い雨",
-		66: "軽い着氷雨",
-		67: "着氷雨",
-		71: "弱い雪",
-		73: "雪",
-		75: "強い雪",
-		77: "雪つぶ",
-		80: "弱いにわか雨",
-		81: "にわか雨",
-		82: "強いにわか雨",
-		85: "弱いにわか雪",
-		86: "にわか雪",
-		95: "雷雨",
-		96: "雹を伴う雷雨",
-		99: "強い雹を伴う雷雨",
-	}
-	
-	if desc, exists := weatherCodes[code]; exists {
+	if desc, exists := i18n.Lookup("weather.code." + strconv.Itoa(code)); exists {
 		return desc
 	}
-	return "不明"
+	return i18n.T("weather.unknown")
 }
 
-// FormatDate formats date string to Japanese format
+// FormatDate formats date string in the localized month/day format
 func FormatDate(dateStr string) string {
 	if len(dateStr) < 10 {
 		return dateStr
@@ -311,16 +305,11 @@ func FormatDate(dateStr string) string {
 		return dateStr
 	}
 	
-	return t.Format("01月02日")
+	return t.Format(i18n.T("date.layout"))
 }
 
-// GetWindDirection converts wind direction to Japanese
+// GetWindDirection converts wind direction to one of 16 localized compass points
 func GetWindDirection(direction float64) string {
-	directions := []string{
-		"北", "北北東", "北東", "東北東", "東", "東南東", "南東", "南南東",
-		"南", "南南西", "南西", "西南西", "西", "西北西", "北西", "北北西",
-	}
-	
 	index := int((direction + 11.25) / 22.5) % 16
-	return directions[index]
-}
\ No newline at end of file
+	return i18n.T("wind." + strconv.Itoa(index))
+}
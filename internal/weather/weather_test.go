@@ -2,6 +2,9 @@ package weather
 
 import (
 	"testing"
+
+	"runcast/internal/config"
+	"runcast/internal/types"
 )
 
 func TestGetCityCoordinate(t *testing.T) {
@@ -409,4 +412,93 @@ func TestCreateDustLevel(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestListCities(t *testing.T) {
+	cfg := &config.Config{
+		Locations: map[string]types.CityCoordinate{
+			"park": {Name: "公園", Lat: 35.7, Lon: 139.7},
+			"home": {Name: "自宅", Lat: 35.6, Lon: 139.6},
+		},
+	}
+
+	cities := ListCities(cfg)
+	builtIn := len(GetSupportedCities())
+	if len(cities) != builtIn+2 {
+		t.Fatalf("Expected %d cities, got %d", builtIn+2, len(cities))
+	}
+	if cities[0].Custom || cities[0].Coordinate.Name == "" {
+		t.Errorf("Expected a named built-in city first, got %+v", cities[0])
+	}
+	if cities[builtIn].Key != "home" || cities[builtIn+1].Key != "park" || !cities[builtIn].Custom {
+		t.Errorf("Expected the custom locations sorted last, got %+v", cities[builtIn:])
+	}
+
+	if cities := ListCities(nil); len(cities) != builtIn {
+		t.Errorf("Expected only the built-in cities without a config, got %d", len(cities))
+	}
+}
+
+func TestResolveCityCoordinateDirect(t *testing.T) {
+	tests := []struct {
+		city         string
+		expectedName string
+		expectError  bool
+	}{
+		{city: "35.68,139.76", expectedName: "35.68°N 139.76°E"},
+		{city: "geo:-33.8688,151.2093", expectedName: "33.8688°S 151.2093°E"},
+		{city: "51.477812,-0.001475", expectedName: "51.4778°N 0.0015°W"},
+		{city: "95,139.76", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.city, func(t *testing.T) {
+			coord, err := ResolveCityCoordinate(tt.city, nil)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", coord)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if coord.Name != tt.expectedName {
+				t.Errorf("Expected name %s, got %s", tt.expectedName, coord.Name)
+			}
+		})
+	}
+}
+
+func TestGetDailyDustLevel(t *testing.T) {
+	airQuality := &types.AirQualityData{}
+	airQuality.Hourly.Time = []string{"2025-07-05T23:00", "2025-07-06T00:00", "2025-07-06T01:00"}
+	airQuality.Hourly.Dust = []float64{600, 60, 120}
+	airQuality.Hourly.PM10 = []float64{300, 90, 40}
+
+	tests := []struct {
+		date     string
+		expected *types.DustLevel
+	}{
+		{"2025-07-05", &types.DustLevel{Level: 4, Dust: 600, PM10: 300}},
+		{"2025-07-06", &types.DustLevel{Level: 2, Dust: 120, PM10: 90}},
+		{"2025-07-07", nil},
+	}
+
+	for _, tt := range tests {
+		got := GetDailyDustLevel(airQuality, tt.date)
+		if tt.expected == nil {
+			if got != nil {
+				t.Errorf("GetDailyDustLevel(%s) = %+v, expected nil", tt.date, got)
+			}
+			continue
+		}
+		if got == nil || got.Level != tt.expected.Level || got.Dust != tt.expected.Dust || got.PM10 != tt.expected.PM10 {
+			t.Errorf("GetDailyDustLevel(%s) = %+v, expected %+v", tt.date, got, tt.expected)
+		}
+	}
+
+	if GetDailyDustLevel(nil, "2025-07-05") != nil {
+		t.Error("Expected nil without air quality data")
+	}
+}
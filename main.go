@@ -4,148 +4,90 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
-	"runcast/internal/display"
+	"runcast/internal/config"
+	"runcast/internal/i18n"
 	"runcast/internal/running"
-	"runcast/internal/types"
 	"runcast/internal/weather"
 )
 
 func showHelp() {
-	fmt.Println("🏃‍♂️ runcast - ランニング天気予報")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("使用方法:")
-	fmt.Println("  runcast [オプション]")
-	fmt.Println()
-	fmt.Println("オプション:")
-	fmt.Println("  -city string")
-	fmt.Println("      都市名を指定 (デフォルト: tokyo)")
-	fmt.Println("  -time string")
-	fmt.Println("      時間帯を指定 (morning, noon, evening, night)")
-	fmt.Println("  -date string")
-	fmt.Println("      日付を指定 (today, tomorrow, day-after-tomorrow)")
-	fmt.Println("  -distance string")
-	fmt.Println("      目標距離を指定 (5k, 10k, half, full)")
-	fmt.Println("  -help")
-	fmt.Println("      このヘルプを表示")
-	fmt.Println()
-	fmt.Println("対応都市:")
-	supportedCities := weather.GetSupportedCities()
-	for i, city := range supportedCities {
-		if i > 0 {
-			fmt.Print(", ")
-		}
-		fmt.Print(city)
-	}
-	fmt.Println()
-	fmt.Println()
-	fmt.Println("カスタム位置設定:")
-	fmt.Println("  .runcast.conf ファイルを作成することで任意の位置を追加できます")
-	fmt.Println("  設定ファイルの配置場所（優先順）:")
-	fmt.Println("    1. カレントディレクトリ: .runcast.conf")
-	fmt.Println("    2. ホームディレクトリ: ~/.runcast.conf")
-	fmt.Println("    3. 設定ディレクトリ: ~/.config/runcast/config.toml")
-	fmt.Println()
-	fmt.Println("  設定ファイルの例:")
-	fmt.Println("    [locations]")
-	fmt.Println("    home = { name = \"自宅\", lat = 35.6762, lon = 139.6503 }")
-	fmt.Println("    office = { name = \"会社\", lat = 35.6584, lon = 139.7016 }")
-	fmt.Println()
-	fmt.Println("例:")
-	fmt.Println("  runcast -city=osaka")
-	fmt.Println("  runcast -city=tokyo -time=morning")
-	fmt.Println("  runcast -city=kyoto -date=tomorrow -distance=10k")
-	fmt.Println("  runcast -city=home    # カスタム位置を使用")
+	fmt.Println(i18n.T("help", strings.Join(weather.GetSupportedCities(), ", ")))
 }
 
-func main() {
-	city := flag.String("city", "tokyo", "都市名を指定")
-	timeOfDay := flag.String("time", "", "時間帯を指定 (morning, noon, evening, night)")
-	dateSpec := flag.String("date", "", "日付を指定 (today, tomorrow, day-after-tomorrow)")
-	distanceFlag := flag.String("distance", "", "目標距離を指定 (5k, 10k, half, full)")
-	help := flag.Bool("help", false, "ヘルプを表示")
-	flag.Parse()
-
-	// Show help if requested
-	if *help {
+// commands are the subcommands; without one the flags of the flat invocation are accepted for backward compatibility
+var commands = map[string]func(args []string){
+	"now":    runNow,
+	"plan":   runPlan,
+	"cities": runCities,
+	"config": runConfig,
+	"serve":  runServe,
+	"help": func([]string) {
 		showHelp()
-		return
-	}
+	},
+}
+
+func main() {
+	// Pick the language from the environment first so that flag errors are localized too
+	i18n.SetLanguage(i18n.DetectLanguage("", "", os.Getenv))
 
-	// Distance category processing
-	var distanceCategory *types.DistanceCategory
-	if *distanceFlag != "" {
-		distanceCategory = running.GetDistanceCategory(*distanceFlag)
-		if distanceCategory == nil {
-			fmt.Printf("無効な距離です: %s\n", *distanceFlag)
-			fmt.Println("有効な距離: 5k, 10k, half, full")
+	if len(os.Args) > 1 {
+		if command, exists := commands[os.Args[1]]; exists {
+			command(os.Args[2:])
 			return
 		}
 	}
 
-	// Get city coordinates
-	coord, err := weather.GetCityCoordinate(*city)
-	if err != nil {
-		log.Fatal(err)
-	}
+	flags := flag.NewFlagSet("runcast", flag.ExitOnError)
+	flags.Usage = showHelp
+	opts := addForecastFlags(flags, true)
+	flags.Parse(os.Args[1:])
+	runForecast(opts, showHelp)
+}
 
-	// Validate date specification if provided
-	if *dateSpec != "" && !weather.ValidateDateSpec(*dateSpec) {
-		fmt.Printf("無効な日付指定です: %s\n", *dateSpec)
-		fmt.Println("有効な日付: today, tomorrow, day-after-tomorrow")
-		return
+// loadConfig loads the configuration, sets the display language and applies the custom time periods and scoring.
+// It returns nil when loading fails, in which case the defaults and built-in cities are used.
+func loadConfig(lang string) *config.Config {
+	cfg, configErr := config.LoadConfig()
+	configLanguage := ""
+	if configErr == nil {
+		configLanguage = cfg.Language
 	}
-
-	// Validate time specification if provided
-	if *timeOfDay != "" && !weather.ValidateTimeSpec(*timeOfDay) {
-		fmt.Printf("無効な時間指定です: %s\n", *timeOfDay)
-		fmt.Println("有効な時間: morning, noon, evening, night")
-		return
+	i18n.SetLanguage(i18n.DetectLanguage(lang, configLanguage, os.Getenv))
+	if configErr != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("warning.config_load", configErr))
+		return nil
 	}
 
-	// Determine required forecast days
-	requiredDays := 1 // Default to 1 day for running forecasts
-	if *dateSpec != "" {
-		dayOffset := weather.GetDateOffset(*dateSpec)
-		// Ensure we have enough data for the requested date
-		if requiredDays <= dayOffset {
-			requiredDays = dayOffset + 1
-		}
-	}
-	
-	// Get weather data
-	weatherData, err := weather.GetWeather(coord.Lat, coord.Lon)
-	if err != nil {
-		log.Fatal(err)
-	}
+	weather.SetCustomTimePeriods(cfg.TimePeriods())
+	running.SetScoring(cfg.Scoring.Scoring())
+	return cfg
+}
 
-	// Get air quality data
-	airQuality, err := weather.GetAirQuality(coord.Lat, coord.Lon)
-	if err != nil {
-		// Air quality data is optional, continue without it
-		fmt.Printf("警告: 大気質データの取得に失敗しました: %v\n", err)
-		airQuality = nil
+// newProvider sets up the weather data provider wrapped with the on-disk response cache
+func newProvider(cfg *config.Config, offline bool) weather.Provider {
+	var provider weather.Provider = weather.NewOpenMeteoProvider()
+	cacheConfig := config.CacheConfig{}
+	if cfg != nil {
+		provider = weather.NewOpenMeteoProviderFromConfig(cfg.Provider)
+		cacheConfig = cfg.Cache
 	}
 
-	// Display logic - always in running mode
-	if *dateSpec != "" {
-		dayOffset := weather.GetDateOffset(*dateSpec)
-
-		if *timeOfDay != "" {
-			// Date + time specific running weather
-			display.DisplayDateTimeBasedRunningWeatherWithDistanceAndDust(weatherData, coord.Name, *dateSpec, *timeOfDay, dayOffset, distanceCategory, airQuality)
-		} else {
-			// Date specific running weather (full day)
-			// Get average dust level for the day
-			dustLevel := weather.GetCurrentDustLevel(airQuality)
-			display.DisplayDateBasedRunningWeatherWithDistanceAndDust(weatherData, coord.Name, *dateSpec, dayOffset, distanceCategory, dustLevel)
+	// Wrap the provider with the on-disk response cache
+	if !cacheConfig.Disabled || offline {
+		var err error
+		cacheDir := cacheConfig.Dir
+		if cacheDir == "" {
+			cacheDir, err = weather.DefaultCacheDir()
+		}
+		if err == nil {
+			provider = weather.NewCachedProvider(provider, cacheDir, cacheConfig.TTLDuration(weather.DefaultCacheTTL), offline)
+		} else if offline {
+			log.Fatal(i18n.T("error.cache_dir", err))
 		}
-	} else if *timeOfDay != "" {
-		// Time-specific running weather
-		display.DisplayTimeBasedRunningWeatherWithDistanceAndDust(weatherData, coord.Name, *timeOfDay, requiredDays, distanceCategory, airQuality)
-	} else {
-		// Current running weather
-		dustLevel := weather.GetCurrentDustLevel(airQuality)
-		display.DisplayRunningWeatherWithDistanceAndDust(weatherData, coord.Name, distanceCategory, dustLevel)
 	}
-}
\ No newline at end of file
+
+	return provider
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"runcast/internal/i18n"
+	"runcast/internal/server"
+)
+
+// runServe runs the HTTP API server until it is interrupted
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Println(i18n.T("help.serve"))
+	}
+	addr := flags.String("addr", server.DefaultAddr, i18n.T("flag.addr"))
+	cacheTTL := flags.Duration("cache-ttl", server.DefaultCacheTTL, i18n.T("flag.cache_ttl"))
+	offline := flags.Bool("offline", false, i18n.T("flag.offline"))
+	lang := flags.String("lang", "", i18n.T("flag.lang"))
+	flags.Parse(args)
+
+	if *lang != "" && !i18n.IsSupported(*lang) {
+		fmt.Println(i18n.T("error.invalid_language", *lang))
+		fmt.Println(i18n.T("hint.valid_language", strings.Join(i18n.SupportedLanguages(), ", ")))
+		return
+	}
+
+	cfg := loadConfig(*lang)
+	srv := server.New(newProvider(cfg, *offline), cfg)
+	srv.CacheTTL = *cacheTTL
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Print(i18n.T("serve.listening", *addr))
+	if err := srv.ListenAndServe(ctx, *addr); err != nil {
+		log.Fatal(err)
+	}
+	log.Print(i18n.T("serve.stopped"))
+}